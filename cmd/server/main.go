@@ -17,15 +17,30 @@ func main() {
 	}
 
 	// Initialize logger with config
-	if err := logger.Init(cfg.LogLevel); err != nil {
+	if err := logger.Init(cfg.LogLevel, logger.WithFileOutput(logger.FileOutput{
+		Path:       cfg.LogFile,
+		MaxSizeMB:  cfg.LogFileMaxSizeMB,
+		MaxBackups: cfg.LogFileMaxBackups,
+		MaxAgeDays: cfg.LogFileMaxAgeDays,
+	})); err != nil {
 		os.Stderr.WriteString("Failed to initialize logger: " + err.Error() + "\n")
 		os.Exit(1)
 	}
 	defer logger.Sync() // Sync logger on exit
+	logger.SetQuietMethods(cfg.QuietLogMethods)
+	logger.SetRedactedFields(cfg.RedactLogFields)
+	logger.SetMetricsOrganizations(cfg.MetricsOrganizations)
 
 	logger.Get().Infow("Starting catalog service",
 		"environment", cfg.Environment,
-		"log_level", cfg.LogLevel)
+		"profile", cfg.Profile,
+		"log_level", cfg.LogLevel,
+		"log_file", cfg.LogFile)
+
+	if cfg.JWTSecretGenerated {
+		logger.Get().Warn("JWT_SECRET_KEY is not set, using a randomly generated secret: " +
+			"issued tokens stop working on restart and are not shared between instances. Do not use this outside local development")
+	}
 
 	// Create and start application
 	application := app.NewApp(cfg)
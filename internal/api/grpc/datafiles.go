@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+// dataFilePattern selects the files loaded when the data path is a directory
+const dataFilePattern = "*.yaml"
+
+// LoadOptions control how services files are validated when they are loaded
+type LoadOptions struct {
+	// StrictYAML rejects unknown fields (e.g. a misspelled "descripton") instead of ignoring them
+	StrictYAML bool
+	// FutureTimestamps is how created_at/updated_at values later than now plus TimestampSkew are handled,
+	// empty ignores them
+	FutureTimestamps model.FutureTimestampPolicy
+	// TimestampSkew tolerates clock drift between the data's source and this server
+	TimestampSkew time.Duration
+	// RequireHTTPSURLs rejects services whose url is set but is not an absolute https URL
+	RequireHTTPSURLs bool
+	// RequireKnownOrganizations rejects services whose organization_id is not in the file's organizations
+	// list, when it has one
+	RequireKnownOrganizations bool
+	// NameNormalization is applied to service names and descriptions before they are validated,
+	// empty trims them
+	NameNormalization model.NameNormalization
+	// MaxServices caps the number of services loaded, 0 means unlimited
+	MaxServices int
+	// AllowMissing starts NewCatalogServerFromPath with an empty catalog when the data path does not exist,
+	// reloads still fail until it does
+	AllowMissing bool
+	// ShardCount splits service IDs across shards by consistent hashing, values up to 1 disable sharding.
+	// ListServices and GetService then only serve the services owned by ShardIndex.
+	ShardCount int
+	ShardIndex int
+}
+
+// checkFutureTimestamps applies the future timestamp policy to a parsed services file
+func (o LoadOptions) checkFutureTimestamps(sf *model.ServicesFile) error {
+	if o.FutureTimestamps == "" || o.FutureTimestamps == model.FutureTimestampsIgnore {
+		return nil
+	}
+
+	err := sf.CheckFutureTimestamps(time.Now().Add(o.TimestampSkew))
+	if err == nil {
+		return nil
+	}
+	if o.FutureTimestamps == model.FutureTimestampsReject {
+		logger.Get().Errorw("Future timestamp in services.yaml", "error", err)
+		return fmt.Errorf("invalid services.yaml: %w", err)
+	}
+	logger.Get().Warnw("Future timestamp in services.yaml", "error", err)
+	return nil
+}
+
+// LoadServicesFile reads the services file at path. When path is a directory, every *.yaml file in it is
+// loaded in sorted filename order and merged into one services file; a service or organization ID defined
+// in more than one file is an error naming both files.
+func LoadServicesFile(path string, loadOpts LoadOptions) (*model.ServicesFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return readServicesFile(path, loadOpts)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, dataFilePattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data files in %s: %w", path, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s data files found in %s", dataFilePattern, path)
+	}
+	sort.Strings(files)
+
+	merged := &model.ServicesFile{}
+	definedIn := make(map[string]string)
+	orgDefinedIn := make(map[string]string)
+	for _, file := range files {
+		sf, err := readServicesFile(file, loadOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range sf.Services {
+			if other, ok := definedIn[s.ID]; ok && other != file {
+				return nil, fmt.Errorf("duplicate service ID %q defined in both %s and %s", s.ID, filepath.Base(other), filepath.Base(file))
+			}
+			definedIn[s.ID] = file
+		}
+		for _, o := range sf.Organizations {
+			if other, ok := orgDefinedIn[o.ID]; ok {
+				return nil, fmt.Errorf("duplicate organization %q defined in both %s and %s", o.ID, filepath.Base(other), filepath.Base(file))
+			}
+			orgDefinedIn[o.ID] = file
+		}
+		merged.Services = append(merged.Services, sf.Services...)
+		merged.Organizations = append(merged.Organizations, sf.Organizations...)
+	}
+
+	logger.Get().Infow("Loaded data directory", "path", path, "files_count", len(files), "services_count", len(merged.Services))
+	return merged, nil
+}
+
+// readServicesFile reads and parses a single services file, streaming its services (see parseServicesFileFrom)
+func readServicesFile(path string, loadOpts LoadOptions) (*model.ServicesFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	sf, err := parseServicesFileFrom(file, loadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return sf, nil
+}
@@ -0,0 +1,15 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ankittk/catalog-service/internal/interceptor"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// recordLocale adds the locale chosen by the locale interceptor to the request log
+func recordLocale(ctx context.Context, reqLogger *logger.RequestLogger) {
+	if locale := interceptor.LocaleFromContext(ctx); locale != "" {
+		reqLogger.SetLocale(locale)
+	}
+}
@@ -0,0 +1,15 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// recordOrganization labels the request log and metrics with the organization of the authenticated caller
+func recordOrganization(ctx context.Context, reqLogger *logger.RequestLogger) {
+	if claims, ok := auth.ClaimsFromContext(ctx); ok && claims != nil {
+		reqLogger.SetOrganization(claims.Organization)
+	}
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+const (
+	// RequestIDMetadataKey carries the request ID in incoming metadata and in response trailers
+	RequestIDMetadataKey = "x-request-id"
+
+	// maxRequestIDLength bounds caller-supplied request IDs before they are logged and echoed
+	maxRequestIDLength = 128
+)
+
+// echoRequestID adopts the caller's request ID when one is sent, then returns the request's trace ID
+// in the response trailer so clients can quote it in support tickets
+func echoRequestID(ctx context.Context, reqLogger *logger.RequestLogger) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDLength {
+			reqLogger.SetTraceID(ids[0])
+		}
+	}
+
+	// SetTrailer only fails outside a gRPC call, e.g. when handlers are invoked directly in tests
+	_ = gogrpc.SetTrailer(ctx, metadata.Pairs(RequestIDMetadataKey, reqLogger.TraceID()))
+}
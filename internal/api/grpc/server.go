@@ -1,8 +1,12 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,41 +24,198 @@ type Server struct {
 	v1.UnimplementedCatalogServiceServer
 	svc     *service.CatalogService
 	metrics *logger.MetricsLogger
+	// loadOpts apply to the services file on startup and on every reload
+	loadOpts LoadOptions
 }
 
-// NewCatalogServerFromYAML creates a new server by parsing YAML data
-func NewCatalogServerFromYAML(yamlData []byte) (*Server, error) {
+// NewCatalogServerFromYAML creates a new server by parsing YAML data as described by loadOpts,
+// applying opts to the catalog service
+func NewCatalogServerFromYAML(yamlData []byte, loadOpts LoadOptions, opts ...service.Option) (*Server, error) {
 	logger.Get().Info("Initializing catalog server from YAML data")
 
-	var sf model.ServicesFile
-	if err := yaml.Unmarshal(yamlData, &sf); err != nil {
-		logger.Get().Errorw("Failed to parse services.yaml", "error", err)
-		return nil, fmt.Errorf("failed to parse services.yaml: %w", err)
+	sf, err := parseServicesFile(yamlData, loadOpts)
+	if err != nil {
+		return nil, err
+	}
+	return newCatalogServer(sf, loadOpts, opts...)
+}
+
+// NewCatalogServerFromReader creates a new server by decoding YAML from r one service at a time,
+// without holding the file in memory (see parseServicesFileFrom), applying opts to the catalog service
+func NewCatalogServerFromReader(r io.Reader, loadOpts LoadOptions, opts ...service.Option) (*Server, error) {
+	logger.Get().Info("Initializing catalog server from YAML stream")
+
+	sf, err := parseServicesFileFrom(r, loadOpts)
+	if err != nil {
+		return nil, err
+	}
+	return newCatalogServer(sf, loadOpts, opts...)
+}
+
+// NewCatalogServerFromPath creates a new server from a services file, or from a directory of them
+// (see LoadServicesFile), applying opts to the catalog service
+func NewCatalogServerFromPath(path string, loadOpts LoadOptions, opts ...service.Option) (*Server, error) {
+	logger.Get().Infow("Initializing catalog server from data path", "path", path)
+
+	sf, err := LoadServicesFile(path, loadOpts)
+	if errors.Is(err, fs.ErrNotExist) && loadOpts.AllowMissing {
+		logger.Get().Warnw("Data file does not exist, starting with an empty catalog", "path", path)
+		sf, err = &model.ServicesFile{}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
+	return newCatalogServer(sf, loadOpts, opts...)
+}
 
+// newCatalogServer creates a server serving the services of a parsed services file, failing with
+// ResourceExhausted when they exceed loadOpts.MaxServices
+func newCatalogServer(sf *model.ServicesFile, loadOpts LoadOptions, opts ...service.Option) (*Server, error) {
 	// Create a local store with the parsed services
-	store := &model.Store{}
-	store.SetServices(sf.Services)
-	catalogService := service.NewCatalogService(store)
+	store := model.NewStore(loadOpts.MaxServices)
+	if err := store.SetServices(sf.Services); err != nil {
+		logger.Get().Errorw("Services file exceeds the store limit", "error", err)
+		return nil, status.Errorf(codes.ResourceExhausted, "failed to load services: %v", err)
+	}
+	store.SetOrganizations(sf.Organizations)
+	if loadOpts.ShardCount > 1 {
+		store.SetSharding(model.NewHashRing(loadOpts.ShardCount), loadOpts.ShardIndex)
+		logger.Get().Infow("Serving local shard", "shard_index", loadOpts.ShardIndex, "shard_count", loadOpts.ShardCount)
+	}
+	catalogService := service.NewCatalogService(store, opts...)
 
-	logger.Get().Infow("Catalog server initialized successfully", "services_count", len(sf.Services))
+	logger.Get().Infow("Catalog server initialized successfully",
+		"services_count", len(sf.Services),
+		"schema_version", sf.EffectiveSchemaVersion())
 
 	return &Server{
-		svc:     catalogService,
-		metrics: logger.NewMetricsLogger(),
+		svc:      catalogService,
+		metrics:  logger.NewMetricsLogger(),
+		loadOpts: loadOpts,
 	}, nil
 }
 
+// Reload parses YAML data and atomically swaps it in as the served catalog.
+// On error the current catalog keeps being served unchanged.
+func (s *Server) Reload(yamlData []byte) error {
+	sf, err := parseServicesFile(yamlData, s.loadOpts)
+	if err != nil {
+		return err
+	}
+	return s.replace(sf)
+}
+
+// ReloadFromPath loads a services file or directory like NewCatalogServerFromPath and atomically swaps it in.
+// On error the current catalog keeps being served unchanged.
+func (s *Server) ReloadFromPath(path string) error {
+	sf, err := LoadServicesFile(path, s.loadOpts)
+	if err != nil {
+		return err
+	}
+	return s.replace(sf)
+}
+
+// Services returns the served services, see service.CatalogService.Services
+func (s *Server) Services() []*model.Service {
+	return s.svc.Services()
+}
+
+// Subscribe registers for catalog change events, see service.CatalogService.Subscribe
+func (s *Server) Subscribe(buffer int) (<-chan service.Event, func()) {
+	return s.svc.Subscribe(buffer)
+}
+
+// replace swaps in the services of a parsed services file
+func (s *Server) replace(sf *model.ServicesFile) error {
+	if err := s.svc.ReplaceServices(sf.Services); err != nil {
+		logger.Get().Errorw("Services file exceeds the store limit", "error", err)
+		return err
+	}
+	s.svc.ReplaceOrganizations(sf.Organizations)
+	logger.Get().Infow("Catalog reloaded successfully",
+		"services_count", len(sf.Services),
+		"schema_version", sf.EffectiveSchemaVersion())
+	return nil
+}
+
+// parseServicesFile parses YAML data into a services file with a supported schema version,
+// enforcing loadOpts
+func parseServicesFile(yamlData []byte, loadOpts LoadOptions) (*model.ServicesFile, error) {
+	var sf model.ServicesFile
+	if err := decodeYAML(yamlData, &sf, loadOpts.StrictYAML); err != nil {
+		logger.Get().Errorw("Failed to parse services.yaml", "error", err)
+		return nil, fmt.Errorf("failed to parse services.yaml: %w", err)
+	}
+
+	if err := loadOpts.checkServicesFile(&sf); err != nil {
+		return nil, err
+	}
+	return &sf, nil
+}
+
+// checkServicesFile normalizes a decoded services file and validates it against loadOpts
+func (o LoadOptions) checkServicesFile(sf *model.ServicesFile) error {
+	// Fail fast on files written for a schema this release does not understand
+	if err := sf.CheckSchemaVersion(); err != nil {
+		logger.Get().Errorw("Unsupported services.yaml schema version", "schema_version", sf.SchemaVersion, "error", err)
+		return err
+	}
+
+	// Normalize before validating, so a whitespace-only name is reported as missing
+	sf.Normalize(o.NameNormalization)
+	if err := sf.CheckNames(); err != nil {
+		logger.Get().Errorw("Service without a name in services.yaml", "error", err)
+		return fmt.Errorf("invalid services.yaml: %w", err)
+	}
+
+	// Version IDs identify a version within its service, so they must be unique there
+	if err := sf.CheckVersionIDs(); err != nil {
+		logger.Get().Errorw("Duplicate version ID in services.yaml", "error", err)
+		return fmt.Errorf("invalid services.yaml: %w", err)
+	}
+
+	// Display names are looked up by organization ID, so each may be listed once
+	if err := sf.CheckOrganizations(); err != nil {
+		logger.Get().Errorw("Invalid organization in services.yaml", "error", err)
+		return fmt.Errorf("invalid services.yaml: %w", err)
+	}
+	if o.RequireKnownOrganizations {
+		if err := sf.CheckKnownOrganizations(); err != nil {
+			logger.Get().Errorw("Unknown organization in services.yaml", "error", err)
+			return fmt.Errorf("invalid services.yaml: %w", err)
+		}
+	}
+
+	if err := o.checkFutureTimestamps(sf); err != nil {
+		return err
+	}
+
+	if o.RequireHTTPSURLs {
+		if err := sf.CheckHTTPSURLs(); err != nil {
+			logger.Get().Errorw("Non-https URL in services.yaml", "error", err)
+			return fmt.Errorf("invalid services.yaml: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // ListServices returns a list of all services
 func (s *Server) ListServices(ctx context.Context, req *v1.ListServicesRequest) (*v1.ListServicesResponse, error) {
 	// Create request logger for structured logging
 	reqLogger := logger.NewRequestLogger("ListServices", "/v1/services")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
 	reqLogger.AddField("page_size", req.GetPageSize())
 	reqLogger.AddField("page_token", req.GetPageToken())
 	reqLogger.AddField("organization_id", req.GetOrganizationId())
+	reqLogger.AddField("organization_ids", req.GetOrganizationIds())
 	reqLogger.AddField("search_query", req.GetSearchQuery())
 	reqLogger.AddField("sort_by", req.GetSortBy())
 	reqLogger.AddField("sort_order", req.GetSortOrder())
+	reqLogger.AddField("version", req.GetVersion())
+	reqLogger.AddField("ids_only", req.GetIdsOnly())
 
 	reqLogger.LogRequest()
 
@@ -62,8 +223,9 @@ func (s *Server) ListServices(ctx context.Context, req *v1.ListServicesRequest)
 	if ctx.Err() != nil {
 		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
 		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-			"method": "ListServices",
-			"status": "cancelled",
+			"method":       "ListServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
 		})
 		return nil, status.Error(codes.Canceled, "request cancelled")
 	}
@@ -84,8 +246,9 @@ func (s *Server) ListServices(ctx context.Context, req *v1.ListServicesRequest)
 
 	// Log metrics for request count
 	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-		"method": "ListServices",
-		"status": statusCode.String(),
+		"method":       "ListServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
 	})
 
 	if err == nil {
@@ -97,10 +260,110 @@ func (s *Server) ListServices(ctx context.Context, req *v1.ListServicesRequest)
 	return resp, err
 }
 
+// CountServices returns the number of services matching the list filters
+func (s *Server) CountServices(ctx context.Context, req *v1.CountServicesRequest) (*v1.CountServicesResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("CountServices", "/v1/services:count")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("organization_id", req.GetOrganizationId())
+	reqLogger.AddField("search_query", req.GetSearchQuery())
+	reqLogger.AddField("version", req.GetVersion())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "CountServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.CountServices(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "CountServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ExportServices passes every service matching the list filters to send, for the JSON Lines export
+// served by the HTTP server outside the gateway
+func (s *Server) ExportServices(ctx context.Context, req *v1.ListServicesRequest, send func(*v1.Service) error) error {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ExportServices", "/v1/services:export")
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("organization_id", req.GetOrganizationId())
+	reqLogger.AddField("organization_ids", req.GetOrganizationIds())
+	reqLogger.AddField("search_query", req.GetSearchQuery())
+	reqLogger.AddField("sort_by", req.GetSortBy())
+	reqLogger.AddField("sort_order", req.GetSortOrder())
+	reqLogger.AddField("version", req.GetVersion())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ExportServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return status.Error(codes.Canceled, "request cancelled")
+	}
+
+	exported, err := s.svc.ExportServices(ctx, req, send)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ExportServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+	s.metrics.LogHistogram("grpc_response_size", float64(exported), map[string]string{
+		"method": "ExportServices",
+	})
+
+	return err
+}
+
 // GetService returns a specific service by ID
 func (s *Server) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1.GetServiceResponse, error) {
 	// Create request logger for structured logging
 	reqLogger := logger.NewRequestLogger("GetService", "/v1/services/{id}")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
 	reqLogger.AddField("service_id", req.GetId())
 
 	reqLogger.LogRequest()
@@ -109,8 +372,9 @@ func (s *Server) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1
 	if ctx.Err() != nil {
 		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
 		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-			"method": "GetService",
-			"status": "cancelled",
+			"method":       "GetService",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
 		})
 		return nil, status.Error(codes.Canceled, "request cancelled")
 	}
@@ -129,8 +393,103 @@ func (s *Server) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1
 	reqLogger.LogResponse(int(statusCode), err)
 
 	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-		"method": "GetService",
-		"status": statusCode.String(),
+		"method":       "GetService",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// BatchGetServices returns several services by ID, listing the IDs that were not found
+func (s *Server) BatchGetServices(ctx context.Context, req *v1.BatchGetServicesRequest) (*v1.BatchGetServicesResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("BatchGetServices", "/v1/services:batchGet")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("ids", req.GetIds())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "BatchGetServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.BatchGetServices(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "BatchGetServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ListServicesDelta returns the services changed since a token from an earlier call
+func (s *Server) ListServicesDelta(ctx context.Context, req *v1.ListServicesDeltaRequest) (*v1.ListServicesDeltaResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ListServicesDelta", "/v1/services:delta")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("since_token", req.GetSinceToken())
+	reqLogger.AddField("page_size", req.GetPageSize())
+	reqLogger.AddField("page_token", req.GetPageToken())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ListServicesDelta",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.ListServicesDelta(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	} else {
+		reqLogger.AddField("services_count", len(resp.GetServices()))
+		reqLogger.AddField("deleted_count", len(resp.GetDeletedServices()))
+		reqLogger.AddField("has_next_page", resp.GetNextPageToken() != "")
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ListServicesDelta",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
 	})
 
 	return resp, err
@@ -140,6 +499,9 @@ func (s *Server) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1
 func (s *Server) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersionsRequest) (*v1.GetServiceVersionsResponse, error) {
 	// Create request logger for structured logging
 	reqLogger := logger.NewRequestLogger("GetServiceVersions", "/v1/services/{id}/versions")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
 	reqLogger.AddField("service_id", req.GetServiceId())
 
 	reqLogger.LogRequest()
@@ -148,8 +510,9 @@ func (s *Server) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersi
 	if ctx.Err() != nil {
 		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
 		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-			"method": "GetServiceVersions",
-			"status": "cancelled",
+			"method":       "GetServiceVersions",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
 		})
 		return nil, status.Error(codes.Canceled, "request cancelled")
 	}
@@ -168,8 +531,9 @@ func (s *Server) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersi
 	reqLogger.LogResponse(int(statusCode), err)
 
 	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
-		"method": "GetServiceVersions",
-		"status": statusCode.String(),
+		"method":       "GetServiceVersions",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
 	})
 
 	if err == nil {
@@ -180,3 +544,594 @@ func (s *Server) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersi
 
 	return resp, err
 }
+
+// StreamServiceVersions streams the versions of a specific service in chunks
+func (s *Server) StreamServiceVersions(req *v1.StreamServiceVersionsRequest, stream v1.CatalogService_StreamServiceVersionsServer) error {
+	ctx := stream.Context()
+
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("StreamServiceVersions", "/v1/services/{service_id}/versions:stream")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("service_id", req.GetServiceId())
+	reqLogger.AddField("chunk_size", req.GetChunkSize())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "StreamServiceVersions",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return status.Error(codes.Canceled, "request cancelled")
+	}
+
+	err := s.svc.StreamServiceVersions(req, stream)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "StreamServiceVersions",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return err
+}
+
+// GetServiceHistory returns the version timeline of a service
+func (s *Server) GetServiceHistory(ctx context.Context, req *v1.GetServiceHistoryRequest) (*v1.GetServiceHistoryResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("GetServiceHistory", "/v1/services/{service_id}/history")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("service_id", req.GetServiceId())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "GetServiceHistory",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.GetServiceHistory(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "GetServiceHistory",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// DiffServices compares two services, or two versions of one service
+func (s *Server) DiffServices(ctx context.Context, req *v1.DiffServicesRequest) (*v1.DiffServicesResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("DiffServices", "/v1/services/{service_id}:diff")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("service_id", req.GetServiceId())
+	reqLogger.AddField("other_service_id", req.GetOtherServiceId())
+	reqLogger.AddField("base_version_id", req.GetBaseVersionId())
+	reqLogger.AddField("target_version_id", req.GetTargetVersionId())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "DiffServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.DiffServices(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "DiffServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ListRecentVersions returns versions across all services, most recently updated first
+func (s *Server) ListRecentVersions(ctx context.Context, req *v1.ListRecentVersionsRequest) (*v1.ListRecentVersionsResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ListRecentVersions", "/v1/versions")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("page_size", req.GetPageSize())
+	reqLogger.AddField("page_token", req.GetPageToken())
+	reqLogger.AddField("updated_after", req.GetUpdatedAfter().AsTime())
+	reqLogger.AddField("is_active", req.GetIsActive())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ListRecentVersions",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.ListRecentVersions(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ListRecentVersions",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	if err == nil {
+		s.metrics.LogHistogram("grpc_response_size", float64(len(resp.GetVersions())), map[string]string{
+			"method": "ListRecentVersions",
+		})
+	}
+
+	return resp, err
+}
+
+// SearchVersions finds versions across all services whose version string or description matches a query
+func (s *Server) SearchVersions(ctx context.Context, req *v1.SearchVersionsRequest) (*v1.SearchVersionsResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("SearchVersions", "/v1/versions:search")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("page_size", req.GetPageSize())
+	reqLogger.AddField("page_token", req.GetPageToken())
+	reqLogger.AddField("search_query", req.GetSearchQuery())
+	reqLogger.AddField("is_active", req.GetIsActive())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "SearchVersions",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.SearchVersions(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "SearchVersions",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	if err == nil {
+		s.metrics.LogHistogram("grpc_response_size", float64(len(resp.GetResults())), map[string]string{
+			"method": "SearchVersions",
+		})
+	}
+
+	return resp, err
+}
+
+// DescribeCatalog returns aggregate catalog statistics
+func (s *Server) DescribeCatalog(ctx context.Context, req *v1.DescribeCatalogRequest) (*v1.DescribeCatalogResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("DescribeCatalog", "/v1/catalog")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "DescribeCatalog",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.DescribeCatalog(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "DescribeCatalog",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ListOrganizations returns the organizations with their display names
+func (s *Server) ListOrganizations(ctx context.Context, req *v1.ListOrganizationsRequest) (*v1.ListOrganizationsResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ListOrganizations", "/v1/organizations")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ListOrganizations",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.ListOrganizations(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ListOrganizations",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ActivateVersionAcrossServices activates a version in every service that has it
+func (s *Server) ActivateVersionAcrossServices(ctx context.Context, req *v1.ActivateVersionAcrossServicesRequest) (*v1.ActivateVersionAcrossServicesResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ActivateVersionAcrossServices", "/v1/versions:activate")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("version", req.GetVersion())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ActivateVersionAcrossServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.ActivateVersionAcrossServices(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ActivateVersionAcrossServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// CreateServices adds a batch of new services and reports the outcome of each (admin only)
+func (s *Server) CreateServices(ctx context.Context, req *v1.CreateServicesRequest) (*v1.CreateServicesResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("CreateServices", "/v1/services:batchCreate")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("services_count", len(req.GetServices()))
+	reqLogger.AddField("transactional", req.GetTransactional())
+	reqLogger.AddField("has_idempotency_key", req.GetIdempotencyKey() != "")
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "CreateServices",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.CreateServices(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	} else {
+		reqLogger.AddField("created_count", resp.GetCreatedCount())
+		reqLogger.AddField("failed_count", resp.GetFailedCount())
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "CreateServices",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// TouchService bumps a service's updated_at without changing anything else (admin only)
+func (s *Server) TouchService(ctx context.Context, req *v1.TouchServiceRequest) (*v1.TouchServiceResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("TouchService", "/v1/services/{id}:touch")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("service_id", req.GetId())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "TouchService",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.TouchService(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "TouchService",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ListAuditEvents returns recorded catalog changes, newest first (admin only)
+func (s *Server) ListAuditEvents(ctx context.Context, req *v1.ListAuditEventsRequest) (*v1.ListAuditEventsResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ListAuditEvents", "/v1/audit/events")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("page_size", req.GetPageSize())
+	reqLogger.AddField("page_token", req.GetPageToken())
+	reqLogger.AddField("actor", req.GetActor())
+	reqLogger.AddField("service_id", req.GetServiceId())
+	reqLogger.AddField("action", req.GetAction())
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ListAuditEvents",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp, err := s.svc.ListAuditEvents(ctx, req)
+
+	statusCode := codes.OK
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			statusCode = st.Code()
+		} else {
+			statusCode = codes.Internal
+		}
+	}
+
+	reqLogger.LogResponse(int(statusCode), err)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ListAuditEvents",
+		"status":       statusCode.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, err
+}
+
+// ValidateCatalog reports every problem in a services file without loading it. The file is checked with the
+// options the served catalog was loaded with, so an error is reported for everything a reload would fail on;
+// it also reports problems the loader accepts (see validateServicesData), so a valid file always reloads but
+// not every reloadable file is valid.
+func (s *Server) ValidateCatalog(ctx context.Context, req *v1.ValidateCatalogRequest) (*v1.ValidateCatalogResponse, error) {
+	// Create request logger for structured logging
+	reqLogger := logger.NewRequestLogger("ValidateCatalog", "/v1/catalog:validate")
+	echoRequestID(ctx, reqLogger)
+	recordLocale(ctx, reqLogger)
+	recordOrganization(ctx, reqLogger)
+	reqLogger.AddField("content_bytes", len(req.GetContent()))
+
+	reqLogger.LogRequest()
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		reqLogger.LogResponse(int(codes.Canceled), ctx.Err())
+		s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+			"method":       "ValidateCatalog",
+			"status":       "cancelled",
+			"organization": reqLogger.Organization(),
+		})
+		return nil, status.Error(codes.Canceled, "request cancelled")
+	}
+
+	resp := validateServicesData([]byte(req.GetContent()), s.loadOpts)
+
+	reqLogger.AddField("valid", resp.GetValid())
+	reqLogger.AddField("issues_count", len(resp.GetIssues()))
+	reqLogger.LogResponse(int(codes.OK), nil)
+
+	s.metrics.LogCounter("grpc_requests_total", 1, map[string]string{
+		"method":       "ValidateCatalog",
+		"status":       codes.OK.String(),
+		"organization": reqLogger.Organization(),
+	})
+
+	return resp, nil
+}
+
+// decodeYAML unmarshals YAML data into out; in strict mode unknown fields are an error naming the offending key
+func decodeYAML(yamlData []byte, out interface{}, strict bool) error {
+	if !strict {
+		return yaml.Unmarshal(yamlData, out)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlData))
+	decoder.KnownFields(true)
+	// An empty document is valid, matching yaml.Unmarshal
+	if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// MutatingMethods are the RPCs that change catalog state, rejected in read-only mode. Every new RPC that
+// writes must be added here.
+var MutatingMethods = []string{
+	"/v1.CatalogService/ActivateVersionAcrossServices",
+	"/v1.CatalogService/CreateServices",
+	"/v1.CatalogService/TouchService",
+}
+
+// ExportMethod names the HTTP export to interceptors, like the full method name of an RPC; it is not a
+// CatalogService RPC
+const ExportMethod = "/v1.CatalogService/ExportServices"
+
+// ExperimentalMethods maps RPCs, and the export, that ship behind a feature flag to the flag that enables them
+var ExperimentalMethods = map[string]string{
+	"/v1.CatalogService/GetServiceHistory":             "service_history",
+	"/v1.CatalogService/ActivateVersionAcrossServices": "bulk_activate",
+	"/v1.CatalogService/StreamServiceVersions":         "stream",
+	"/v1.CatalogService/SearchVersions":                "search",
+	ExportMethod:                                       "export",
+}
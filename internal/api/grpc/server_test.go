@@ -0,0 +1,639 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/interceptor"
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+func TestNewCatalogServerFromYAML_SchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{name: "absent schema version", yaml: "services: []\n"},
+		{name: "supported schema version", yaml: "schema_version: 1\nservices: []\n"},
+		{name: "unsupported schema version", yaml: "schema_version: 99\nservices: []\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := NewCatalogServerFromYAML([]byte(tt.yaml), LoadOptions{})
+			if tt.wantErr {
+				assert.Nil(t, srv)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "schema_version 99")
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, srv)
+			}
+		})
+	}
+}
+
+func TestServer_Reload(t *testing.T) {
+	srv, err := NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), LoadOptions{})
+	assert.NoError(t, err)
+
+	// a rejected reload keeps serving the current catalog
+	err = srv.Reload([]byte("schema_version: 99\nservices: []\n"))
+	assert.Error(t, err)
+	resp, err := srv.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.TotalCount)
+
+	err = srv.Reload([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`))
+	assert.NoError(t, err)
+	resp, err = srv.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.TotalCount)
+}
+
+func TestNewCatalogServerFromYAML_StrictYAML(t *testing.T) {
+	data := []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    descripton: "Handles user authentication"
+    organization_id: "org-1"
+`)
+
+	srv, err := NewCatalogServerFromYAML(data, LoadOptions{StrictYAML: true})
+	assert.Nil(t, srv)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "field descripton not found")
+	}
+
+	// lenient mode loads the service and leaves the misspelled field empty
+	srv, err = NewCatalogServerFromYAML(data, LoadOptions{})
+	assert.NoError(t, err)
+	resp, err := srv.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "User Service", resp.Service.Name)
+	assert.Empty(t, resp.Service.Description)
+
+	// strict mode applies to reloads too, and a rejected reload keeps the current catalog
+	srv, err = NewCatalogServerFromYAML([]byte("services: []\n"), LoadOptions{StrictYAML: true})
+	assert.NoError(t, err)
+	assert.Error(t, srv.Reload(data))
+
+	_, err = NewCatalogServerFromYAML([]byte(""), LoadOptions{StrictYAML: true})
+	assert.NoError(t, err)
+}
+
+func TestNewCatalogServerFromYAML_FutureTimestamps(t *testing.T) {
+	servicesYAML := func(versionCreatedAt time.Time) []byte {
+		return []byte(fmt.Sprintf(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    created_at: "2024-05-01T10:00:00Z"
+    versions:
+      - id: "v1"
+        version: "v1.0.0"
+        created_at: %q
+`, versionCreatedAt.Format(time.RFC3339Nano)))
+	}
+	farFuture := servicesYAML(time.Now().AddDate(10, 0, 0))
+	nearFuture := servicesYAML(time.Now().Add(time.Minute))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		loadOpts LoadOptions
+		wantErr  string
+	}{
+		{
+			name:     "far future rejected",
+			data:     farFuture,
+			loadOpts: LoadOptions{FutureTimestamps: model.FutureTimestampsReject, TimestampSkew: 5 * time.Minute},
+			wantErr:  `version "v1" of service "svc-1" created_at`,
+		},
+		{
+			name:     "within skew accepted",
+			data:     nearFuture,
+			loadOpts: LoadOptions{FutureTimestamps: model.FutureTimestampsReject, TimestampSkew: 5 * time.Minute},
+		},
+		{
+			name:     "beyond zero skew rejected",
+			data:     nearFuture,
+			loadOpts: LoadOptions{FutureTimestamps: model.FutureTimestampsReject},
+			wantErr:  "is in the future",
+		},
+		{
+			name:     "far future only warned",
+			data:     farFuture,
+			loadOpts: LoadOptions{FutureTimestamps: model.FutureTimestampsWarn},
+		},
+		{
+			name: "check disabled by default",
+			data: farFuture,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := NewCatalogServerFromYAML(tt.data, tt.loadOpts)
+			if tt.wantErr != "" {
+				assert.Nil(t, srv)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewCatalogServerFromYAML_NameNormalization(t *testing.T) {
+	data := []byte(`
+services:
+  - id: "svc-1"
+    name: "  User   Service \t"
+    description: "  Manages  users  "
+`)
+
+	tests := []struct {
+		name            string
+		normalization   model.NameNormalization
+		wantName        string
+		wantDescription string
+	}{
+		{name: "padded name trimmed by default", wantName: "User   Service", wantDescription: "Manages  users"},
+		{name: "collapse", normalization: model.NameNormalizationCollapse, wantName: "User Service", wantDescription: "Manages  users"},
+		{name: "none", normalization: model.NameNormalizationNone, wantName: "  User   Service \t", wantDescription: "  Manages  users  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := NewCatalogServerFromYAML(data, LoadOptions{NameNormalization: tt.normalization})
+			assert.NoError(t, err)
+			resp, err := srv.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, resp.Service.Name)
+			assert.Equal(t, tt.wantDescription, resp.Service.Description)
+		})
+	}
+
+	t.Run("whitespace-only name rejected", func(t *testing.T) {
+		_, err := NewCatalogServerFromYAML([]byte("services:\n  - id: svc-1\n    name: \"   \"\n"), LoadOptions{})
+		assert.ErrorContains(t, err, `service "svc-1" has no name`)
+	})
+}
+
+func TestNewCatalogServerFromYAML_RequireHTTPSURLs(t *testing.T) {
+	servicesYAML := func(url string) []byte {
+		return []byte(fmt.Sprintf(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    url: %q
+`, url))
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		loadOpts LoadOptions
+		wantErr  string
+	}{
+		{name: "http rejected", url: "http://catalog.example.com/svc-1", loadOpts: LoadOptions{RequireHTTPSURLs: true}, wantErr: `service "svc-1" url "http://catalog.example.com/svc-1" must use https`},
+		{name: "other scheme rejected", url: "ftp://catalog.example.com/svc-1", loadOpts: LoadOptions{RequireHTTPSURLs: true}, wantErr: "must use https"},
+		{name: "relative rejected", url: "/services/svc-1", loadOpts: LoadOptions{RequireHTTPSURLs: true}, wantErr: "must be absolute"},
+		{name: "https accepted", url: "https://catalog.example.com/svc-1", loadOpts: LoadOptions{RequireHTTPSURLs: true}},
+		{name: "empty accepted", url: "", loadOpts: LoadOptions{RequireHTTPSURLs: true}},
+		{name: "http accepted without the flag", url: "http://catalog.example.com/svc-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := NewCatalogServerFromYAML(servicesYAML(tt.url), tt.loadOpts)
+			if tt.wantErr != "" {
+				assert.Nil(t, srv)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewCatalogServerFromYAML_RequireKnownOrganizations(t *testing.T) {
+	servicesYAML := func(orgs string) []byte {
+		return []byte(orgs + `
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`)
+	}
+	strict := LoadOptions{RequireKnownOrganizations: true}
+
+	tests := []struct {
+		name     string
+		orgs     string
+		loadOpts LoadOptions
+		wantErr  string
+	}{
+		{name: "unknown organization rejected", orgs: "organizations:\n  - id: org-1\n", loadOpts: strict, wantErr: `service "svc-2" belongs to unknown organization "org-2"`},
+		{name: "listed organizations accepted", orgs: "organizations:\n  - id: org-1\n  - id: org-2\n", loadOpts: strict},
+		{name: "accepted without a list", loadOpts: strict},
+		{name: "accepted without the flag", orgs: "organizations:\n  - id: org-1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := NewCatalogServerFromYAML(servicesYAML(tt.orgs), tt.loadOpts)
+			if tt.wantErr != "" {
+				assert.Nil(t, srv)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewCatalogServerFromYAML_DuplicateVersionIDs(t *testing.T) {
+	data := []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    versions:
+      - id: "v1"
+        version: "v1.0.0"
+      - id: "v1"
+        version: "v1.1.0"
+`)
+
+	srv, err := NewCatalogServerFromYAML(data, LoadOptions{})
+	assert.Nil(t, srv)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `service "svc-1" has more than one version with id "v1"`)
+	}
+
+	// The streaming loader and reloads reject it too, and a rejected reload keeps the current catalog
+	_, err = NewCatalogServerFromReader(bytes.NewReader(data), LoadOptions{})
+	assert.Error(t, err)
+	srv, err = NewCatalogServerFromYAML([]byte("services: []\n"), LoadOptions{})
+	assert.NoError(t, err)
+	assert.Error(t, srv.Reload(data))
+}
+
+func TestNewCatalogServerFromYAML_Organizations(t *testing.T) {
+	data := []byte(`
+organizations:
+  - id: "org-1"
+    display_name: "Platform Team"
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`)
+
+	srv, err := NewCatalogServerFromReader(bytes.NewReader(data), LoadOptions{})
+	assert.NoError(t, err)
+	resp, err := srv.ListOrganizations(context.Background(), &v1.ListOrganizationsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Organizations, 2) {
+		assert.Equal(t, "Platform Team", resp.Organizations[0].DisplayName)
+		assert.Equal(t, "org-2", resp.Organizations[1].DisplayName)
+	}
+	got, err := srv.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Platform Team", got.Service.OrganizationName)
+
+	// A reload swaps the display names along with the services
+	assert.NoError(t, srv.Reload([]byte("services:\n  - id: \"svc-1\"\n    name: \"User Service\"\n    organization_id: \"org-1\"\n")))
+	got, err = srv.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "org-1", got.Service.OrganizationName)
+
+	_, err = NewCatalogServerFromYAML([]byte(`
+organizations:
+  - id: "org-1"
+    display_name: "Platform Team"
+  - id: "org-1"
+    display_name: "Identity Team"
+services: []
+`), LoadOptions{})
+	assert.ErrorContains(t, err, `organization "org-1" is listed more than once`)
+}
+
+func TestNewCatalogServerFromYAML_MaxServices(t *testing.T) {
+	data := []byte(`
+services:
+  - id: "svc-1"
+    name: "One"
+  - id: "svc-2"
+    name: "Two"
+  - id: "svc-3"
+    name: "Three"
+`)
+
+	srv, err := NewCatalogServerFromYAML(data, LoadOptions{MaxServices: 2})
+	assert.Nil(t, srv)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "3 services exceed the limit of 2")
+
+	// an oversized reload is rejected and the current catalog keeps being served
+	srv, err = NewCatalogServerFromYAML([]byte("services:\n  - id: \"svc-1\"\n    name: \"One\"\n"), LoadOptions{MaxServices: 2})
+	assert.NoError(t, err)
+	err = srv.Reload(data)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	resp, err := srv.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Services, 1)
+
+	_, err = NewCatalogServerFromYAML(data, LoadOptions{})
+	assert.NoError(t, err)
+}
+
+func TestServer_RequestIDTrailer(t *testing.T) {
+	srv, err := NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), LoadOptions{})
+	assert.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := gogrpc.NewServer()
+	v1.RegisterCatalogServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := v1.NewCatalogServiceClient(conn)
+
+	t.Run("generated ID", func(t *testing.T) {
+		var trailer metadata.MD
+		_, err := client.ListServices(context.Background(), &v1.ListServicesRequest{}, gogrpc.Trailer(&trailer))
+		assert.NoError(t, err)
+		if ids := trailer.Get(RequestIDMetadataKey); assert.Len(t, ids, 1) {
+			assert.True(t, strings.HasPrefix(ids[0], "trace_"))
+		}
+	})
+
+	t.Run("propagated ID", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDMetadataKey, "ticket-42")
+		var trailer metadata.MD
+		_, err := client.GetService(ctx, &v1.GetServiceRequest{Id: "svc-1"}, gogrpc.Trailer(&trailer))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ticket-42"}, trailer.Get(RequestIDMetadataKey))
+	})
+
+	t.Run("failed call", func(t *testing.T) {
+		var trailer metadata.MD
+		_, err := client.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-9"}, gogrpc.Trailer(&trailer))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Len(t, trailer.Get(RequestIDMetadataKey), 1)
+	})
+}
+
+func TestServer_RecordsLocale(t *testing.T) {
+	srv, err := NewCatalogServerFromYAML([]byte("services: []\n"), LoadOptions{})
+	assert.NoError(t, err)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	t.Cleanup(func() { logger.SetLogger(previous) })
+	locale := interceptor.Locale("en", []string{"en", "de"})
+	info := &gogrpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.ListServices(ctx, req.(*v1.ListServicesRequest))
+	}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "supported locale", acceptLanguage: "de-DE", want: "de"},
+		{name: "unsupported locale falls back to default", acceptLanguage: "fr", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", tt.acceptLanguage))
+			_, err := locale(ctx, &v1.ListServicesRequest{}, info, handler)
+			assert.NoError(t, err)
+
+			for _, message := range []string{"Request started", "Request completed"} {
+				entries := logs.FilterMessage(message).All()
+				if assert.Len(t, entries, 1, message) {
+					assert.Equal(t, tt.want, entries[0].ContextMap()["locale"], message)
+				}
+			}
+			logs.TakeAll()
+		})
+	}
+}
+
+func TestServer_RecordsOrganization(t *testing.T) {
+	// The server's metrics logger is bound to the logger at creation
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	t.Cleanup(func() { logger.SetLogger(previous) })
+
+	srv, err := NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), LoadOptions{})
+	assert.NoError(t, err)
+	logs.TakeAll()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "from claims", ctx: context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1"}), want: "org-1"},
+		{name: "anonymous without claims", ctx: context.Background(), want: logger.AnonymousOrganization},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latency := logger.RequestLatency().WithLabelValues("GetService", "OK", tt.want)
+			before := latency.Snapshot().Count
+
+			_, err := srv.GetService(tt.ctx, &v1.GetServiceRequest{Id: "svc-1"})
+			assert.NoError(t, err)
+
+			assert.Equal(t, before+1, latency.Snapshot().Count)
+			metrics := logs.FilterMessage("Metric recorded").FilterField(zap.String("metric_name", "grpc_requests_total")).All()
+			if assert.Len(t, metrics, 1) {
+				assert.Equal(t, tt.want, metrics[0].ContextMap()["organization"])
+			}
+			logs.TakeAll()
+		})
+	}
+}
+
+func TestLoadServicesFile_Directory(t *testing.T) {
+	writeFile := func(t *testing.T, dir, name, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	t.Run("merges files in sorted order", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "payments.yaml", `
+services:
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`)
+		writeFile(t, dir, "identity.yaml", `
+schema_version: 1
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`)
+		writeFile(t, dir, "README.md", "not a data file")
+
+		sf, err := LoadServicesFile(dir, LoadOptions{})
+		assert.NoError(t, err)
+		if assert.Len(t, sf.Services, 2) {
+			assert.Equal(t, "svc-1", sf.Services[0].ID)
+			assert.Equal(t, "svc-2", sf.Services[1].ID)
+		}
+
+		srv, err := NewCatalogServerFromPath(dir, LoadOptions{})
+		assert.NoError(t, err)
+		resp, err := srv.ListServices(context.Background(), &v1.ListServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), resp.TotalCount)
+	})
+
+	t.Run("reports duplicate IDs across files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yaml", `
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`)
+		writeFile(t, dir, "b.yaml", `
+services:
+  - id: "svc-1"
+    name: "Other User Service"
+    organization_id: "org-2"
+`)
+
+		_, err := LoadServicesFile(dir, LoadOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `duplicate service ID "svc-1" defined in both a.yaml and b.yaml`)
+	})
+
+	t.Run("reports organizations listed in two files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yaml", "organizations:\n  - id: \"org-1\"\n    display_name: \"Platform Team\"\nservices: []\n")
+		writeFile(t, dir, "b.yaml", "organizations:\n  - id: \"org-1\"\nservices: []\n")
+
+		_, err := LoadServicesFile(dir, LoadOptions{})
+		assert.ErrorContains(t, err, `duplicate organization "org-1" defined in both a.yaml and b.yaml`)
+	})
+
+	t.Run("names the file that fails to parse", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yaml", "services: []\n")
+		writeFile(t, dir, "b.yaml", "schema_version: 99\nservices: []\n")
+
+		_, err := LoadServicesFile(dir, LoadOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "b.yaml")
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		_, err := LoadServicesFile(t.TempDir(), LoadOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "services.yaml", "services: []\n")
+
+		sf, err := LoadServicesFile(filepath.Join(dir, "services.yaml"), LoadOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, sf.Services)
+	})
+}
+
+func TestMethodSets_NameCatalogServiceRPCs(t *testing.T) {
+	svc := v1.File_v1_catalog_proto.Services().ByName("CatalogService")
+	methods := make(map[string]bool)
+	for i := 0; i < svc.Methods().Len(); i++ {
+		methods[fmt.Sprintf("/%s/%s", svc.FullName(), svc.Methods().Get(i).Name())] = true
+	}
+
+	for _, method := range MutatingMethods {
+		assert.True(t, methods[method], "mutating method %s is not a CatalogService RPC", method)
+	}
+	for method := range ExperimentalMethods {
+		if method == ExportMethod {
+			continue
+		}
+		assert.True(t, methods[method], "experimental method %s is not a CatalogService RPC", method)
+	}
+}
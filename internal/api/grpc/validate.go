@@ -0,0 +1,345 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// issueLinePattern splits "line N: message" errors reported by yaml.v3 and the model checks
+var issueLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// validationReport collects the issues found in a services file
+type validationReport struct {
+	issues []*v1.ValidationIssue
+}
+
+// add records an issue; a "line N: " prefix of message sets the line when line is 0
+func (r *validationReport) add(severity v1.ValidationIssue_Severity, line int, serviceID, versionID, field, message string) {
+	if m := issueLinePattern.FindStringSubmatch(message); m != nil {
+		if line == 0 {
+			line, _ = strconv.Atoi(m[1])
+		}
+		message = m[2]
+	}
+	r.issues = append(r.issues, &v1.ValidationIssue{
+		Severity:  severity,
+		Message:   message,
+		Line:      int32(line),
+		ServiceId: serviceID,
+		VersionId: versionID,
+		Field:     field,
+	})
+}
+
+func (r *validationReport) addError(line int, serviceID, versionID, field, format string, args ...interface{}) {
+	r.add(v1.ValidationIssue_SEVERITY_ERROR, line, serviceID, versionID, field, fmt.Sprintf(format, args...))
+}
+
+func (r *validationReport) addWarning(line int, serviceID, versionID, field, format string, args ...interface{}) {
+	r.add(v1.ValidationIssue_SEVERITY_WARNING, line, serviceID, versionID, field, fmt.Sprintf(format, args...))
+}
+
+// response returns the issues ordered by line, file-level issues without a line first
+func (r *validationReport) response(serviceCount int) *v1.ValidateCatalogResponse {
+	sort.SliceStable(r.issues, func(i, j int) bool {
+		return r.issues[i].Line < r.issues[j].Line
+	})
+	valid := true
+	for _, issue := range r.issues {
+		if issue.Severity == v1.ValidationIssue_SEVERITY_ERROR {
+			valid = false
+		}
+	}
+	return &v1.ValidateCatalogResponse{Valid: valid, Issues: r.issues, ServiceCount: int32(serviceCount)}
+}
+
+// validateServicesData runs the load-time checks of loadOpts over a services file, including the organizations
+// list (IDs present and listed once, and with RequireKnownOrganizations, services of listed organizations only),
+// plus integrity checks the loader does not enforce (duplicate service IDs and version strings, version
+// service_id consistency, a single active version), and reports every problem instead of stopping at the first.
+// Only a YAML syntax error ends validation early.
+func validateServicesData(yamlData []byte, loadOpts LoadOptions) *v1.ValidateCatalogResponse {
+	report := &validationReport{}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlData, &doc); err != nil {
+		report.addError(0, "", "", "", "%v", err)
+		return report.response(0)
+	}
+	if len(doc.Content) == 0 {
+		return report.response(0)
+	}
+	root := doc.Content[0]
+	checkNodes(report, root, loadOpts.StrictYAML)
+
+	// Decoding the checked tree, with invalid timestamps cleared, leaves only type mismatches to report
+	var sf model.ServicesFile
+	if err := root.Decode(&sf); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			report.addError(0, "", "", "", "%v", err)
+			return report.response(0)
+		}
+		for _, msg := range typeErr.Errors {
+			report.addError(0, "", "", "", "%s", msg)
+		}
+	}
+
+	if err := sf.CheckSchemaVersion(); err != nil {
+		report.addError(valueLine(root, "schema_version"), "", "", "schema_version", "%v", err)
+	}
+	if loadOpts.MaxServices > 0 && len(sf.Services) > loadOpts.MaxServices {
+		report.addError(0, "", "", "services", "%d services exceed the limit of %d", len(sf.Services), loadOpts.MaxServices)
+	}
+
+	var orgNodes []*yaml.Node
+	if orgs := mappingValue(root, "organizations"); orgs != nil && orgs.Kind == yaml.SequenceNode {
+		orgNodes = orgs.Content
+	}
+	validateOrganizations(report, sf.Organizations, orgNodes)
+
+	var serviceNodes []*yaml.Node
+	if services := mappingValue(root, "services"); services != nil && services.Kind == yaml.SequenceNode {
+		serviceNodes = services.Content
+	}
+	firstDefined := make(map[string]int)
+	knownOrgs := model.OrganizationIDs(sf.Organizations)
+	for i, svc := range sf.Services {
+		if svc == nil || i >= len(serviceNodes) {
+			continue
+		}
+		loadOpts.validateService(report, svc, serviceNodes[i], firstDefined)
+		if loadOpts.RequireKnownOrganizations {
+			if err := svc.CheckKnownOrganization(knownOrgs); err != nil {
+				report.addError(valueLine(serviceNodes[i], "organization_id"), svc.ID, "", "organization_id", "%v", err)
+			}
+		}
+	}
+
+	return report.response(len(sf.Services))
+}
+
+// validateOrganizations reports each organization without an ID or listed more than once, the problems
+// CheckOrganizations stops the loader at
+func validateOrganizations(report *validationReport, orgs []*model.Organization, nodes []*yaml.Node) {
+	firstDefined := make(map[string]int, len(orgs))
+	for i, o := range orgs {
+		if i >= len(nodes) {
+			break
+		}
+		node := nodes[i]
+		if o == nil || o.ID == "" {
+			report.addError(node.Line, "", "", "organizations", "organization %d has no id", i+1)
+			continue
+		}
+		if first, seen := firstDefined[o.ID]; seen {
+			report.addError(valueLine(node, "id"), "", "", "organizations", "duplicate organization ID %q, first defined at line %d", o.ID, first)
+			continue
+		}
+		firstDefined[o.ID] = node.Line
+	}
+}
+
+// validateService checks one service and its versions, firstDefined maps the IDs of earlier services to their lines
+func (o LoadOptions) validateService(report *validationReport, svc *model.Service, node *yaml.Node, firstDefined map[string]int) {
+	line := node.Line
+
+	switch first, seen := firstDefined[svc.ID]; {
+	case svc.ID == "":
+		report.addError(line, "", "", "id", "service id is required")
+	case seen:
+		report.addError(valueLine(node, "id"), svc.ID, "", "id", "duplicate service ID %q, first defined at line %d", svc.ID, first)
+	default:
+		firstDefined[svc.ID] = line
+	}
+
+	// The loader normalizes names before requiring them, so a whitespace-only name is missing
+	normalized := *svc
+	normalized.Normalize(o.NameNormalization)
+	if normalized.Name == "" {
+		report.addError(valueLine(node, "name"), svc.ID, "", "name", "name is required")
+	}
+
+	if err := model.CheckURL(svc.URL, o.RequireHTTPSURLs); err != nil {
+		report.addError(valueLine(node, "url"), svc.ID, "", "url", "%v", err)
+	}
+
+	if o.FutureTimestamps == model.FutureTimestampsWarn || o.FutureTimestamps == model.FutureTimestampsReject {
+		if err := svc.CheckFutureTimestamps(time.Now().Add(o.TimestampSkew)); err != nil {
+			if o.FutureTimestamps == model.FutureTimestampsReject {
+				report.addError(line, svc.ID, "", "", "%v", err)
+			} else {
+				report.addWarning(line, svc.ID, "", "", "%v", err)
+			}
+		}
+	}
+
+	var versionNodes []*yaml.Node
+	if versions := mappingValue(node, "versions"); versions != nil && versions.Kind == yaml.SequenceNode {
+		versionNodes = versions.Content
+	}
+	versionIDs := make(map[string]bool)
+	versionStrings := make(map[string]bool)
+	var active []string
+	for i, v := range svc.Versions {
+		if v == nil || i >= len(versionNodes) {
+			continue
+		}
+		vNode := versionNodes[i]
+
+		if v.ID != "" {
+			if versionIDs[v.ID] {
+				report.addError(valueLine(vNode, "id"), svc.ID, v.ID, "id", "duplicate version ID %q in service %q", v.ID, svc.ID)
+			}
+			versionIDs[v.ID] = true
+		}
+		if v.Version == "" {
+			report.addError(vNode.Line, svc.ID, v.ID, "version", "version is required")
+		} else {
+			if versionStrings[v.Version] {
+				report.addError(valueLine(vNode, "version"), svc.ID, v.ID, "version", "version %q is listed more than once in service %q", v.Version, svc.ID)
+			}
+			versionStrings[v.Version] = true
+		}
+		if v.ServiceID != "" && v.ServiceID != svc.ID {
+			report.addError(valueLine(vNode, "service_id"), svc.ID, v.ID, "service_id", "service_id %q does not match the enclosing service %q", v.ServiceID, svc.ID)
+		}
+		if v.IsActive {
+			active = append(active, v.Version)
+		}
+	}
+	if len(active) > 1 {
+		report.addError(line, svc.ID, "", "is_active", "%d versions are active (%s), at most one may be", len(active), strings.Join(active, ", "))
+	}
+}
+
+// knownFields are the keys of each services file mapping, unknown ones are reported in strict mode
+var (
+	servicesFileFields = yamlFieldNames(model.ServicesFile{})
+	serviceFields      = yamlFieldNames(model.Service{})
+	versionFields      = yamlFieldNames(model.ServiceVersion{})
+)
+
+// timestampFields are the keys of service and version mappings holding a model.Timestamp
+var timestampFields = map[string]bool{"created_at": true, "updated_at": true}
+
+// yamlFieldNames returns the YAML keys of a struct's fields
+func yamlFieldNames(v interface{}) map[string]bool {
+	t := reflect.TypeOf(v)
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlFieldName(t.Field(i)); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// yamlFieldName returns the YAML key of a struct field, "" without one
+func yamlFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	return name
+}
+
+// checkNodes reports the problems the decoder would stop at: unknown fields in strict mode and invalid
+// timestamps, which are cleared so the tree can still be decoded
+func checkNodes(report *validationReport, root *yaml.Node, strict bool) {
+	if strict {
+		checkKnownFields(report, root, servicesFileFields, "model.ServicesFile", "", "")
+	}
+	services := mappingValue(root, "services")
+	if services == nil || services.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, svcNode := range services.Content {
+		serviceID := scalarValue(svcNode, "id")
+		if strict {
+			checkKnownFields(report, svcNode, serviceFields, "model.Service", serviceID, "")
+		}
+		checkTimestampFields(report, svcNode, serviceID, "")
+
+		versions := mappingValue(svcNode, "versions")
+		if versions == nil || versions.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, vNode := range versions.Content {
+			versionID := scalarValue(vNode, "id")
+			if strict {
+				checkKnownFields(report, vNode, versionFields, "model.ServiceVersion", serviceID, versionID)
+			}
+			checkTimestampFields(report, vNode, serviceID, versionID)
+		}
+	}
+}
+
+// checkKnownFields reports every key of a mapping that is not one of known, worded like the strict decoder
+func checkKnownFields(report *validationReport, node *yaml.Node, known map[string]bool, typeName, serviceID, versionID string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; !known[key.Value] {
+			report.addError(key.Line, serviceID, versionID, key.Value, "field %s not found in type %s", key.Value, typeName)
+		}
+	}
+}
+
+// checkTimestampFields reports every created_at or updated_at of a mapping that is not an RFC 3339 timestamp,
+// clearing it so decoding does not fail on it
+func checkTimestampFields(report *validationReport, node *yaml.Node, serviceID, versionID string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+		if !timestampFields[key.Value] || val.Tag == "!!null" {
+			continue
+		}
+		var ts model.Timestamp
+		if err := ts.UnmarshalYAML(val); err != nil {
+			report.addError(0, serviceID, versionID, key.Value, "%v", err)
+			val.SetString("")
+			val.Tag = "!!null"
+		}
+	}
+}
+
+// scalarValue returns the value of key in a mapping node when it is a scalar, "" otherwise
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+// mappingValue returns the value of key in a mapping node, nil if node is not a mapping or lacks the key
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// valueLine returns the line of key's value in a mapping node, or the node's own line without the key
+func valueLine(node *yaml.Node, key string) int {
+	if value := mappingValue(node, key); value != nil {
+		return value.Line
+	}
+	return node.Line
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// issueSummary is the part of a validation issue the tests compare
+type issueSummary struct {
+	Severity  v1.ValidationIssue_Severity
+	Line      int32
+	ServiceID string
+	VersionID string
+	Field     string
+}
+
+func summarize(issues []*v1.ValidationIssue) []issueSummary {
+	summaries := make([]issueSummary, 0, len(issues))
+	for _, issue := range issues {
+		summaries = append(summaries, issueSummary{issue.Severity, issue.Line, issue.ServiceId, issue.VersionId, issue.Field})
+	}
+	return summaries
+}
+
+func TestServer_ValidateCatalog_ReportsEveryIssue(t *testing.T) {
+	srv, err := NewCatalogServerFromYAML([]byte("services: []\n"), LoadOptions{
+		StrictYAML:       true,
+		RequireHTTPSURLs: true,
+		FutureTimestamps: model.FutureTimestampsWarn,
+	})
+	require.NoError(t, err)
+
+	content := `schema_version: 1
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    url: "http://users.example.com"
+    versions:
+      - id: "v1"
+        version: "v1.0.0"
+        is_active: true
+      - id: "v1"
+        version: "v1.0.0"
+        is_active: true
+        created_at: "yesterday"
+  - id: "svc-1"
+    name: "Duplicate"
+    descripton: "typo"
+    organization_id: "org-1"
+    updated_at: "2999-01-01T00:00:00Z"
+    versions:
+      - id: "v3"
+        service_id: "svc-9"
+`
+	resp, err := srv.ValidateCatalog(context.Background(), &v1.ValidateCatalogRequest{Content: content})
+	require.NoError(t, err)
+
+	assert.False(t, resp.Valid)
+	assert.Equal(t, int32(2), resp.ServiceCount)
+	errorSeverity, warningSeverity := v1.ValidationIssue_SEVERITY_ERROR, v1.ValidationIssue_SEVERITY_WARNING
+	assert.Equal(t, []issueSummary{
+		{errorSeverity, 3, "svc-1", "", "is_active"},
+		{errorSeverity, 6, "svc-1", "", "url"},
+		{errorSeverity, 11, "svc-1", "v1", "id"},
+		{errorSeverity, 12, "svc-1", "v1", "version"},
+		{errorSeverity, 14, "svc-1", "v1", "created_at"},
+		{errorSeverity, 15, "svc-1", "", "id"},
+		{warningSeverity, 15, "svc-1", "", ""},
+		{errorSeverity, 17, "svc-1", "", "descripton"},
+		{errorSeverity, 21, "svc-1", "v3", "version"},
+		{errorSeverity, 22, "svc-1", "v3", "service_id"},
+	}, summarize(resp.Issues))
+
+	for _, issue := range resp.Issues {
+		assert.NotEmpty(t, issue.Message)
+		assert.NotRegexp(t, `^line \d+:`, issue.Message, "the line belongs in the line field")
+	}
+	assert.Contains(t, resp.Issues[5].Message, "first defined at line 3")
+	assert.Contains(t, resp.Issues[7].Message, "descripton")
+
+	// Nothing was loaded
+	list, err := srv.ListServices(context.Background(), &v1.ListServicesRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, list.TotalCount)
+}
+
+func TestValidateServicesData(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		opts        LoadOptions
+		wantValid   bool
+		wantIssues  int
+		wantMessage string
+	}{
+		{name: "valid file", yaml: "services:\n  - id: svc-1\n    name: User Service\n    versions: [{id: v1, version: v1.0.0, is_active: true}]\n", wantValid: true},
+		{name: "empty file", yaml: "", wantValid: true},
+		{name: "syntax error stops validation", yaml: "services:\n  - id: svc-1\n   name: bad\n", wantIssues: 1, wantMessage: "did not find expected"},
+		{name: "unsupported schema version", yaml: "schema_version: 99\nservices: []\n", wantIssues: 1, wantMessage: "schema_version 99"},
+		{name: "too many services", yaml: "services: [{id: svc-1, name: One}, {id: svc-2, name: Two}]\n", opts: LoadOptions{MaxServices: 1}, wantIssues: 1, wantMessage: "exceed the limit"},
+		{name: "missing service id", yaml: "services: [{name: Nameless}]\n", wantIssues: 1, wantMessage: "service id is required"},
+		{name: "whitespace-only name", yaml: "services: [{id: svc-1, name: \"  \"}]\n", wantIssues: 1, wantMessage: "name is required"},
+		{name: "whitespace-only name kept without normalization", yaml: "services: [{id: svc-1, name: \"  \"}]\n", opts: LoadOptions{NameNormalization: model.NameNormalizationNone}, wantValid: true},
+		{name: "relative url", yaml: "services: [{id: svc-1, name: One, url: /svc-1}]\n", wantIssues: 1, wantMessage: "must be absolute"},
+		{name: "type mismatch", yaml: "services: [{id: svc-1, name: One, versions: [{version: v1.0.0, is_active: maybe}]}]\n", wantIssues: 1, wantMessage: "cannot unmarshal"},
+		{name: "unknown field ignored when lenient", yaml: "services: [{id: svc-1, name: One, descripton: typo}]\n", wantValid: true},
+		{name: "duplicate organization", yaml: "organizations: [{id: org-1}, {id: org-1, display_name: One}]\nservices: []\n", wantIssues: 1, wantMessage: `duplicate organization ID "org-1", first defined at line 1`},
+		{name: "organization without id", yaml: "organizations:\n  - display_name: Nameless\nservices: []\n", wantIssues: 1, wantMessage: "organization 1 has no id"},
+		{name: "unknown organization", yaml: "organizations: [{id: org-1}]\nservices: [{id: svc-1, name: One, organization_id: org-2}]\n", opts: LoadOptions{RequireKnownOrganizations: true}, wantIssues: 1, wantMessage: "org-2"},
+		{name: "future timestamp rejected", yaml: "services: [{id: svc-1, name: One, created_at: \"2999-01-01T00:00:00Z\"}]\n", opts: LoadOptions{FutureTimestamps: model.FutureTimestampsReject}, wantIssues: 1, wantMessage: "svc-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := validateServicesData([]byte(tt.yaml), tt.opts)
+			assert.Equal(t, tt.wantValid, resp.Valid)
+			assert.Len(t, resp.Issues, tt.wantIssues)
+			if tt.wantMessage != "" && len(resp.Issues) > 0 {
+				assert.Contains(t, resp.Issues[0].Message, tt.wantMessage)
+			}
+		})
+	}
+}
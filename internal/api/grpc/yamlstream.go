@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+// servicesKey is the top-level key of the list decoded one service at a time
+const servicesKey = "services"
+
+// parseServicesFileFrom decodes a services file from r like parseServicesFile, but one service at a time:
+// the file is never held in memory as a whole, and the parse tree of each service is released once the
+// service is decoded, so peak memory is bounded by the parse tree and the decoded services rather than
+// the file, the tree and the services together. Like parseServicesFile, only the first YAML document
+// of r is read.
+func parseServicesFileFrom(r io.Reader, loadOpts LoadOptions) (*model.ServicesFile, error) {
+	sf, err := decodeServicesStream(r, loadOpts.StrictYAML)
+	if err != nil {
+		logger.Get().Errorw("Failed to parse services.yaml", "error", err)
+		return nil, fmt.Errorf("failed to parse services.yaml: %w", err)
+	}
+
+	if err := loadOpts.checkServicesFile(sf); err != nil {
+		return nil, err
+	}
+	return sf, nil
+}
+
+// decodeServicesStream decodes the first document of r, decoding the entries of its top-level services
+// list one at a time and the rest of the document, e.g. schema_version, afterwards
+func decodeServicesStream(r io.Reader, strict bool) (*model.ServicesFile, error) {
+	var doc yaml.Node
+	// An empty document is valid, matching yaml.Unmarshal
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &model.ServicesFile{}, nil
+		}
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &model.ServicesFile{}, nil
+	}
+	root := doc.Content[0]
+
+	services, err := decodeServiceNodes(root, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everything but the services list
+	var sf model.ServicesFile
+	if strict {
+		if err := unknownFieldError(root, reflect.TypeOf(sf)); err != nil {
+			return nil, err
+		}
+	}
+	if err := root.Decode(&sf); err != nil {
+		return nil, err
+	}
+	if len(sf.Services) > 0 && len(services) > 0 {
+		return nil, errors.New("services defined more than once")
+	}
+	sf.Services = append(sf.Services, services...)
+	return &sf, nil
+}
+
+// decodeServiceNodes decodes the services list of a top-level mapping entry by entry, dropping each entry
+// from the tree once decoded and the list from root once complete. Anchors defined in one entry and used
+// in a later one resolve, as aliases keep the nodes they point to.
+func decodeServiceNodes(root *yaml.Node, strict bool) ([]*model.Service, error) {
+	if root.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	serviceType := reflect.TypeOf(model.Service{})
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, list := root.Content[i], root.Content[i+1]
+		if key.Value != servicesKey {
+			continue
+		}
+		switch {
+		case list.Kind == yaml.ScalarNode && list.Tag == "!!null":
+			// An empty list
+		case list.Kind != yaml.SequenceNode:
+			return nil, fmt.Errorf("line %d: services must be a list", list.Line)
+		}
+
+		services := make([]*model.Service, 0, len(list.Content))
+		for j, entry := range list.Content {
+			if strict {
+				if err := unknownFieldError(entry, serviceType); err != nil {
+					return nil, fmt.Errorf("service at line %d: %w", entry.Line, err)
+				}
+			}
+			var svc *model.Service
+			if err := entry.Decode(&svc); err != nil {
+				return nil, fmt.Errorf("service at line %d: %w", entry.Line, err)
+			}
+			services = append(services, svc)
+			list.Content[j] = nil
+		}
+
+		root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		return services, nil
+	}
+	return nil, nil
+}
+
+// unknownFieldError returns the error the strict decoder reports for the first key of node, or of the
+// mappings nested in it, that is not a field of t. yaml.Node.Decode never checks for unknown fields.
+func unknownFieldError(node *yaml.Node, t reflect.Type) error {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types decoding themselves, such as model.Timestamp, accept what they choose
+	if reflect.PointerTo(t).Implements(reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()) {
+		return nil
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := unknownFieldError(item, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := unknownFieldError(node.Content[i], t.Elem()); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFieldIndexes(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			// Merge keys ("<<: *base") bring in the keys of other mappings of the same type
+			if key.Tag == "!!merge" {
+				if err := unknownFieldError(value, mergedType(value, t)); err != nil {
+					return err
+				}
+				continue
+			}
+			index, ok := fields[key.Value]
+			if !ok {
+				return fmt.Errorf("line %d: field %s not found in type %s", key.Line, key.Value, t)
+			}
+			if err := unknownFieldError(value, t.Field(index).Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergedType returns the type a merge key's value is checked against: t for a single mapping, a slice of t
+// for a list of mappings
+func mergedType(value *yaml.Node, t reflect.Type) reflect.Type {
+	if value.Kind == yaml.SequenceNode {
+		return reflect.SliceOf(t)
+	}
+	return t
+}
+
+// yamlFieldIndexes maps the YAML keys of a struct's fields to the fields' indexes
+func yamlFieldIndexes(t reflect.Type) map[string]int {
+	indexes := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlFieldName(t.Field(i)); name != "" {
+			indexes[name] = i
+		}
+	}
+	return indexes
+}
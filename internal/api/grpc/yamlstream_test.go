@@ -0,0 +1,280 @@
+package grpc
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServicesFileFrom_MatchesParseServicesFile(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "empty file", yaml: ""},
+		{name: "empty list", yaml: "services:\n"},
+		{name: "flow list", yaml: "services: [{id: svc-1, name: User Service, organization_id: org-1}]\n"},
+		{
+			name: "indented list",
+			yaml: `schema_version: 1
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    versions:
+      - id: "v1"
+        version: "v1.0.0"
+        created_at: "2025-08-01T09:00:00.5+02:00"
+  - id: "svc-2"
+    name: "Payment Service"
+    organization_id: "org-2"
+`,
+		},
+		{
+			name: "unindented list followed by a key",
+			yaml: `services:
+- id: svc-1
+  name: User Service
+  organization_id: org-1
+- id: svc-2
+  name: Payment Service
+  organization_id: org-2
+schema_version: 1
+`,
+		},
+		{
+			name: "comments and block scalars",
+			yaml: `# Catalog
+services: # all services
+  # first
+  - id: svc-1
+    name: User Service
+    organization_id: org-1
+    description: |
+      Handles users.
+
+      # Not a comment
+      - not an entry
+    versions: [{id: v1, version: v1.0.0}]
+
+  - id: svc-2
+    name: Payment Service
+    organization_id: org-2
+    url: https://payments.example.com
+`,
+		},
+		{name: "windows line endings", yaml: "services:\r\n  - id: svc-1\r\n    name: User Service\r\n    organization_id: org-1\r\n"},
+		{
+			name: "anchors across entries",
+			yaml: `services:
+  - id: svc-1
+    name: User Service
+    organization_id: &org org-1
+    versions: &versions
+      - id: v1
+        version: v1.0.0
+  - &payments
+    id: svc-2
+    name: Payment Service
+    organization_id: *org
+    versions: *versions
+  - <<: *payments
+    id: svc-3
+    name: Billing Service
+`,
+		},
+		{
+			name: "explicit document start",
+			yaml: "---\nschema_version: 1\nservices:\n  - id: svc-1\n    name: User Service\n    organization_id: org-1\n",
+		},
+		{
+			name: "multiple documents",
+			yaml: `schema_version: 1
+services:
+  - id: svc-1
+    name: User Service
+    organization_id: org-1
+---
+services:
+  - id: svc-2
+    name: Payment Service
+    organization_id: org-2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := parseServicesFile([]byte(tt.yaml), LoadOptions{StrictYAML: true})
+			require.NoError(t, err)
+
+			got, err := parseServicesFileFrom(strings.NewReader(tt.yaml), LoadOptions{StrictYAML: true})
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParseServicesFileFrom_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		opts    LoadOptions
+		wantErr string
+	}{
+		{
+			name:    "timestamp reports the line in its service",
+			yaml:    "services:\n  - id: svc-1\n    name: User Service\n  - id: svc-2\n    created_at: yesterday\n",
+			wantErr: `service at line 4: line 5: cannot parse "yesterday" as an RFC 3339 timestamp`,
+		},
+		{
+			name:    "unknown field in strict mode",
+			yaml:    "services:\n  - id: svc-1\n  - id: svc-2\n    descripton: typo\n",
+			opts:    LoadOptions{StrictYAML: true},
+			wantErr: "service at line 3",
+		},
+		{
+			name:    "unknown field merged from another entry in strict mode",
+			yaml:    "services:\n  - &base\n    id: svc-1\n    descripton: typo\n  - <<: *base\n    id: svc-2\n",
+			opts:    LoadOptions{StrictYAML: true},
+			wantErr: "field descripton not found in type model.Service",
+		},
+		{
+			name:    "unknown top-level field in strict mode",
+			yaml:    "services:\n  - id: svc-1\nextra: true\n",
+			opts:    LoadOptions{StrictYAML: true},
+			wantErr: "extra",
+		},
+		{
+			name:    "services not a list",
+			yaml:    "services:\n  id: svc-1\n",
+			wantErr: "line 2: services must be a list",
+		},
+		{
+			name:    "unsupported schema version",
+			yaml:    "schema_version: 99\nservices:\n  - id: svc-1\n",
+			wantErr: "schema",
+		},
+		{
+			name:    "non-https URL",
+			yaml:    "services:\n  - id: svc-1\n    name: User Service\n    url: http://example.com\n",
+			opts:    LoadOptions{RequireHTTPSURLs: true},
+			wantErr: "https",
+		},
+		{
+			name:    "whitespace-only name",
+			yaml:    "services:\n  - id: svc-1\n    name: \"  \\t \"\n",
+			wantErr: `service "svc-1" has no name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseServicesFileFrom(strings.NewReader(tt.yaml), tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseServicesFileFrom_LowerPeakMemory(t *testing.T) {
+	data := generateServicesYAML(5000)
+
+	bytesPeak := peakHeapDuring(func() {
+		// The byte-slice path also needs the whole file in memory
+		yamlData := bytes.Clone(data)
+		_, err := parseServicesFile(yamlData, LoadOptions{})
+		require.NoError(t, err)
+	})
+	streamPeak := peakHeapDuring(func() {
+		_, err := parseServicesFileFrom(bytes.NewReader(data), LoadOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Logf("peak heap growth: byte slice %d KiB, stream %d KiB", bytesPeak>>10, streamPeak>>10)
+	assert.Less(t, streamPeak, bytesPeak)
+}
+
+func BenchmarkParseServicesFile(b *testing.B) {
+	data := generateServicesYAML(5000)
+
+	b.Run("bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(float64(peakHeapDuring(func() {
+				_, _ = parseServicesFile(bytes.Clone(data), LoadOptions{})
+			})), "peak-B/op")
+		}
+	})
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(float64(peakHeapDuring(func() {
+				_, _ = parseServicesFileFrom(bytes.NewReader(data), LoadOptions{})
+			})), "peak-B/op")
+		}
+	})
+}
+
+// generateServicesYAML returns a services file with n services of three versions each
+func generateServicesYAML(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("schema_version: 1\nservices:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, "  - id: \"svc-%d\"\n    name: \"Service %d\"\n    organization_id: \"org-%d\"\n", i, i, i%10)
+		fmt.Fprintf(&buf, "    description: \"Generated service %d used to measure memory while loading large catalogs\"\n", i)
+		buf.WriteString("    created_at: \"2025-08-01T09:00:00Z\"\n    versions:\n")
+		for v := 0; v < 3; v++ {
+			fmt.Fprintf(&buf, "      - id: \"svc-%d-v%d\"\n        version: \"v%d.0.0\"\n        is_active: %t\n", i, v, v, v == 2)
+		}
+	}
+	return buf.Bytes()
+}
+
+// peakHeapDuring returns how far the live heap grew above its starting size while fn ran, sampled every 100µs
+func peakHeapDuring(fn func()) uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	base := stats.HeapAlloc
+
+	var peak atomic.Uint64
+	sample := func() {
+		var s runtime.MemStats
+		runtime.ReadMemStats(&s)
+		if s.HeapAlloc > base && s.HeapAlloc-base > peak.Load() {
+			peak.Store(s.HeapAlloc - base)
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(100 * time.Microsecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sample()
+			}
+		}
+	}()
+
+	fn()
+	sample()
+	close(done)
+	wg.Wait()
+	return peak.Load()
+}
@@ -2,25 +2,40 @@ package app
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	grpcserver "github.com/ankittk/catalog-service/internal/api/grpc"
 	"github.com/ankittk/catalog-service/internal/auth"
 	authhandler "github.com/ankittk/catalog-service/internal/auth"
 	"github.com/ankittk/catalog-service/internal/config"
+	"github.com/ankittk/catalog-service/internal/health"
+	"github.com/ankittk/catalog-service/internal/idgen"
+	"github.com/ankittk/catalog-service/internal/interceptor"
 	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+	"github.com/ankittk/catalog-service/internal/service"
+	"github.com/ankittk/catalog-service/internal/webhook"
 	v1 "github.com/ankittk/catalog-service/proto/v1"
 )
 
@@ -32,21 +47,47 @@ type App struct {
 	grpcAddr   string
 	httpAddr   string
 	jwtManager *auth.JWTManager
+	readOnly   *interceptor.ReadOnlyMode
+	features   *interceptor.FeatureFlags
+	warmup     *interceptor.Warmup
+	limiter    *interceptor.ConcurrencyLimiter
+	stats      *interceptor.ServerStats
+	probe      *health.Probe
+
+	catalogServer *grpcserver.Server
+	// gatewayConn is the HTTP gateway's connection to the gRPC server
+	gatewayConn *grpc.ClientConn
+	// stopWebhooks ends webhook delivery, nil when webhooks are disabled
+	stopWebhooks func()
 }
 
 // NewApp creates a new application instance
 func NewApp(cfg *config.Config) *App {
 	app := &App{
 		config:   cfg,
-		grpcAddr: fmt.Sprintf(":%s", cfg.GRPCPort),
-		httpAddr: fmt.Sprintf(":%s", cfg.HTTPPort),
+		grpcAddr: cfg.GRPCListenAddr(),
+		httpAddr: cfg.HTTPListenAddr(),
+		readOnly: interceptor.NewReadOnlyMode(cfg.ReadOnly, grpcserver.MutatingMethods),
+		features: interceptor.NewFeatureFlags(cfg.Features, grpcserver.ExperimentalMethods),
+		warmup:   interceptor.NewWarmup(cfg.WarmupWindow),
+		limiter:  interceptor.NewConcurrencyLimiter(cfg.MaxConcurrentRequests, cfg.RetryDelay),
+		stats:    interceptor.NewServerStats(),
+		probe:    health.NewProbe(v1.CatalogService_ServiceDesc.ServiceName),
 	}
 
 	// Initialize JWT manager if authentication is enabled
 	if cfg.EnableAuth {
 		app.jwtManager = auth.NewJWTManager(cfg.JWTSecretKey, cfg.JWTTokenDuration)
+		app.jwtManager.SetRoleTokenDurations(cfg.JWTRoleTokenDurations)
+		app.jwtManager.SetClockSkew(cfg.JWTClockSkew)
+		app.jwtManager.AddExemptPaths(cfg.AuthExemptPaths...)
+		app.jwtManager.AddExemptGRPCMethods(cfg.AuthExemptGRPCMethods...)
 		logger.Get().Infow("JWT authentication enabled",
-			"token_duration", cfg.JWTTokenDuration.String())
+			"token_duration", cfg.JWTTokenDuration.String(),
+			"role_token_durations", cfg.JWTRoleTokenDurations,
+			"clock_skew", cfg.JWTClockSkew.String(),
+			"exempt_paths", cfg.AuthExemptPaths,
+			"exempt_grpc_methods", cfg.AuthExemptGRPCMethods)
 	} else {
 		logger.Get().Info("JWT authentication disabled")
 	}
@@ -59,58 +100,172 @@ func (a *App) Start() error {
 	logger.Get().Infow("Starting catalog service",
 		"grpc_port", a.config.GRPCPort,
 		"http_port", a.config.HTTPPort,
+		"bind_address", a.config.BindAddress,
 		"data_file", a.config.LocalDataStorage,
-		"auth_enabled", a.config.EnableAuth)
+		"auth_enabled", a.config.EnableAuth,
+		"read_only", a.readOnly.Enabled())
 
 	// Initialize gRPC server
 	if err := a.initGRPCServer(); err != nil {
 		return fmt.Errorf("failed to initialize gRPC server: %w", err)
 	}
 
+	// Check service URLs before serving, failing startup only in URL_CHECK=fail mode
+	if err := a.checkURLs(); err != nil {
+		return fmt.Errorf("service URL check failed: %w", err)
+	}
+
+	// Start gRPC first, the HTTP gateway connects to it while initializing
+	if err := a.startGRPCServer(); err != nil {
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+
 	// Initialize HTTP server
 	if err := a.initHTTPServer(); err != nil {
+		a.grpcServer.Stop()
 		return fmt.Errorf("failed to initialize HTTP server: %w", err)
 	}
 
-	// Start servers
-	if err := a.startServers(); err != nil {
-		return fmt.Errorf("failed to start servers: %w", err)
-	}
+	a.startHTTPServer()
+
+	// Data is loaded and both servers are up, start receiving traffic
+	a.probe.SetReady(true)
 
 	return nil
 }
 
 // initGRPCServer initializes the gRPC server
 func (a *App) initGRPCServer() error {
+	// Recover from handler panics first so they never take down the process
+	interceptors := []grpc.UnaryServerInterceptor{interceptor.Recovery()}
+	streamInterceptors := []grpc.StreamServerInterceptor{interceptor.RecoveryStream()}
+
+	// Shed load before doing any work once too many requests, unary calls and streams alike, are in flight
+	interceptors = append(interceptors, a.limiter.UnaryInterceptor())
+	streamInterceptors = append(streamInterceptors, a.limiter.StreamInterceptor())
+
+	// Label requests cold or warm and time the rest of the chain, after load shedding
+	interceptors = append(interceptors, a.warmup.UnaryInterceptor())
+	streamInterceptors = append(streamInterceptors, a.warmup.StreamInterceptor())
+
+	// Resolve the request locale before handlers log the request
+	interceptors = append(interceptors, interceptor.Locale(a.config.DefaultLocale, a.config.SupportedLocales))
+	streamInterceptors = append(streamInterceptors, interceptor.LocaleStream(a.config.DefaultLocale, a.config.SupportedLocales))
+
+	// Log payloads before authentication so rejected requests can be debugged too
+	if a.config.LogPayloads {
+		interceptors = append(interceptors, interceptor.LogPayloads())
+		logger.Get().Debug("gRPC payload logging enabled")
+	}
+
 	// Create gRPC server with authentication interceptor if enabled
-	var opts []grpc.ServerOption
 	if a.config.EnableAuth && a.jwtManager != nil {
-		opts = append(opts, grpc.UnaryInterceptor(a.jwtManager.GRPCUnaryInterceptor()))
+		interceptors = append(interceptors, a.jwtManager.GRPCUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, a.jwtManager.GRPCStreamInterceptor())
 		logger.Get().Info("gRPC server configured with JWT authentication")
 	}
 
-	a.grpcServer = grpc.NewServer(opts...)
+	// Experimental RPCs answer Unimplemented until their feature flag is on
+	interceptors = append(interceptors, a.features.UnaryInterceptor())
+	streamInterceptors = append(streamInterceptors, a.features.StreamInterceptor())
+	logger.Get().Infow("Feature flags configured", "enabled", a.features.Features())
 
-	// Get absolute path to data file
-	localDataStorage, err := a.config.GetDataFileAbsPath()
+	// Reject oversized request fields before any handler runs
+	fieldLimits, err := a.newFieldLimits()
 	if err != nil {
-		return fmt.Errorf("failed to resolve data file path: %w", err)
+		return err
 	}
+	interceptors = append(interceptors, fieldLimits.UnaryInterceptor())
+	streamInterceptors = append(streamInterceptors, fieldLimits.StreamInterceptor())
 
-	// Read YAML data with proper error handling
-	yamlData, err := os.ReadFile(localDataStorage)
+	// Apply the default server-side deadline when the client did not send one
+	interceptors = append(interceptors, interceptor.DefaultDeadline(a.config.RequestTimeout))
+
+	// Cut off streams whose client stops reading or takes too long, freeing what they hold
+	streamInterceptors = append(streamInterceptors, interceptor.StreamDeadline(a.config.StreamTimeout, a.config.StreamSendTimeout))
+
+	// Read-only guard runs after authentication so rejected callers are still identified
+	interceptors = append(interceptors, a.readOnly.UnaryInterceptor())
+	streamInterceptors = append(streamInterceptors, a.readOnly.StreamInterceptor())
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		// Count connections and RPCs for the stats admin endpoint
+		grpc.StatsHandler(a.stats),
+	}
+	if a.config.MaxConcurrentStreams > 0 {
+		serverOpts = append(serverOpts, grpc.MaxConcurrentStreams(uint32(a.config.MaxConcurrentStreams)))
+	}
+	if a.config.MaxMessageSize > 0 {
+		serverOpts = append(serverOpts,
+			grpc.MaxRecvMsgSize(int(a.config.MaxMessageSize)),
+			grpc.MaxSendMsgSize(int(a.config.MaxMessageSize)))
+	}
+	if policy, ok := gatewayKeepalivePolicy(a.config); ok {
+		serverOpts = append(serverOpts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+	a.grpcServer = grpc.NewServer(serverOpts...)
+
+	dataPath, err := a.config.GetDataFileAbsPath()
 	if err != nil {
-		return fmt.Errorf("failed to read data file %s: %w", localDataStorage, err)
+		return fmt.Errorf("failed to resolve data file path: %w", err)
 	}
 
-	catalogServer, err := grpcserver.NewCatalogServerFromYAML(yamlData)
+	loadOpts := grpcserver.LoadOptions{
+		StrictYAML:                a.config.StrictYAML,
+		FutureTimestamps:          model.FutureTimestampPolicy(a.config.FutureTimestamps),
+		TimestampSkew:             a.config.TimestampSkew,
+		RequireHTTPSURLs:          a.config.RequireHTTPSURLs,
+		RequireKnownOrganizations: a.config.RequireKnownOrganizations,
+		NameNormalization:         model.NameNormalization(a.config.NameNormalization),
+		MaxServices:               a.config.MaxServices,
+		AllowMissing:              a.config.AllowEmptyCatalog,
+		ShardCount:                a.config.ShardCount,
+		ShardIndex:                a.config.ShardIndex,
+	}
+	idGenerator, err := idgen.New(a.config.IDGenerator)
+	if err != nil {
+		return err
+	}
+	catalogServer, err := grpcserver.NewCatalogServerFromPath(dataPath, loadOpts,
+		service.WithSearchMinLength(a.config.SearchMinLength),
+		service.WithMaxListResults(a.config.MaxListResults),
+		service.WithSearchWildcard(a.config.SearchWildcard),
+		service.WithSearchFields(a.config.SearchFields),
+		service.WithSearchMatch(a.config.SearchMatch),
+		service.WithStrictSort(a.config.StrictSort),
+		service.WithDefaultSort(a.config.DefaultSortBy, a.config.DefaultSortOrder),
+		service.WithRequireHTTPSURLs(a.config.RequireHTTPSURLs),
+		service.WithRequireKnownOrganizations(a.config.RequireKnownOrganizations),
+		service.WithNameNormalization(model.NameNormalization(a.config.NameNormalization)),
+		service.WithFutureTimestamps(model.FutureTimestampPolicy(a.config.FutureTimestamps), a.config.TimestampSkew),
+		service.WithAuditLogSize(a.config.AuditLogSize),
+		service.WithServiceIDFormat(a.config.ServiceIDPattern, a.config.ServiceIDMaxLength),
+		service.WithOrganizationIDFormat(a.config.OrganizationIDPattern, a.config.OrganizationIDMaxLength),
+		service.WithIDGenerator(idGenerator),
+		service.WithRetryDelay(a.config.RetryDelay),
+		service.WithDefaultOrganization(a.defaultOrganization()),
+		service.WithAnonymousOrganizations(a.config.AnonymousOrganizations),
+		service.WithCrossOrgPolicy(service.CrossOrgPolicy(a.config.CrossOrgAccess)),
+		service.WithFieldLimits(a.fieldLimits()),
+		service.WithIdempotencyTTL(a.config.IdempotencyTTL),
+		service.WithIdempotencyMaxKeys(a.config.IdempotencyMaxKeys),
+		service.WithMaxSnapshots(a.config.MaxSnapshots),
+		service.WithAnonymousWrites(a.config.AllowAnonymousWrites),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create catalog server: %w", err)
 	}
+	a.catalogServer = catalogServer
+	a.startWebhooks()
 
 	// Register services
 	v1.RegisterCatalogServiceServer(a.grpcServer, catalogServer)
 
+	// Standard gRPC health service, NOT_SERVING until startup completes and again from the start of shutdown
+	healthpb.RegisterHealthServer(a.grpcServer, a.probe.GRPCHealthServer())
+
 	// Enable reflection for development as it is useful for development and debugging
 	if a.config.Environment == "development" {
 		reflection.Register(a.grpcServer)
@@ -121,33 +276,50 @@ func (a *App) initGRPCServer() error {
 
 // initHTTPServer initializes the HTTP server with gRPC gateway
 func (a *App) initHTTPServer() error {
+	handler, err := a.createHTTPHandler()
+	if err != nil {
+		return err
+	}
+
 	// Create HTTP server
 	a.httpServer = &http.Server{
 		Addr:    a.httpAddr,
-		Handler: a.createHTTPHandler(),
+		Handler: handler,
+	}
+
+	if a.config.EnableH2C {
+		// Serve HTTP/2 without TLS for internal clients, HTTP/1.1 requests are passed through unchanged.
+		// ConfigureServer lets Shutdown also close the HTTP/2 connections.
+		h2s := &http2.Server{}
+		if err := http2.ConfigureServer(a.httpServer, h2s); err != nil {
+			return fmt.Errorf("failed to configure HTTP/2: %w", err)
+		}
+		a.httpServer.Handler = h2c.NewHandler(a.httpServer.Handler, h2s)
+		logger.Get().Info("HTTP gateway configured with HTTP/2 cleartext (h2c)")
 	}
 
 	return nil
 }
 
-// createHTTPHandler creates the HTTP handler with gRPC gateway
-func (a *App) createHTTPHandler() http.Handler {
+// createHTTPHandler creates the HTTP handler with gRPC gateway, failing when the gRPC server cannot be reached
+func (a *App) createHTTPHandler() (http.Handler, error) {
 	mux := http.NewServeMux()
 
 	// Create gRPC gateway mux
-	gwmux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	cachePolicy := newCacheControlPolicy(a.config)
+	gwmux := newGatewayMux(cachePolicy, newGatewayMarshaler(a.config), prettyJSONOptions(a.config)...)
 
-	// Register gRPC gateway handlers
-	if err := v1.RegisterCatalogServiceHandlerFromEndpoint(
-		context.Background(),
-		gwmux,
-		a.grpcAddr,
-		opts,
-	); err != nil {
-		logger.Get().Errorw("Failed to register gRPC gateway", "error", err)
-		return mux
+	// Connect to the gRPC server, retrying while it starts, and register gRPC gateway handlers
+	conn, err := dialGateway(context.Background(), a.grpcAddr, gatewayDialOptions(a.config),
+		gatewayDialTimeout(a.config), a.config.GatewayDialRetries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect gRPC gateway: %w", err)
 	}
+	if err := v1.RegisterCatalogServiceHandler(context.Background(), gwmux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register gRPC gateway: %w", err)
+	}
+	a.gatewayConn = conn
 
 	// CORS middleware
 	corsMiddleware := a.createCORSMiddleware()
@@ -165,10 +337,13 @@ func (a *App) createHTTPHandler() http.Handler {
 
 	// Authentication endpoints (no auth required)
 	if a.config.EnableAuth && a.jwtManager != nil {
-		authHandler := authhandler.NewAuthHandler(a.jwtManager)
+		authHandler := authhandler.NewAuthHandler(a.jwtManager, authhandler.NewDemoCredentials())
+		authHandler.SetMaxConcurrentLogins(a.config.MaxConcurrentLogins)
+		login := requireJSON(http.HandlerFunc(authHandler.Login))
 		mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
 			corsMiddleware(w, r)
-			authHandler.Login(w, r)
+			cachePolicy.applyAuth(w)
+			login.ServeHTTP(w, r)
 		})
 	}
 
@@ -178,8 +353,71 @@ func (a *App) createHTTPHandler() http.Handler {
 		authMiddleware(gwmux).ServeHTTP(w, r)
 	})
 
+	// JSON Lines export, streamed outside the gateway marshaler with the scoping and filters of ListServices.
+	// It does not pass through gRPC, so it runs the interceptors guarding reads itself, with the field limits
+	// of ListServices whose requests it takes.
+	fieldLimits, err := a.newFieldLimits()
+	if err != nil {
+		return nil, err
+	}
+	exportGuard := interceptor.ChainUnary(
+		a.limiter.UnaryInterceptor(),
+		a.warmup.UnaryInterceptor(),
+		interceptor.Locale(a.config.DefaultLocale, a.config.SupportedLocales),
+		a.features.UnaryInterceptor(),
+		fieldLimits.UnaryInterceptorAs("/v1.CatalogService/ListServices"),
+	)
+	export := newExportHandler(a.catalogServer, gwmux, newJSONMarshaler(a.config, false), cachePolicy, exportGuard, a.config.StreamTimeout, a.config.StreamSendTimeout)
+	mux.HandleFunc(exportPath, func(w http.ResponseWriter, r *http.Request) {
+		corsMiddleware(w, r)
+		if r.Method == "OPTIONS" {
+			return
+		}
+		authMiddleware(export).ServeHTTP(w, r)
+	})
+
+	// Read-only mode admin endpoint (admin role required when auth is enabled)
+	mux.HandleFunc("/admin/read-only", func(w http.ResponseWriter, r *http.Request) {
+		corsMiddleware(w, r)
+		if r.Method == "OPTIONS" {
+			return
+		}
+		authMiddleware(a.requireAdmin(requireJSON(a.readOnly))).ServeHTTP(w, r)
+	})
+
+	// Feature flags admin endpoint (admin role required when auth is enabled)
+	mux.HandleFunc("/admin/features", func(w http.ResponseWriter, r *http.Request) {
+		corsMiddleware(w, r)
+		if r.Method == "OPTIONS" {
+			return
+		}
+		authMiddleware(a.requireAdmin(a.features)).ServeHTTP(w, r)
+	})
+
+	// Server statistics admin endpoint (admin role required when auth is enabled)
+	mux.HandleFunc("/admin/stats", func(w http.ResponseWriter, r *http.Request) {
+		corsMiddleware(w, r)
+		if r.Method == "OPTIONS" {
+			return
+		}
+		authMiddleware(a.requireAdmin(a.stats)).ServeHTTP(w, r)
+	})
+
+	// Request latency histograms for Prometheus scrapers (admin role required when auth is enabled)
+	mux.HandleFunc(metricsPath, func(w http.ResponseWriter, r *http.Request) {
+		corsMiddleware(w, r)
+		if r.Method == "OPTIONS" {
+			return
+		}
+		authMiddleware(a.requireAdmin(http.HandlerFunc(serveMetrics))).ServeHTTP(w, r)
+	})
+
+	// Kubernetes probes (no auth required): liveness while serving, readiness once data is loaded
+	mux.Handle("/healthz", withRequestLogging("Liveness", "/healthz", a.probe.LivenessHandler()))
+	mux.Handle("/ready", withRequestLogging("Readiness", "/ready", a.probe.ReadinessHandler()))
+
 	// Health check endpoint (no auth required)
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/health", withRequestLogging("HealthCheck", "/health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		corsMiddleware(w, r)
 		if r.Method == "OPTIONS" {
 			return
@@ -202,75 +440,181 @@ func (a *App) createHTTPHandler() http.Handler {
 			healthResponse["timestamp"],
 			healthResponse["version"],
 			healthResponse["auth_enabled"])
-	})
+	})))
 
-	return mux
+	// gRPC-Web requests from browsers go straight to the gRPC server instead of through the JSON gateway
+	if a.config.EnableGRPCWeb {
+		logger.Get().Info("HTTP gateway configured with gRPC-Web")
+		return withGRPCWeb(a.grpcServer, corsMiddleware, mux), nil
+	}
+	return mux, nil
 }
 
-// createCORSMiddleware creates a CORS middleware function
-func (a *App) createCORSMiddleware() func(http.ResponseWriter, *http.Request) {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Parse CORS origins from config
-		origins := strings.Split(a.config.CORSOrigins, ",")
-		origin := r.Header.Get("Origin")
-
-		// Check if origin is allowed
-		allowed := false
-		for _, allowedOrigin := range origins {
-			allowedOrigin = strings.TrimSpace(allowedOrigin)
-			if allowedOrigin == "*" || allowedOrigin == origin {
-				allowed = true
-				break
+// newGatewayMux creates the gRPC gateway mux, forwarding request IDs in both directions,
+// setting caching headers per route, accepting query parameter aliases such as q for search_query
+// and adding pagination links to service lists. extra options are applied last.
+func newGatewayMux(cachePolicy *cacheControlPolicy, marshaler runtime.Marshaler, extra ...runtime.ServeMuxOption) *runtime.ServeMux {
+	opts := []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithMiddlewares(withQueryAliases, withRequestURL),
+		runtime.WithErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+			cachePolicy.applyError(w)
+			gatewayErrorHandler(ctx, mux, marshaler, w, r, err)
+		}),
+		runtime.WithIncomingHeaderMatcher(gatewayIncomingHeaderMatcher),
+		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+			setRequestIDHeader(ctx, w)
+			cachePolicy.applySuccess(w, resp)
+			if list, ok := resp.(*v1.ListServicesResponse); ok {
+				setPageLinks(ctx, list)
 			}
-		}
+			return nil
+		}),
+	}
+	return runtime.NewServeMux(append(opts, extra...)...)
+}
 
-		if allowed {
-			w.Header().Set("Access-Control-Allow-Origin", origin) // Allow the origin
+// newGatewayMarshaler creates the gateway's JSON marshaler, compact unless JSON_PRETTY is set.
+// Enums are always rendered by name.
+func newGatewayMarshaler(cfg *config.Config) runtime.Marshaler {
+	return newJSONMarshaler(cfg, cfg.JSONPretty)
+}
+
+// newJSONMarshaler creates a gateway JSON marshaler, indenting its output when pretty is set
+func newJSONMarshaler(cfg *config.Config, pretty bool) runtime.Marshaler {
+	indent := ""
+	if pretty {
+		indent = prettyJSONIndent
+	}
+	return &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				// Zero values such as "total_count": 0 are included rather than omitted
+				EmitUnpopulated: cfg.JSONEmitDefaults,
+				// Field names as written in the proto ("organization_id") rather than camelCase ("organizationId")
+				UseProtoNames: cfg.JSONUseProtoNames,
+				Indent:        indent,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+}
+
+// gatewayIncomingHeaderMatcher forwards a caller's X-Request-Id to the gRPC handlers, in addition to the default headers
+func gatewayIncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return grpcserver.RequestIDMetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// setRequestIDHeader copies the request ID from the gRPC response trailer into the X-Request-Id header
+func setRequestIDHeader(ctx context.Context, w http.ResponseWriter) {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return
+	}
+	if ids := md.TrailerMD.Get(grpcserver.RequestIDMetadataKey); len(ids) > 0 {
+		w.Header().Set(requestIDHeader, ids[0])
+	}
+}
+
+// gatewayErrorHandler exposes the machine-readable error reason as the X-Error-Reason header and any retry hint
+// as the Retry-After header, then writes the default JSON error body which also carries both in its details
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	setRequestIDHeader(ctx, w)
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			switch d := detail.(type) {
+			case *errdetails.ErrorInfo:
+				w.Header().Set("X-Error-Reason", d.GetReason())
+			case *errdetails.RetryInfo:
+				w.Header().Set("Retry-After", retryAfterSeconds(d.GetRetryDelay().AsDuration()))
+			}
 		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")               // Allow these methods
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With") // Allow these headers
-		w.Header().Set("Access-Control-Allow-Credentials", "true")                                      // Allow credentials for CORS
-		w.Header().Set("Access-Control-Max-Age", "86400")                                               // 24 hours for CORS
+// retryAfterSeconds formats a retry delay for the Retry-After header, in whole seconds rounded up
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
 
-		// Handle preflight requests for CORS
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
+// adminTokenHeader carries ADMIN_TOKEN on admin requests when auth is disabled
+const adminTokenHeader = "X-Admin-Token"
+
+// requireAdmin rejects requests that may not use admin endpoints, see isAdminRequest
+func (a *App) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.isAdminRequest(r) {
+			logger.Get().Warnw("Admin endpoint access denied", "path", r.URL.Path, "method", r.Method)
+			http.Error(w, "Forbidden: admin role required", http.StatusForbidden)
 			return
 		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAdminRequest reports whether a request may use admin endpoints: the caller needs the admin role when auth is
+// enabled. With auth disabled there are no roles, so the request must send ADMIN_TOKEN in the X-Admin-Token
+// header when one is configured, and without one only GET requests are served so server state cannot be
+// changed anonymously.
+func (a *App) isAdminRequest(r *http.Request) bool {
+	if a.config.EnableAuth {
+		claims, ok := auth.ClaimsFromContext(r.Context())
+		return ok && claims.Role == "admin"
+	}
+	if a.config.AdminToken != "" {
+		return subtle.ConstantTimeCompare([]byte(r.Header.Get(adminTokenHeader)), []byte(a.config.AdminToken)) == 1
 	}
+	return r.Method == http.MethodGet
 }
 
-// startServers starts both gRPC and HTTP servers
-func (a *App) startServers() error {
-	// Start gRPC server
-	go func() {
-		lis, err := net.Listen("tcp", a.grpcAddr)
-		if err != nil {
-			logger.Get().Fatalw("Failed to listen for gRPC", "error", err)
-		}
+// createCORSMiddleware creates a CORS middleware function
+func (a *App) createCORSMiddleware() func(http.ResponseWriter, *http.Request) {
+	return newCORSPolicy(a.config).apply
+}
+
+// startGRPCServer listens on the gRPC address and serves in the background
+func (a *App) startGRPCServer() error {
+	lis, err := net.Listen("tcp", a.grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
 
+	go func() {
 		logger.Get().Infow("gRPC server listening", "address", a.grpcAddr)
 		if err := a.grpcServer.Serve(lis); err != nil {
 			logger.Get().Fatalw("Failed to serve gRPC", "error", err)
 		}
 	}()
 
-	// Start HTTP server
+	return nil
+}
+
+// startHTTPServer serves the HTTP gateway in the background
+func (a *App) startHTTPServer() {
 	go func() {
 		logger.Get().Infow("HTTP server listening", "address", a.httpAddr)
 		if err := a.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Get().Fatalw("Failed to serve HTTP", "error", err)
 		}
 	}()
-
-	return nil
 }
 
 // Stop gracefully shuts down the application
 func (a *App) Stop() error {
 	logger.Get().Info("Shutting down application...")
 
+	// Fail readiness and the gRPC health status first so load balancers stop routing new traffic,
+	// then give them time to notice while the servers keep accepting requests
+	a.probe.SetReady(false)
+	if a.config.ShutdownDrainDelay > 0 {
+		logger.Get().Infow("Draining before shutdown", "delay", a.config.ShutdownDrainDelay.String())
+		time.Sleep(a.config.ShutdownDrainDelay)
+	}
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -282,24 +626,117 @@ func (a *App) Stop() error {
 		}
 	}
 
+	// The gateway has no requests left to forward
+	if a.gatewayConn != nil {
+		a.gatewayConn.Close()
+	}
+
 	// Stop gRPC server
 	if a.grpcServer != nil {
 		a.grpcServer.GracefulStop()
 	}
 
+	// Pending webhook deliveries are abandoned
+	if a.stopWebhooks != nil {
+		a.stopWebhooks()
+	}
+
 	logger.Get().Info("Application stopped")
 	return nil
 }
 
-// WaitForShutdown waits for shutdown signals
+// defaultOrganization returns the organization anonymous listings are scoped to; with auth enabled every
+// caller is authenticated, so DEFAULT_ORGANIZATION does not apply
+func (a *App) defaultOrganization() string {
+	if a.config.EnableAuth {
+		if a.config.DefaultOrganization != "" {
+			logger.Get().Warnw("DEFAULT_ORGANIZATION is ignored when auth is enabled", "default_organization", a.config.DefaultOrganization)
+		}
+		return ""
+	}
+	return a.config.DefaultOrganization
+}
+
+// startWebhooks delivers catalog changes to the configured webhook URLs in the background
+func (a *App) startWebhooks() {
+	if len(a.config.WebhookURLs) == 0 {
+		return
+	}
+
+	dispatcher := webhook.NewDispatcher(webhook.Config{
+		URLs:         a.config.WebhookURLs,
+		Secret:       a.config.WebhookSecret,
+		MaxRetries:   a.config.WebhookMaxRetries,
+		RetryBackoff: a.config.WebhookRetryBackoff,
+		Timeout:      a.config.WebhookTimeout,
+	})
+	// The subscription buffer is the delivery queue: events beyond it are dropped and logged
+	events, unsubscribe := a.catalogServer.Subscribe(a.config.WebhookQueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	go dispatcher.Run(ctx, events)
+
+	a.stopWebhooks = func() {
+		unsubscribe()
+		cancel()
+	}
+	logger.Get().Infow("Webhooks enabled", "urls", len(a.config.WebhookURLs), "queue_size", a.config.WebhookQueueSize)
+}
+
+// newFieldLimits creates the field limit interceptors from fieldLimits
+func (a *App) newFieldLimits() (*interceptor.FieldLimits, error) {
+	limits, err := interceptor.NewFieldLimits(v1.File_v1_catalog_proto.Services().ByName("CatalogService"), a.fieldLimits())
+	if err != nil {
+		return nil, fmt.Errorf("invalid FIELD_LIMITS: %w", err)
+	}
+	return limits, nil
+}
+
+// fieldLimits returns the default request field limits with FIELD_LIMITS applied on top
+func (a *App) fieldLimits() map[string]int {
+	limits := make(map[string]int, len(service.DefaultFieldLimits)+len(a.config.FieldLimits))
+	for key, limit := range service.DefaultFieldLimits {
+		limits[key] = limit
+	}
+	for key, limit := range a.config.FieldLimits {
+		limits[key] = limit
+	}
+	return limits
+}
+
+// reloadData re-reads the data file or directory and atomically swaps it in; on failure the current catalog is kept
+func (a *App) reloadData() {
+	dataPath, err := a.config.GetDataFileAbsPath()
+	if err == nil {
+		err = a.catalogServer.ReloadFromPath(dataPath)
+	}
+	if err != nil {
+		logger.Get().Errorw("Failed to reload data file, keeping current catalog", "error", err)
+		return
+	}
+	// Requests against the freshly loaded catalog count as cold again
+	a.warmup.Reset()
+}
+
+// WaitForShutdown waits for shutdown signals, reloading the data file on SIGHUP
 func (a *App) WaitForShutdown() {
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
 
-	logger.Get().Info("Received shutdown signal")
-	if err := a.Stop(); err != nil {
-		logger.Get().Errorw("Error during shutdown", "error", err)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
+	for {
+		select {
+		case <-reload:
+			logger.Get().Info("Received reload signal")
+			a.reloadData()
+		case <-quit:
+			logger.Get().Info("Received shutdown signal")
+			if err := a.Stop(); err != nil {
+				logger.Get().Errorw("Error during shutdown", "error", err)
+			}
+			return
+		}
 	}
 }
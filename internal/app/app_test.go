@@ -0,0 +1,485 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ankittk/catalog-service/internal/config"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+func TestApp_Stop_HealthNotServingBeforeStop(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	a := NewApp(&config.Config{
+		LocalDataStorage:   dataFile,
+		Environment:        "test",
+		ShutdownDrainDelay: 300 * time.Millisecond,
+	})
+	require.NoError(t, a.initGRPCServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = a.grpcServer.Serve(lis) }()
+	a.probe.SetReady(true)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	check := func() (healthpb.HealthCheckResponse_ServingStatus, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "v1.CatalogService"})
+		return resp.GetStatus(), err
+	}
+
+	status, err := check()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status)
+
+	stopped := make(chan struct{})
+	go func() {
+		_ = a.Stop()
+		close(stopped)
+	}()
+
+	// During the drain delay the server still answers, reporting NOT_SERVING
+	assert.Eventually(t, func() bool {
+		status, err := check()
+		return err == nil && status == healthpb.HealthCheckResponse_NOT_SERVING
+	}, 200*time.Millisecond, 10*time.Millisecond)
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the drain delay elapsed")
+	default:
+	}
+
+	<-stopped
+	_, err = check()
+	assert.Error(t, err, "server no longer accepts requests after stopping")
+}
+
+func TestApp_Start_ServesRequests(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	// The gateway reaches the gRPC server once it is listening
+	var body []byte
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body)
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Contains(t, string(body), "User Service")
+
+	resp, err := http.Get("http://" + a.httpAddr + "/ready")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestApp_LogPayloads(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+`), 0o600))
+
+	for _, enabled := range []bool{true, false} {
+		t.Run("enabled="+strconv.FormatBool(enabled), func(t *testing.T) {
+			logs := observeLogs(t)
+			a := NewApp(&config.Config{
+				BindAddress:      "127.0.0.1",
+				GRPCPort:         freePort(t),
+				HTTPPort:         freePort(t),
+				LocalDataStorage: dataFile,
+				Environment:      "test",
+				LogPayloads:      enabled,
+			})
+			require.NoError(t, a.Start())
+			defer func() { _ = a.Stop() }()
+
+			require.Eventually(t, func() bool {
+				resp, err := http.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+				if err != nil {
+					return false
+				}
+				resp.Body.Close()
+				return resp.StatusCode == http.StatusOK
+			}, 5*time.Second, 20*time.Millisecond)
+
+			requests := logs.FilterMessage("gRPC request payload").All()
+			if !enabled {
+				assert.Empty(t, requests)
+				assert.Empty(t, logs.FilterMessage("gRPC response payload").All())
+				return
+			}
+			require.Len(t, requests, 1)
+			assert.Equal(t, map[string]interface{}{"id": "svc-1"}, requests[0].ContextMap()["request"])
+			assert.Len(t, logs.FilterMessage("gRPC response payload").All(), 1)
+		})
+	}
+}
+
+func TestApp_Start_AllowEmptyCatalog(t *testing.T) {
+	a := NewApp(&config.Config{
+		BindAddress:       "127.0.0.1",
+		GRPCPort:          freePort(t),
+		HTTPPort:          freePort(t),
+		LocalDataStorage:  filepath.Join(t.TempDir(), "services.yaml"),
+		Environment:       "test",
+		AllowEmptyCatalog: true,
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	// Reads succeed with an empty list instead of the server failing to start
+	var body []byte
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body)
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+	var list struct {
+		Services   []json.RawMessage `json:"services"`
+		TotalCount int               `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal(body, &list))
+	assert.Empty(t, list.Services)
+	assert.Zero(t, list.TotalCount)
+
+	resp, err := http.Get("http://" + a.httpAddr + "/ready")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestApp_AdminStats(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err := http.Get("http://" + a.httpAddr + "/admin/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats struct {
+		UptimeSeconds     float64 `json:"uptime_seconds"`
+		ActiveConnections int64   `json:"active_connections"`
+		RequestsTotal     int64   `json:"requests_total"`
+		Goroutines        int     `json:"goroutines"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	assert.Positive(t, stats.UptimeSeconds)
+	assert.Positive(t, stats.RequestsTotal, "the gateway call was counted")
+	assert.Positive(t, stats.ActiveConnections, "the gateway holds a connection")
+	assert.Positive(t, stats.Goroutines)
+}
+
+func TestApp_Metrics(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+		WarmupWindow:     time.Minute,
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err := http.Get("http://" + a.httpAddr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, logger.MetricsContentType, resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `catalog_request_duration_seconds_count{method="GetService",status="OK",`)
+	assert.Contains(t, string(body), `catalog_request_phase_duration_seconds_count{method="/v1.CatalogService/GetService",phase="cold"}`)
+}
+
+func TestApp_FeatureFlags(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+    versions:
+      - id: "v1"
+        version: "1.0.0"
+        service_id: "svc-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+		Features:         []string{"search"},
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	// The enabled flag's method is served
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/versions:search?q=1.0")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// Every method behind a disabled flag is not
+	for _, path := range []string{
+		"/v1/services/svc-1/versions:stream",
+		"/v1/services:export",
+		"/v1/services/svc-1/history",
+	} {
+		t.Run(path, func(t *testing.T) {
+			resp, err := http.Get("http://" + a.httpAddr + path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, http.StatusNotImplemented, resp.StatusCode, string(body))
+		})
+	}
+}
+
+func TestApp_ExportFieldLimits(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+		Features:         config.DefaultFeatures,
+		// sort_by is only limited by the interceptors, search_query by the service as well
+		FieldLimits: map[string]int{"ListServices.search_query": 5, "ListServices.sort_by": 3},
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services:export?q=user")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	tests := []struct {
+		query       string
+		wantMessage string
+	}{
+		{"q=payments", "search_query has 8 characters, the limit is 5"},
+		{"sort_by=name", "sort_by has 4 characters, the limit is 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			resp, err := http.Get("http://" + a.httpAddr + "/v1/services:export?" + tt.query)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Contains(t, string(body), tt.wantMessage)
+		})
+	}
+}
+
+func TestApp_RequireAdmin_AuthDisabled(t *testing.T) {
+	const token = "0123456789abcdefghijklmnopqrstuv"
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		adminToken string
+		method     string
+		header     string
+		wantStatus int
+	}{
+		{name: "no token configured allows reads", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "no token configured refuses changes", method: http.MethodPut, wantStatus: http.StatusForbidden},
+		{name: "matching token allows changes", adminToken: token, method: http.MethodPut, header: token, wantStatus: http.StatusOK},
+		{name: "missing token refused", adminToken: token, method: http.MethodGet, wantStatus: http.StatusForbidden},
+		{name: "wrong token refused", adminToken: token, method: http.MethodPut, header: token[1:] + "x", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &App{config: &config.Config{AdminToken: tt.adminToken}}
+			req := httptest.NewRequest(tt.method, "/admin/read-only", nil)
+			if tt.header != "" {
+				req.Header.Set(adminTokenHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			a.requireAdmin(ok).ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestApp_Start_H2C(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+		EnableH2C:        true,
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	// An HTTP/2 prior-knowledge client dials plain TCP where it would normally use TLS
+	h2cClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+
+	get := func(client *http.Client) (*http.Response, []byte) {
+		var resp *http.Response
+		var body []byte
+		assert.Eventually(t, func() bool {
+			var err error
+			resp, err = client.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			body, _ = io.ReadAll(resp.Body)
+			return resp.StatusCode == http.StatusOK
+		}, 5*time.Second, 20*time.Millisecond)
+		return resp, body
+	}
+
+	resp, body := get(h2cClient)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, 2, resp.ProtoMajor)
+	}
+	assert.Contains(t, string(body), "User Service")
+
+	// HTTP/1.1 keeps working on the same port
+	resp, body = get(http.DefaultClient)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, 1, resp.ProtoMajor)
+	}
+	assert.Contains(t, string(body), "User Service")
+}
+
+// freePort returns a TCP port that was free on the loopback interface
+func freePort(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return strconv.Itoa(lis.Addr().(*net.TCPAddr).Port)
+}
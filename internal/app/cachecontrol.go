@@ -0,0 +1,68 @@
+package app
+
+import (
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ankittk/catalog-service/internal/config"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// cacheControlNoStore keeps credentials and errors out of every cache
+const cacheControlNoStore = "no-store"
+
+// cacheControlPolicy sets the Cache-Control header of gateway responses based on the route, identified by
+// the response type since the gateway does not expose the matched route to response options
+type cacheControlPolicy struct {
+	list    string
+	service string
+	// varyAuthorization keeps caches from serving one caller's response to another
+	varyAuthorization bool
+}
+
+// newCacheControlPolicy builds the cache policy from configuration
+func newCacheControlPolicy(cfg *config.Config) *cacheControlPolicy {
+	return &cacheControlPolicy{
+		list:              cfg.CacheControlList,
+		service:           cfg.CacheControlService,
+		varyAuthorization: cfg.EnableAuth,
+	}
+}
+
+// applySuccess sets the caching headers of a successful gateway response, leaving Cache-Control unset
+// when the route's configured value is empty
+func (p *cacheControlPolicy) applySuccess(w http.ResponseWriter, resp proto.Message) {
+	switch resp.(type) {
+	case *v1.ActivateVersionAcrossServicesResponse, *v1.TouchServiceResponse, *v1.CreateServicesResponse:
+		// Responses to mutations must never be replayed from a cache
+		p.set(w, cacheControlNoStore)
+	case *v1.ListAuditEventsResponse:
+		// The audit log is admin-only and changes with every mutation
+		p.set(w, cacheControlNoStore)
+	case *v1.GetServiceResponse:
+		// GET /v1/services/{id}
+		p.set(w, p.service)
+	default:
+		p.set(w, p.list)
+	}
+}
+
+// applyError marks an error response as not cacheable, so a transient failure is not served from cache
+func (p *cacheControlPolicy) applyError(w http.ResponseWriter) {
+	p.set(w, cacheControlNoStore)
+}
+
+// applyAuth marks an authentication response, which carries a token, as not cacheable
+func (p *cacheControlPolicy) applyAuth(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", cacheControlNoStore)
+}
+
+func (p *cacheControlPolicy) set(w http.ResponseWriter, value string) {
+	if value != "" {
+		w.Header().Set("Cache-Control", value)
+	}
+	if p.varyAuthorization {
+		w.Header().Add("Vary", "Authorization")
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// requireJSON rejects POST, PUT and PATCH requests whose Content-Type is not application/json with
+// 415 Unsupported Media Type, so a form post fails clearly instead of with a decode error.
+// Parameters such as charset are accepted.
+func requireJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				logger.Get().Debugw("Rejected request body with unsupported content type",
+					"path", r.URL.Path, "content_type", r.Header.Get("Content-Type"))
+				http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
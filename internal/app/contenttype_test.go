@@ -0,0 +1,45 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+)
+
+func TestRequireJSON(t *testing.T) {
+	login := requireJSON(http.HandlerFunc(auth.NewAuthHandler(auth.NewJWTManager("test-secret-key", time.Hour), nil).Login))
+	body := `{"email": "admin@org1.com", "password": "admin123", "organization": "org-1"}`
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "json", method: http.MethodPost, contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "json with charset", method: http.MethodPost, contentType: "application/json; charset=utf-8", wantStatus: http.StatusOK},
+		{name: "media type is case insensitive", method: http.MethodPost, contentType: "Application/JSON", wantStatus: http.StatusOK},
+		{name: "plain text", method: http.MethodPost, contentType: "text/plain", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "form post", method: http.MethodPost, contentType: "application/x-www-form-urlencoded", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "missing content type", method: http.MethodPost, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "requests without a body are not checked", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/auth/login", strings.NewReader(body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			login.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ankittk/catalog-service/internal/config"
+)
+
+// corsAllowedMethods are the methods cross-origin callers may use
+var corsAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+
+// requestIDHeader carries the request ID callers can quote in support tickets
+const requestIDHeader = "X-Request-Id"
+
+// corsAllowedHeaders are the request headers cross-origin callers may send
+var corsAllowedHeaders = []string{"Content-Type", "Authorization", "X-Requested-With", requestIDHeader,
+	"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+
+// corsExposedHeaders are the response headers cross-origin callers may read
+var corsExposedHeaders = []string{"X-Error-Reason", requestIDHeader, "Grpc-Status", "Grpc-Message"}
+
+// corsPolicy applies the configured CORS rules to HTTP responses
+type corsPolicy struct {
+	origins          []string
+	anyOrigin        bool
+	allowCredentials bool
+	maxAge           time.Duration
+}
+
+// newCORSPolicy builds the CORS policy from configuration
+func newCORSPolicy(cfg *config.Config) *corsPolicy {
+	p := &corsPolicy{
+		allowCredentials: cfg.CORSAllowCredentials,
+		maxAge:           cfg.CORSMaxAge,
+	}
+	for _, origin := range strings.Split(cfg.CORSOrigins, ",") {
+		origin = strings.TrimSpace(origin)
+		switch origin {
+		case "":
+		case "*":
+			p.anyOrigin = true
+		default:
+			p.origins = append(p.origins, origin)
+		}
+	}
+	return p
+}
+
+// apply sets CORS headers for the request and answers preflight requests with 200
+func (p *corsPolicy) apply(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != ""
+
+	w.Header().Add("Vary", "Origin")
+	if preflight {
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if origin != "" && p.originAllowed(origin) {
+		// A literal "*" is only safe without credentials, otherwise echo the specific origin
+		if p.anyOrigin && !p.allowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.allowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			p.applyPreflight(w, r)
+		} else {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+		}
+	}
+
+	// Handle preflight requests for CORS
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+}
+
+// applyPreflight sets only the preflight headers relevant to the method and headers the browser asked for
+func (p *corsPolicy) applyPreflight(w http.ResponseWriter, r *http.Request) {
+	if method := r.Header.Get("Access-Control-Request-Method"); containsFold(corsAllowedMethods, method) {
+		w.Header().Set("Access-Control-Allow-Methods", method)
+	}
+
+	var headers []string
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && containsFold(corsAllowedHeaders, header) {
+			headers = append(headers, header)
+		}
+	}
+	if len(headers) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	}
+
+	if p.maxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(p.maxAge.Seconds())))
+	}
+}
+
+// originAllowed reports whether the origin matches the configured origins
+func (p *corsPolicy) originAllowed(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	for _, allowed := range p.origins {
+		if allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ankittk/catalog-service/internal/config"
+)
+
+func TestCORSPolicy_PublicWithoutCredentials(t *testing.T) {
+	policy := newCORSPolicy(&config.Config{CORSOrigins: "*", CORSMaxAge: 10 * time.Minute})
+
+	t.Run("preflight", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/services", nil)
+		req.Header.Set("Origin", "https://anyone.example.com")
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		req.Header.Set("Access-Control-Request-Headers", "authorization, x-unknown")
+		rec := httptest.NewRecorder()
+
+		policy.apply(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("disallowed method in preflight", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/services", nil)
+		req.Header.Set("Origin", "https://anyone.example.com")
+		req.Header.Set("Access-Control-Request-Method", "PATCH")
+		rec := httptest.NewRecorder()
+
+		policy.apply(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Headers"))
+	})
+
+	t.Run("actual request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/services", nil)
+		req.Header.Set("Origin", "https://anyone.example.com")
+		rec := httptest.NewRecorder()
+
+		policy.apply(rec, req)
+
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "X-Error-Reason, X-Request-Id, Grpc-Status, Grpc-Message", rec.Header().Get("Access-Control-Expose-Headers"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Max-Age"))
+	})
+}
+
+func TestCORSPolicy_StrictOriginWithCredentials(t *testing.T) {
+	policy := newCORSPolicy(&config.Config{
+		CORSOrigins:          "https://app.example.com, https://admin.example.com",
+		CORSAllowCredentials: true,
+	})
+
+	t.Run("allowed origin preflight", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/admin/read-only", nil)
+		req.Header.Set("Origin", "https://admin.example.com")
+		req.Header.Set("Access-Control-Request-Method", "PUT")
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type, Authorization")
+		rec := httptest.NewRecorder()
+
+		policy.apply(rec, req)
+
+		assert.Equal(t, "https://admin.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "PUT", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Max-Age"))
+		assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("unknown origin gets no CORS headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/services", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec := httptest.NewRecorder()
+
+		policy.apply(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	})
+}
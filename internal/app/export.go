@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+
+	grpcserver "github.com/ankittk/catalog-service/internal/api/grpc"
+	"github.com/ankittk/catalog-service/internal/interceptor"
+	"github.com/ankittk/catalog-service/internal/logger"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+const (
+	// exportPath is served by exportHandler instead of the gateway
+	exportPath = "/v1/services:export"
+
+	// exportMethod names the export to interceptors, like the full method name of an RPC
+	exportMethod = grpcserver.ExportMethod
+
+	// exportContentType is the media type of JSON Lines
+	exportContentType = "application/x-ndjson"
+
+	// exportFlushInterval is how many lines are written between flushes, so consumers receive the
+	// export incrementally without a flush per service
+	exportFlushInterval = 100
+)
+
+// exportFilters maps the query parameters of the export to the ListServices filters they set
+var exportFilters = map[string]func(*v1.ListServicesRequest, string){
+	"organization_id": func(req *v1.ListServicesRequest, v string) { req.OrganizationId = v },
+	"search_query":    func(req *v1.ListServicesRequest, v string) { req.SearchQuery = v },
+	"search_fields":   func(req *v1.ListServicesRequest, v string) { req.SearchFields = v },
+	"search_match":    func(req *v1.ListServicesRequest, v string) { req.SearchMatch = v },
+	"version":         func(req *v1.ListServicesRequest, v string) { req.Version = v },
+	"sort_by":         func(req *v1.ListServicesRequest, v string) { req.SortBy = v },
+	"sort_order":      func(req *v1.ListServicesRequest, v string) { req.SortOrder = v },
+}
+
+// exportHandler streams the services matching the ListServices filters as JSON Lines, one service per line,
+// so ETL consumers can process the catalog incrementally instead of parsing a single array.
+// Errors found before the first line are written like gateway errors.
+type exportHandler struct {
+	srv       *grpcserver.Server
+	mux       *runtime.ServeMux
+	marshaler runtime.Marshaler
+	cache     *cacheControlPolicy
+	// guard runs the export through the interceptors protecting RPCs, nil runs it directly
+	guard grpc.UnaryServerInterceptor
+	// streamTimeout ends exports that have not completed in time and sendTimeout those whose client does
+	// not take a line in time, 0 disables either
+	streamTimeout time.Duration
+	sendTimeout   time.Duration
+}
+
+// newExportHandler creates the export handler. marshaler writes both the lines and error responses, so it
+// must not indent; mux supplies the gateway's error handling options.
+func newExportHandler(srv *grpcserver.Server, mux *runtime.ServeMux, marshaler runtime.Marshaler, cache *cacheControlPolicy, guard grpc.UnaryServerInterceptor, streamTimeout, sendTimeout time.Duration) *exportHandler {
+	return &exportHandler{srv: srv, mux: mux, marshaler: marshaler, cache: cache, guard: guard, streamTimeout: streamTimeout, sendTimeout: sendTimeout}
+}
+
+// ServeHTTP handles GET /v1/services:export
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	if h.streamTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.streamTimeout)
+		defer cancel()
+		w.Header().Set(interceptor.ResponseTimeoutHeader, interceptor.FormatResponseTimeout(h.streamTimeout))
+	}
+
+	// Write deadlines fail the writes to a client that stopped reading instead of blocking on it
+	rc := http.NewResponseController(w)
+	if h.streamTimeout > 0 || h.sendTimeout > 0 {
+		defer func() { _ = rc.SetWriteDeadline(time.Time{}) }()
+	}
+
+	req := exportRequest(r)
+	flusher, _ := w.(http.Flusher)
+	lines := 0
+	send := func(s *v1.Service) error {
+		line, err := h.marshaler.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if lines == 0 {
+			w.Header().Set("Content-Type", exportContentType)
+			h.cache.set(w, h.cache.list)
+		}
+		if deadline := h.writeDeadline(ctx); !deadline.IsZero() {
+			_ = rc.SetWriteDeadline(deadline)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		lines++
+		if flusher != nil && lines%exportFlushInterval == 0 {
+			flusher.Flush()
+		}
+		return nil
+	}
+	err := h.export(ctx, req, send)
+
+	if err != nil && lines > 0 {
+		// The status line is gone, the consumer sees a truncated stream
+		logger.Get().Warnw("Export ended early", "lines", lines, "error", err)
+		return
+	}
+	if err != nil {
+		h.cache.applyError(w)
+		gatewayErrorHandler(r.Context(), h.mux, h.marshaler, w, r, err)
+		return
+	}
+	if lines == 0 {
+		w.Header().Set("Content-Type", exportContentType)
+		h.cache.set(w, h.cache.list)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// export passes the services matching req to send, through the guard when there is one
+func (h *exportHandler) export(ctx context.Context, req *v1.ListServicesRequest, send func(*v1.Service) error) error {
+	run := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, h.srv.ExportServices(ctx, req, send)
+	}
+	if h.guard == nil {
+		_, err := run(ctx, req)
+		return err
+	}
+	_, err := h.guard(ctx, req, &grpc.UnaryServerInfo{FullMethod: exportMethod}, run)
+	return err
+}
+
+// writeDeadline returns when the next line must be written, the earlier of the send timeout from now and the
+// export deadline, or zero without either
+func (h *exportHandler) writeDeadline(ctx context.Context) time.Time {
+	deadline, _ := ctx.Deadline()
+	if h.sendTimeout > 0 {
+		if send := time.Now().Add(h.sendTimeout); deadline.IsZero() || send.Before(deadline) {
+			deadline = send
+		}
+	}
+	return deadline
+}
+
+// exportRequest builds the list request from the query parameters, accepting the gateway's aliases such as
+// q for search_query
+func exportRequest(r *http.Request) *v1.ListServicesRequest {
+	query := r.URL.Query()
+	for alias, field := range queryAliases {
+		if _, set := query[field]; !set && query.Has(alias) {
+			query.Set(field, query.Get(alias))
+		}
+	}
+
+	req := &v1.ListServicesRequest{OrganizationIds: query["organization_ids"]}
+	for param, set := range exportFilters {
+		if v := query.Get(param); v != "" {
+			set(req, v)
+		}
+	}
+	return req
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	grpcserver "github.com/ankittk/catalog-service/internal/api/grpc"
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/config"
+	"github.com/ankittk/catalog-service/internal/interceptor"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+func TestExportHandler(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "Payment Service"
+    organization_id: "org-1"
+    versions:
+      - id: "v1"
+        version: "1.0.0"
+        service_id: "svc-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-1"
+  - id: "svc-3"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-4"
+    name: "Payment Service"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	require.NoError(t, err)
+
+	cfg := &config.Config{CacheControlList: "max-age=30", JSONPretty: true}
+	cachePolicy := newCacheControlPolicy(cfg)
+	gwmux := newGatewayMux(cachePolicy, newGatewayMarshaler(cfg))
+	handler := newExportHandler(srv, gwmux, newJSONMarshaler(cfg, false), cachePolicy, nil, time.Minute, time.Second)
+
+	// export reads the stream line by line, parsing each line as a service
+	export := func(req *http.Request) (*httptest.ResponseRecorder, []*v1.Service) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var services []*v1.Service
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			var s v1.Service
+			require.NoError(t, protojson.Unmarshal(scanner.Bytes(), &s), scanner.Text())
+			services = append(services, &s)
+		}
+		require.NoError(t, scanner.Err())
+		return rec, services
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		orgID   string
+		wantIDs []string
+	}{
+		{name: "every service", target: "/v1/services:export", wantIDs: []string{"svc-2", "svc-1", "svc-4", "svc-3"}},
+		{name: "filters with the q alias", target: "/v1/services:export?organization_id=org-1&q=pay&sort_order=desc", wantIDs: []string{"svc-1", "svc-2"}},
+		{name: "version filter", target: "/v1/services:export?version=1.0.0", wantIDs: []string{"svc-1"}},
+		{name: "scoped to the caller's organization", target: "/v1/services:export?q=payment", orgID: "org-2", wantIDs: []string{"svc-4"}},
+		{name: "another organization is hidden", target: "/v1/services:export?organization_id=org-1", orgID: "org-2"},
+		{name: "several organizations", target: "/v1/services:export?organization_ids=org-1&organization_ids=org-2&q=payment", wantIDs: []string{"svc-2", "svc-1", "svc-4"}},
+		{name: "several organizations scoped to the caller's", target: "/v1/services:export?organization_ids=org-1&organization_ids=org-2", orgID: "org-2", wantIDs: []string{"svc-4"}},
+		{name: "no match", target: "/v1/services:export?q=inventory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.orgID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user", &auth.Claims{Organization: tt.orgID}))
+			}
+			rec, services := export(req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+			assert.Equal(t, "max-age=30", rec.Header().Get("Cache-Control"))
+			assert.Equal(t, "60", rec.Header().Get("X-Response-Timeout"))
+			var ids []string
+			for _, s := range services {
+				ids = append(ids, s.GetId())
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+
+	t.Run("versions are exported", func(t *testing.T) {
+		_, services := export(httptest.NewRequest(http.MethodGet, "/v1/services:export?version=1.0.0", nil))
+		require.Len(t, services, 1)
+		require.Len(t, services[0].GetVersions(), 1)
+		assert.Equal(t, "1.0.0", services[0].GetVersions()[0].GetVersion())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services:export?organization_id=org%20one", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("stalled client is cut off", func(t *testing.T) {
+		stalling := newExportHandler(srv, gwmux, newJSONMarshaler(cfg, false), cachePolicy, nil, time.Minute, 50*time.Millisecond)
+		w := &stalledWriter{ResponseRecorder: httptest.NewRecorder()}
+		start := time.Now()
+		stalling.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/services:export", nil))
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "\n"), "only the line taken before the client stalled")
+		assert.True(t, w.deadline.IsZero(), "the write deadline is cleared")
+	})
+
+	t.Run("guarded by the RPC interceptors", func(t *testing.T) {
+		limiter := interceptor.NewConcurrencyLimiter(1, time.Second)
+		guarded := newExportHandler(srv, gwmux, newJSONMarshaler(cfg, false), cachePolicy, limiter.UnaryInterceptor(), time.Minute, time.Second)
+
+		// an export while the only slot is taken is shed like an RPC
+		hold := limiter.UnaryInterceptor()
+		_, _ = hold(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			rec := httptest.NewRecorder()
+			guarded.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services:export", nil))
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+			return nil, nil
+		})
+
+		// the freed slot serves the export
+		rec := httptest.NewRecorder()
+		guarded.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services:export", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 4, strings.Count(rec.Body.String(), "\n"))
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/services:export", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, http.MethodGet, rec.Header().Get("Allow"))
+	})
+}
+
+// stalledWriter is a response writer whose client stops reading after the first line: later writes block until
+// the write deadline passes
+type stalledWriter struct {
+	*httptest.ResponseRecorder
+	deadline time.Time
+	writes   int
+}
+
+func (w *stalledWriter) SetWriteDeadline(deadline time.Time) error {
+	w.deadline = deadline
+	return nil
+}
+
+func (w *stalledWriter) Write(b []byte) (int, error) {
+	w.writes++
+	if w.writes == 1 {
+		return w.ResponseRecorder.Write(b)
+	}
+	if w.deadline.IsZero() {
+		// Without a deadline the client would hold the export forever
+		time.Sleep(time.Second)
+	}
+	time.Sleep(time.Until(w.deadline))
+	return 0, os.ErrDeadlineExceeded
+}
@@ -0,0 +1,518 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	grpcserver "github.com/ankittk/catalog-service/internal/api/grpc"
+	"github.com/ankittk/catalog-service/internal/config"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+func TestGatewayMux_RequestIDHeader(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	gwmux := newGatewayMux(newCacheControlPolicy(&config.Config{}), newGatewayMarshaler(&config.Config{}))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	t.Run("generated ID", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, strings.HasPrefix(rec.Header().Get("X-Request-Id"), "trace_"))
+	})
+
+	t.Run("propagated ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/services/svc-1", nil)
+		req.Header.Set("X-Request-Id", "ticket-42")
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ticket-42", rec.Header().Get("X-Request-Id"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services/svc-9", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "SERVICE_NOT_FOUND", rec.Header().Get("X-Error-Reason"))
+		assert.NotEmpty(t, rec.Header().Get("X-Request-Id"))
+	})
+}
+
+func TestGatewayMux_CacheControl(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		enableAuth   bool
+		path         string
+		wantCache    string
+		wantVaryAuth bool
+	}{
+		{name: "list", path: "/v1/services", wantCache: "max-age=30"},
+		{name: "single service", path: "/v1/services/svc-1", wantCache: "max-age=300"},
+		{name: "service versions", path: "/v1/services/svc-1/versions", wantCache: "max-age=30"},
+		{name: "catalog summary", path: "/v1/catalog", wantCache: "max-age=30"},
+		{name: "error", path: "/v1/services/svc-9", wantCache: "no-store"},
+		{name: "auth enabled varies by caller", enableAuth: true, path: "/v1/services/svc-1", wantCache: "max-age=300", wantVaryAuth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{CacheControlList: "max-age=30", CacheControlService: "max-age=300", EnableAuth: tt.enableAuth}
+			gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+			assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantCache, rec.Header().Get("Cache-Control"))
+			assert.Equal(t, tt.wantVaryAuth, containsFold(rec.Header().Values("Vary"), "Authorization"))
+		})
+	}
+
+	t.Run("empty value omits the header", func(t *testing.T) {
+		cfg := &config.Config{CacheControlService: "max-age=300"}
+		gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+		assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("auth endpoint", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newCacheControlPolicy(&config.Config{EnableAuth: true}).applyAuth(rec)
+
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	})
+}
+
+func TestGatewayMux_JSONOptions(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "emit defaults",
+			cfg:         &config.Config{JSONEmitDefaults: true},
+			contains:    []string{`"hasBreakingChange":false`, `"description":""`, `"organizationId":"org-1"`},
+			notContains: []string{`"organization_id"`},
+		},
+		{
+			name:        "omit defaults",
+			cfg:         &config.Config{},
+			contains:    []string{`"organizationId":"org-1"`},
+			notContains: []string{`"hasBreakingChange"`, `"description"`},
+		},
+		{
+			name:        "proto names",
+			cfg:         &config.Config{JSONEmitDefaults: true, JSONUseProtoNames: true},
+			contains:    []string{`"has_breaking_change":false`, `"organization_id":"org-1"`},
+			notContains: []string{`"organizationId"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwmux := newGatewayMux(newCacheControlPolicy(tt.cfg), newGatewayMarshaler(tt.cfg))
+			assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services/svc-1", nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			body := strings.ReplaceAll(rec.Body.String(), " ", "")
+			for _, s := range tt.contains {
+				assert.Contains(t, body, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, body, s)
+			}
+		})
+	}
+}
+
+func TestGatewayMux_PrettyJSON(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		target     string
+		wantPretty bool
+	}{
+		{name: "compact by default", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services/svc-1"},
+		{name: "pretty requested", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services/svc-1?pretty=true", wantPretty: true},
+		{name: "bare pretty", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services/svc-1?pretty", wantPretty: true},
+		{name: "pretty false", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services/svc-1?pretty=false"},
+		{name: "pretty list with filters", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services?organization_id=org-1&pretty=1", wantPretty: true},
+		{name: "pretty error", cfg: &config.Config{JSONPrettyParam: true}, target: "/v1/services/svc-9?pretty=true", wantPretty: true},
+		{name: "parameter ignored when disabled", cfg: &config.Config{}, target: "/v1/services/svc-1?pretty=true"},
+		{name: "always pretty", cfg: &config.Config{JSONPretty: true}, target: "/v1/services/svc-1", wantPretty: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwmux := newGatewayMux(newCacheControlPolicy(tt.cfg), newGatewayMarshaler(tt.cfg), prettyJSONOptions(tt.cfg)...)
+			assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			body := rec.Body.String()
+			if tt.wantPretty {
+				assert.Contains(t, body, "{\n  \"")
+			} else {
+				assert.NotContains(t, body, "\n  ")
+			}
+			assert.True(t, json.Valid(rec.Body.Bytes()), body)
+		})
+	}
+}
+
+func TestGatewayMux_BatchGetServices(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	tests := []struct {
+		name        string
+		query       string
+		wantCode    int
+		wantFound   []string
+		wantMissing []string
+	}{
+		{name: "comma separated", query: "ids=svc-2,svc-9,svc-1", wantCode: http.StatusOK, wantFound: []string{"svc-2", "svc-1"}, wantMissing: []string{"svc-9"}},
+		{name: "url encoded and padded", query: "ids=%20svc-1%20%2C%20svc-8%20", wantCode: http.StatusOK, wantFound: []string{"svc-1"}, wantMissing: []string{"svc-8"}},
+		{name: "repeated parameter", query: "ids=svc-1&ids=svc-2&ids=svc-1", wantCode: http.StatusOK, wantFound: []string{"svc-1", "svc-2"}},
+		{name: "all missing", query: "ids=svc-8,svc-9", wantCode: http.StatusOK, wantMissing: []string{"svc-8", "svc-9"}},
+		{name: "no ids", query: "ids=,", wantCode: http.StatusBadRequest},
+		{name: "invalid id", query: "ids=svc-1,svc!", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services:batchGet?"+tt.query, nil))
+
+			assert.Equal(t, tt.wantCode, rec.Code, rec.Body.String())
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var resp v1.BatchGetServicesResponse
+			assert.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+			var found []string
+			for _, s := range resp.GetServices() {
+				found = append(found, s.GetId())
+			}
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantMissing, resp.GetMissingIds())
+		})
+	}
+}
+
+func TestGatewayMux_DiffServices(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services/svc-1:diff?other_service_id=svc-2", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp v1.DiffServicesResponse
+	assert.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+	var fields []string
+	for _, d := range resp.GetDifferences() {
+		fields = append(fields, d.GetField())
+	}
+	assert.Equal(t, []string{"name", "organization_id"}, fields)
+
+	// The verb does not shadow GetService
+	rec = httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services/svc-1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestGatewayMux_PageLinks(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Service"
+    organization_id: "org-1"
+  - id: "svc-3"
+    name: "Billing Service"
+    organization_id: "org-1"
+  - id: "svc-4"
+    name: "Search Service"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	list := func(target string) *v1.ListServicesResponse {
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp v1.ListServicesResponse
+		assert.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+		return &resp
+	}
+
+	first := "http://catalog.example.com/v1/services?organization_id=org-1&search_query=service&sort_by=name&sort_order=desc&page_size=1"
+	resp := list(first)
+	assert.Equal(t, first, resp.GetLinks().GetSelf())
+
+	var names []string
+	var tokens []string
+	for resp.GetLinks().GetNext() != "" {
+		names = append(names, resp.GetServices()[0].GetName())
+		next, err := url.Parse(resp.GetLinks().GetNext())
+		assert.NoError(t, err)
+
+		// Every filter round-trips and only the page token advances
+		query := next.Query()
+		assert.Equal(t, "catalog.example.com", next.Host)
+		assert.Equal(t, "/v1/services", next.Path)
+		assert.Equal(t, "org-1", query.Get("organization_id"))
+		assert.Equal(t, "service", query.Get("search_query"))
+		assert.Equal(t, "name", query.Get("sort_by"))
+		assert.Equal(t, "desc", query.Get("sort_order"))
+		assert.Equal(t, "1", query.Get("page_size"))
+		assert.Equal(t, resp.GetNextPageToken(), query.Get("page_token"))
+		assert.NotContains(t, tokens, query.Get("page_token"))
+		tokens = append(tokens, query.Get("page_token"))
+
+		resp = list(next.String())
+		assert.Equal(t, next.String(), resp.GetLinks().GetSelf())
+	}
+	names = append(names, resp.GetServices()[0].GetName())
+
+	assert.Equal(t, []string{"User Service", "Payment Service", "Billing Service"}, names)
+	assert.Empty(t, resp.GetNextPageToken())
+}
+
+func TestGatewayMux_ListServicesQueryParams(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "Payment Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "Payment Gateway"
+    organization_id: "org-1"
+  - id: "svc-3"
+    name: "Payroll Service"
+    organization_id: "org-1"
+  - id: "svc-4"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-5"
+    name: "Payment Service"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	list := func(target string) ([]string, int32) {
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp v1.ListServicesResponse
+		assert.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+		var ids []string
+		for _, s := range resp.GetServices() {
+			ids = append(ids, s.GetId())
+		}
+		return ids, resp.GetTotalCount()
+	}
+
+	tests := []struct {
+		name      string
+		target    string
+		wantIDs   []string
+		wantTotal int32
+	}{
+		{
+			name:      "every parameter with the q alias",
+			target:    "/v1/services?sort_by=name&sort_order=desc&organization_id=org-1&q=pay&page_size=2",
+			wantIDs:   []string{"svc-3", "svc-1"},
+			wantTotal: 3,
+		},
+		{
+			name:      "search_query",
+			target:    "/v1/services?sort_by=name&sort_order=asc&organization_id=org-1&search_query=pay",
+			wantIDs:   []string{"svc-2", "svc-1", "svc-3"},
+			wantTotal: 3,
+		},
+		{
+			name:      "camelCase parameters",
+			target:    "/v1/services?sortBy=name&sortOrder=desc&organizationId=org-2&searchQuery=pay",
+			wantIDs:   []string{"svc-5"},
+			wantTotal: 1,
+		},
+		{
+			name:      "search_query takes precedence over q",
+			target:    "/v1/services?organization_id=org-1&search_query=user&q=pay",
+			wantIDs:   []string{"svc-4"},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, total := list(tt.target)
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
+
+func TestGatewayMux_CountServices(t *testing.T) {
+	srv, err := grpcserver.NewCatalogServerFromYAML([]byte(`
+services:
+  - id: "svc-1"
+    name: "Payment Service"
+    organization_id: "org-1"
+  - id: "svc-2"
+    name: "User Service"
+    organization_id: "org-1"
+  - id: "svc-3"
+    name: "Payment Service"
+    organization_id: "org-2"
+`), grpcserver.LoadOptions{})
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+	assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, srv))
+
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services:count?organization_id=org-1&q=payment", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp v1.CountServicesResponse
+	assert.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, int32(1), resp.Count)
+}
+
+// unavailableServer fails every GetService call as a rejected request would, with a retry hint
+type unavailableServer struct {
+	v1.UnimplementedCatalogServiceServer
+	retryDelay time.Duration
+}
+
+func (s *unavailableServer) GetService(context.Context, *v1.GetServiceRequest) (*v1.GetServiceResponse, error) {
+	st, _ := status.New(codes.ResourceExhausted, "too many requests").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(s.retryDelay)})
+	return nil, st.Err()
+}
+
+func TestGatewayMux_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryDelay time.Duration
+		want       string
+	}{
+		{name: "whole seconds", retryDelay: 2 * time.Second, want: "2"},
+		{name: "rounded up", retryDelay: 1500 * time.Millisecond, want: "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			gwmux := newGatewayMux(newCacheControlPolicy(cfg), newGatewayMarshaler(cfg))
+			assert.NoError(t, v1.RegisterCatalogServiceHandlerServer(context.Background(), gwmux, &unavailableServer{retryDelay: tt.retryDelay}))
+
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/services/svc-1", nil))
+
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Retry-After"))
+		})
+	}
+}
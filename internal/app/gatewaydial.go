@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/ankittk/catalog-service/internal/config"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// gatewayRetryBackoff is the wait before the gateway's second attempt to connect, doubled for each further attempt
+var gatewayRetryBackoff = 200 * time.Millisecond
+
+// gatewayDialOptions returns the options the HTTP gateway connects to the gRPC server with
+func gatewayDialOptions(cfg *config.Config) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: gatewayDialTimeout(cfg),
+		}),
+	}
+	if cfg.GatewayKeepalive > 0 {
+		// Detect a dead backend connection instead of failing the next requests sent over it
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.GatewayKeepalive,
+			Timeout: gatewayDialTimeout(cfg),
+		}))
+	}
+	if cfg.GatewayIdleTimeout > 0 {
+		opts = append(opts, grpc.WithIdleTimeout(cfg.GatewayIdleTimeout))
+	}
+	if cfg.MaxMessageSize > 0 {
+		// The gateway must accept the largest responses the gRPC server is allowed to send
+		opts = append(opts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(int(cfg.MaxMessageSize)),
+			grpc.MaxCallSendMsgSize(int(cfg.MaxMessageSize))))
+	}
+	return opts
+}
+
+// gatewayKeepalivePolicy lets the gateway ping the gRPC server as often as GATEWAY_KEEPALIVE asks,
+// the server otherwise closes connections pinged more than every 5 minutes. Half the interval leaves room
+// for pings that arrive early.
+func gatewayKeepalivePolicy(cfg *config.Config) (keepalive.EnforcementPolicy, bool) {
+	if cfg.GatewayKeepalive <= 0 {
+		return keepalive.EnforcementPolicy{}, false
+	}
+	return keepalive.EnforcementPolicy{MinTime: cfg.GatewayKeepalive / 2}, true
+}
+
+func gatewayDialTimeout(cfg *config.Config) time.Duration {
+	if cfg.GatewayDialTimeout > 0 {
+		return cfg.GatewayDialTimeout
+	}
+	return config.DefaultGatewayDialTimeout
+}
+
+// dialGateway connects to the gRPC server at addr, waiting up to timeout for each attempt to become ready
+// and retrying up to retries more times with exponential backoff
+func dialGateway(ctx context.Context, addr string, opts []grpc.DialOption, timeout time.Duration, retries int) (*grpc.ClientConn, error) {
+	wait := gatewayRetryBackoff
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			logger.Get().Warnw("gRPC server not ready for the gateway, retrying",
+				"address", addr,
+				"attempt", attempt+1,
+				"backoff", wait.String(),
+				"error", lastErr)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			wait *= 2
+		}
+
+		// passthrough keeps the address as given, like grpc.Dial, so ":9000" reaches the local server
+		conn, err := grpc.NewClient("passthrough:///"+addr, opts...)
+		if err != nil {
+			// An invalid address or option cannot succeed on retry
+			return nil, err
+		}
+		if lastErr = waitForReady(ctx, conn, timeout); lastErr == nil {
+			return conn, nil
+		}
+		conn.Close()
+	}
+	return nil, fmt.Errorf("gRPC server at %s not ready after %d attempts: %w", addr, retries+1, lastErr)
+}
+
+// waitForReady connects conn and blocks until it is ready or timeout elapses
+func waitForReady(ctx context.Context, conn *grpc.ClientConn, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection %s: %w", state, ctx.Err())
+		}
+	}
+}
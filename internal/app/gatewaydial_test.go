@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ankittk/catalog-service/internal/config"
+)
+
+func TestDialGateway_RetriesUntilBackendReady(t *testing.T) {
+	logs := observeLogs(t)
+	addr := net.JoinHostPort("127.0.0.1", freePort(t))
+
+	// The backend only starts listening after the first attempt has timed out
+	server := grpc.NewServer()
+	t.Cleanup(server.Stop)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		_ = server.Serve(lis)
+	}()
+
+	cfg := &config.Config{GatewayDialTimeout: 100 * time.Millisecond, GatewayKeepalive: 30 * time.Second}
+	conn, err := dialGateway(context.Background(), addr, gatewayDialOptions(cfg), gatewayDialTimeout(cfg), 5)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	assert.NotEmpty(t, logs.FilterMessage("gRPC server not ready for the gateway, retrying").All())
+}
+
+func TestDialGateway_FailsAfterRetries(t *testing.T) {
+	logs := observeLogs(t)
+	addr := net.JoinHostPort("127.0.0.1", freePort(t))
+
+	cfg := &config.Config{GatewayDialTimeout: 50 * time.Millisecond}
+	_, err := dialGateway(context.Background(), addr, gatewayDialOptions(cfg), gatewayDialTimeout(cfg), 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ready after 2 attempts")
+	assert.Len(t, logs.FilterMessage("gRPC server not ready for the gateway, retrying").All(), 1)
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// withGRPCWeb serves gRPC-Web requests from browsers with the gRPC server itself, so they pass through the same
+// interceptors as native gRPC calls, and every other request with next. CORS is answered by cors rather than by
+// the grpc-web library so both protocols follow the configured CORS_ORIGINS.
+func withGRPCWeb(server *grpc.Server, cors func(http.ResponseWriter, *http.Request), next http.Handler) http.Handler {
+	wrapped := grpcweb.WrapServer(server)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !wrapped.IsGrpcWebRequest(r) && !wrapped.IsAcceptableGrpcCorsRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cors(w, r)
+		if r.Method == http.MethodOptions {
+			return
+		}
+		wrapped.HandleGrpcWebRequest(w, r)
+	})
+}
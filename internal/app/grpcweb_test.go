@@ -0,0 +1,152 @@
+package app
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ankittk/catalog-service/internal/config"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// grpcWebFrame frames a message or trailer block the way gRPC-Web clients and servers do
+func grpcWebFrame(flags byte, payload []byte) []byte {
+	frame := make([]byte, 5, 5+len(payload))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+// readGRPCWebFrames splits a gRPC-Web response body into its message payloads and trailers
+func readGRPCWebFrames(t *testing.T, body []byte) ([][]byte, map[string]string) {
+	var messages [][]byte
+	trailers := make(map[string]string)
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), 5)
+		flags, size := body[0], binary.BigEndian.Uint32(body[1:5])
+		require.GreaterOrEqual(t, uint32(len(body)-5), size)
+		payload := body[5 : 5+size]
+		body = body[5+size:]
+
+		if flags&0x80 == 0 {
+			messages = append(messages, payload)
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(payload)), "\r\n") {
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				trailers[strings.ToLower(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return messages, trailers
+}
+
+func TestApp_GRPCWeb(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(dataFile, []byte(`
+services:
+  - id: "svc-1"
+    name: "User Service"
+`), 0o600))
+
+	a := NewApp(&config.Config{
+		BindAddress:      "127.0.0.1",
+		GRPCPort:         freePort(t),
+		HTTPPort:         freePort(t),
+		LocalDataStorage: dataFile,
+		Environment:      "test",
+		CORSOrigins:      "https://app.example.com",
+		EnableGRPCWeb:    true,
+	})
+	require.NoError(t, a.Start())
+	defer func() { _ = a.Stop() }()
+
+	grpcWebCall := func(id string) (*http.Response, []byte) {
+		msg, err := proto.Marshal(&v1.GetServiceRequest{Id: id})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "http://"+a.httpAddr+"/v1.CatalogService/GetService",
+			bytes.NewReader(grpcWebFrame(0, msg)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/grpc-web+proto")
+		req.Header.Set("X-Grpc-Web", "1")
+		req.Header.Set("Origin", "https://app.example.com")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, body
+	}
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + a.httpAddr + "/health")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	t.Run("found", func(t *testing.T) {
+		resp, body := grpcWebCall("svc-1")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), "application/grpc-web"))
+		assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+		messages, trailers := readGRPCWebFrames(t, body)
+		assert.Equal(t, "0", trailers["grpc-status"])
+		require.Len(t, messages, 1)
+		var got v1.GetServiceResponse
+		require.NoError(t, proto.Unmarshal(messages[0], &got))
+		assert.Equal(t, "svc-1", got.Service.Id)
+		assert.Equal(t, "User Service", got.Service.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp, body := grpcWebCall("svc-missing")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// Errors without a message may be sent as headers only ("trailers-only" responses)
+		status := resp.Header.Get("Grpc-Status")
+		if status == "" {
+			messages, trailers := readGRPCWebFrames(t, body)
+			assert.Empty(t, messages)
+			status = trailers["grpc-status"]
+		}
+		assert.Equal(t, "5", status)
+	})
+
+	t.Run("JSON gateway unchanged", func(t *testing.T) {
+		resp, err := http.Get("http://" + a.httpAddr + "/v1/services/svc-1")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, "http://"+a.httpAddr+"/v1.CatalogService/GetService", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "content-type, x-grpc-web")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "content-type, x-grpc-web", resp.Header.Get("Access-Control-Allow-Headers"))
+	})
+}
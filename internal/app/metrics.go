@@ -0,0 +1,25 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// metricsPath serves the request latency histograms in the Prometheus text exposition format
+const metricsPath = "/metrics"
+
+// serveMetrics writes the request latency histograms recorded by the request loggers and the warmup interceptor
+func serveMetrics(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", logger.MetricsContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := logger.WriteMetrics(w); err != nil {
+		logger.Get().Errorw("Failed to write metrics", "error", err)
+	}
+}
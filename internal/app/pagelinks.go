@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// pageTokenParam is the query parameter carrying the page token of list requests
+const pageTokenParam = "page_token"
+
+// requestURLKey is the context key of the absolute URL of the HTTP request being handled by the gateway
+type requestURLKey struct{}
+
+// withRequestURL is a gateway middleware making the absolute request URL available to response options,
+// which otherwise only see the response message
+func withRequestURL(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		next(w, r.WithContext(context.WithValue(r.Context(), requestURLKey{}, absoluteURL(r))), pathParams)
+	}
+}
+
+// absoluteURL reconstructs the URL the client requested, honoring X-Forwarded-Proto from a TLS-terminating proxy
+func absoluteURL(r *http.Request) *url.URL {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	} else if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return &url.URL{Scheme: scheme, Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+}
+
+// setPageLinks fills in the self and next links of a ListServices response from the request URL,
+// keeping every filter and replacing only the page token
+func setPageLinks(ctx context.Context, resp *v1.ListServicesResponse) {
+	self, ok := ctx.Value(requestURLKey{}).(*url.URL)
+	if !ok {
+		return
+	}
+
+	links := &v1.PageLinks{Self: self.String()}
+	if resp.GetNextPageToken() != "" {
+		query := self.Query()
+		query.Set(pageTokenParam, resp.GetNextPageToken())
+		next := *self
+		next.RawQuery = query.Encode()
+		links.Next = next.String()
+	}
+	resp.Links = links
+}
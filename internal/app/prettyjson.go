@@ -0,0 +1,49 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/ankittk/catalog-service/internal/config"
+)
+
+const (
+	// prettyJSONParam is the query parameter asking for an indented response, e.g. /v1/services?pretty=true
+	prettyJSONParam = "pretty"
+	// prettyJSONMIME selects the indenting marshaler; it is only used between the middleware and the gateway
+	prettyJSONMIME = "application/x-catalog-pretty+json"
+	// prettyJSONIndent is the indentation of pretty-printed responses
+	prettyJSONIndent = "  "
+)
+
+// prettyJSONOptions returns the gateway options honoring ?pretty=true, none when JSON_PRETTY_PARAM is off
+func prettyJSONOptions(cfg *config.Config) []runtime.ServeMuxOption {
+	if !cfg.JSONPrettyParam {
+		return nil
+	}
+	return []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(prettyJSONMIME, newJSONMarshaler(cfg, true)),
+		runtime.WithMiddlewares(withPrettyJSON),
+	}
+}
+
+// withPrettyJSON is a gateway middleware selecting the indenting marshaler for requests with ?pretty or ?pretty=true.
+// The gateway picks the response marshaler by Accept header, so the parameter is turned into one.
+func withPrettyJSON(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		query := r.URL.Query()
+		if raw, ok := query[prettyJSONParam]; ok {
+			r = r.Clone(r.Context())
+			// A bare ?pretty counts as true
+			if pretty, err := strconv.ParseBool(raw[0]); raw[0] == "" || (err == nil && pretty) {
+				r.Header.Set("Accept", prettyJSONMIME)
+			}
+			// The parameter is not a request field
+			query.Del(prettyJSONParam)
+			r.URL.RawQuery = query.Encode()
+		}
+		next(w, r, pathParams)
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// queryAliases maps short query parameter names accepted by the gateway to the request fields they set
+var queryAliases = map[string]string{
+	"q": "search_query",
+}
+
+// withQueryAliases is a gateway middleware rewriting aliased query parameters, e.g.
+// GET /v1/services?q=pay, to their field names before the request is bound.
+// A field set under its own name takes precedence over its alias.
+func withQueryAliases(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		query := r.URL.Query()
+		rewritten := false
+		for alias, field := range queryAliases {
+			values, ok := query[alias]
+			if !ok {
+				continue
+			}
+			if _, set := query[field]; !set {
+				query[field] = values
+			}
+			delete(query, alias)
+			rewritten = true
+		}
+		if rewritten {
+			r = r.Clone(r.Context())
+			r.URL.RawQuery = query.Encode()
+		}
+		next(w, r, pathParams)
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// statusRecorder captures the status code written by an HTTP handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// withRequestLogging logs plain HTTP endpoints through logger.RequestLogger so they are counted in the
+// request latency histogram like the gRPC methods; quiet methods only log their failures
+func withRequestLogging(method, path string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqLogger := logger.NewRequestLogger(method, path)
+		reqLogger.LogRequest()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		var err error
+		if rec.status >= http.StatusBadRequest {
+			err = fmt.Errorf("%s returned HTTP %d", path, rec.status)
+		}
+		reqLogger.LogResponse(int(codeFromHTTPStatus(rec.status)), err)
+	})
+}
+
+// codeFromHTTPStatus maps an HTTP status to the gRPC code used to label request metrics
+func codeFromHTTPStatus(status int) codes.Code {
+	switch {
+	case status < http.StatusBadRequest:
+		return codes.OK
+	case status == http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case status == http.StatusNotFound:
+		return codes.NotFound
+	case status < http.StatusInternalServerError:
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
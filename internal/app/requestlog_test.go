@@ -0,0 +1,70 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ankittk/catalog-service/internal/health"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// observeLogs routes the global logger to an in-memory core for the duration of the test
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	t.Cleanup(func() { logger.SetLogger(previous) })
+	return logs
+}
+
+func TestWithRequestLogging_QuietMethods(t *testing.T) {
+	logs := observeLogs(t)
+	logger.SetQuietMethods(logger.DefaultQuietMethods)
+	t.Cleanup(func() { logger.SetQuietMethods(logger.DefaultQuietMethods) })
+
+	probe := health.NewProbe()
+
+	t.Run("suppressed health call is counted but not logged at info", func(t *testing.T) {
+		logs.TakeAll()
+		latency := logger.RequestLatency().WithLabelValues("Liveness", "OK", logger.AnonymousOrganization)
+		before := latency.Snapshot().Count
+
+		rec := httptest.NewRecorder()
+		withRequestLogging("Liveness", "/healthz", probe.LivenessHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, before+1, latency.Snapshot().Count)
+		assert.Zero(t, logs.FilterLevelExact(zapcore.InfoLevel).Len())
+		assert.Equal(t, 2, logs.FilterLevelExact(zapcore.DebugLevel).Len())
+	})
+
+	t.Run("failures of suppressed methods still log", func(t *testing.T) {
+		logs.TakeAll()
+		probe.SetReady(false)
+
+		rec := httptest.NewRecorder()
+		withRequestLogging("Readiness", "/ready", probe.ReadinessHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		failed := logs.FilterMessage("Request failed").All()
+		if assert.Len(t, failed, 1) {
+			assert.Equal(t, zapcore.ErrorLevel, failed[0].Level)
+		}
+	})
+
+	t.Run("unsuppressed methods log at info", func(t *testing.T) {
+		logs.TakeAll()
+		logger.SetQuietMethods(nil)
+
+		rec := httptest.NewRecorder()
+		withRequestLogging("Liveness", "/healthz", probe.LivenessHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		assert.Equal(t, 2, logs.FilterLevelExact(zapcore.InfoLevel).Len())
+	})
+}
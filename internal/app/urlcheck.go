@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ankittk/catalog-service/internal/config"
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+// unreachableURL is a service URL that failed the reachability check
+type unreachableURL struct {
+	ServiceID string
+	URL       string
+	Err       error
+}
+
+// urlCheckReport is the outcome of checking the URLs of a set of services
+type urlCheckReport struct {
+	// Checked is the number of services with a URL
+	Checked int
+	// Unreachable lists the failed URLs in the order of the services
+	Unreachable []unreachableURL
+}
+
+// checkServiceURLs sends a HEAD request to the URL of every service that has one, at most concurrency at a time.
+// A URL is unreachable when the request fails, including when ctx ends first, or answers with a 5xx status;
+// any other status shows the service is up even if it does not accept HEAD.
+func checkServiceURLs(ctx context.Context, client *http.Client, services []*model.Service, concurrency int) urlCheckReport {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var report urlCheckReport
+	errs := make([]error, len(services))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, s := range services {
+		if s.URL == "" {
+			continue
+		}
+		report.Checked++
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = headURL(ctx, client, url)
+		}(i, s.URL)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			report.Unreachable = append(report.Unreachable, unreachableURL{ServiceID: services[i].ID, URL: services[i].URL, Err: err})
+		}
+	}
+	return report
+}
+
+// headURL returns an error if a HEAD request to url fails or answers with a server error
+func headURL(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("server answered %s", resp.Status)
+	}
+	return nil
+}
+
+// checkURLs runs the startup URL check configured by URL_CHECK, logging every unreachable URL and failing
+// in fail mode. The whole check takes at most URL_CHECK_TIMEOUT.
+func (a *App) checkURLs() error {
+	mode := a.config.URLCheck
+	if mode == "" || mode == config.URLCheckOff {
+		return nil
+	}
+
+	timeout := a.config.URLCheckTimeout
+	if timeout <= 0 {
+		timeout = config.DefaultURLCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	report := checkServiceURLs(ctx, &http.Client{Timeout: timeout}, a.catalogServer.Services(), a.config.URLCheckConcurrency)
+	for _, u := range report.Unreachable {
+		logger.Get().Warnw("Service URL unreachable", "service_id", u.ServiceID, "url", u.URL, "error", u.Err)
+	}
+	logger.Get().Infow("Service URL check finished", "checked", report.Checked, "unreachable", len(report.Unreachable))
+
+	if mode == config.URLCheckFail && len(report.Unreachable) > 0 {
+		first := report.Unreachable[0]
+		return fmt.Errorf("%d of %d service URLs unreachable, first is service %q at %s: %w",
+			len(report.Unreachable), report.Checked, first.ServiceID, first.URL, first.Err)
+	}
+	return nil
+}
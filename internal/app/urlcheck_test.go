@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+func TestCheckServiceURLs_ReportsUnreachable(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	t.Cleanup(up.Close)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(broken.Close)
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	services := []*model.Service{
+		{ID: "svc-1", URL: up.URL},
+		{ID: "svc-2", URL: broken.URL},
+		{ID: "svc-3"},
+		{ID: "svc-4", URL: downURL},
+	}
+	report := checkServiceURLs(context.Background(), &http.Client{Timeout: time.Second}, services, 2)
+
+	assert.Equal(t, 3, report.Checked)
+	require.Len(t, report.Unreachable, 2)
+	assert.Equal(t, "svc-2", report.Unreachable[0].ServiceID)
+	assert.Contains(t, report.Unreachable[0].Err.Error(), "502")
+	assert.Equal(t, "svc-4", report.Unreachable[1].ServiceID)
+	assert.Equal(t, downURL, report.Unreachable[1].URL)
+}
+
+func TestCheckServiceURLs_BoundedByContext(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report := checkServiceURLs(ctx, http.DefaultClient, []*model.Service{{ID: "svc-1", URL: slow.URL}}, 1)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.Len(t, report.Unreachable, 1)
+	assert.ErrorIs(t, report.Unreachable[0].Err, context.DeadlineExceeded)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import "strings"
+
+// CredentialValidator checks login credentials, e.g. against a user database or LDAP directory
+type CredentialValidator interface {
+	// Validate returns the user ID and role of the user with the given credentials in organization,
+	// or an error wrapping ErrInvalidCredentials if they don't match
+	Validate(email, password, organization string) (userID, role string, err error)
+}
+
+// demoUser is a login accepted by DemoCredentials
+type demoUser struct {
+	Password     string
+	Organization string
+	Role         string
+}
+
+// DemoCredentials validates logins against a fixed set of demo users with plain-text passwords.
+// It is for local development and tests only.
+type DemoCredentials struct {
+	users map[string]demoUser
+}
+
+// NewDemoCredentials creates a validator accepting an admin and a user for each of org-1, org-2 and org-3,
+// e.g. admin@org1.com / admin123 and user@org1.com / user123
+func NewDemoCredentials() *DemoCredentials {
+	return &DemoCredentials{users: map[string]demoUser{
+		"admin@org1.com": {Password: "admin123", Organization: "org-1", Role: "admin"},
+		"user@org1.com":  {Password: "user123", Organization: "org-1", Role: "user"},
+		"admin@org2.com": {Password: "admin123", Organization: "org-2", Role: "admin"},
+		"user@org2.com":  {Password: "user123", Organization: "org-2", Role: "user"},
+		"admin@org3.com": {Password: "admin123", Organization: "org-3", Role: "admin"},
+		"user@org3.com":  {Password: "user123", Organization: "org-3", Role: "user"},
+	}}
+}
+
+// Validate returns the demo user's ID and role if the email, password and organization match
+func (d *DemoCredentials) Validate(email, password, organization string) (string, string, error) {
+	user, exists := d.users[email]
+	if !exists {
+		return "", "", ErrInvalidCredentials
+	}
+
+	if user.Password != password || user.Organization != organization {
+		return "", "", ErrInvalidCredentials
+	}
+
+	// Generate a simple user ID from the email's local part and domain name, e.g. "user-admin@org1"
+	userID := "user-" + strings.TrimSuffix(email, ".com")
+
+	return userID, user.Role, nil
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -25,16 +26,57 @@ type LoginResponse struct {
 	Role         string    `json:"role"`
 }
 
+// Login outcomes recorded as the outcome label of the auth_login_attempts_total counter
+const (
+	LoginOutcomeSuccess     = "success"
+	LoginOutcomeFailure     = "failure"
+	LoginOutcomeError       = "error"
+	LoginOutcomeBadRequest  = "bad_request"
+	LoginOutcomeRateLimited = "rate_limited"
+)
+
 // AuthHandler handles authentication requests
 type AuthHandler struct {
 	jwtManager *JWTManager
+	validator  CredentialValidator
+	metrics    *logger.MetricsLogger
+
+	// loginSlots bounds the logins checked at once, nil leaves them unbounded
+	loginSlots chan struct{}
 }
 
-// NewAuthHandler creates a new authentication handler
-func NewAuthHandler(jwtManager *JWTManager) *AuthHandler {
+// NewAuthHandler creates a new authentication handler checking logins with validator,
+// falling back to the demo users if validator is nil
+func NewAuthHandler(jwtManager *JWTManager, validator CredentialValidator) *AuthHandler {
+	if validator == nil {
+		validator = NewDemoCredentials()
+	}
 	return &AuthHandler{
 		jwtManager: jwtManager,
+		validator:  validator,
+		metrics:    logger.NewMetricsLogger(),
+	}
+}
+
+// SetMaxConcurrentLogins caps the logins checked at once, further logins are rejected with
+// 429 Too Many Requests to blunt credential stuffing; limit <= 0 removes the cap.
+func (h *AuthHandler) SetMaxConcurrentLogins(limit int) {
+	if limit <= 0 {
+		h.loginSlots = nil
+		return
+	}
+	h.loginSlots = make(chan struct{}, limit)
+}
+
+// recordLogin counts a login attempt by organization and outcome
+func (h *AuthHandler) recordLogin(organization, outcome string) {
+	if organization == "" {
+		organization = logger.AnonymousOrganization
 	}
+	h.metrics.LogCounter("auth_login_attempts_total", 1, map[string]string{
+		"organization": organization,
+		"outcome":      outcome,
+	})
 }
 
 // Login handles user login and token generation
@@ -48,36 +90,54 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		logger.Get().Warnw("Failed to decode login request", "error", err)
+		h.recordLogin("", LoginOutcomeBadRequest)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	// Validate request
 	if req.Email == "" || req.Password == "" || req.Organization == "" {
+		h.recordLogin(req.Organization, LoginOutcomeBadRequest)
 		http.Error(w, "Email, password, and organization are required", http.StatusBadRequest)
 		return
 	}
 
-	// In a real application, you would validate credentials against a database
-	// For demo purposes, we'll use a simple validation
-	userID, role, err := h.validateCredentials(req.Email, req.Password, req.Organization)
+	// Reject logins over the concurrency limit instead of queueing them
+	if h.loginSlots != nil {
+		select {
+		case h.loginSlots <- struct{}{}:
+			defer func() { <-h.loginSlots }()
+		default:
+			logger.Get().Warnw("Rejected login over concurrency limit", "organization", req.Organization, "limit", cap(h.loginSlots))
+			h.recordLogin(req.Organization, LoginOutcomeRateLimited)
+			http.Error(w, "Too many concurrent logins, retry later", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	userID, role, err := h.validator.Validate(req.Email, req.Password, req.Organization)
 	if err != nil {
-		logger.Get().Warnw("Invalid credentials", "email", req.Email, "organization", req.Organization)
+		// Any failure is reported as invalid credentials so callers learn nothing about the backend
+		if errors.Is(err, ErrInvalidCredentials) {
+			logger.Get().Warnw("Invalid credentials", "email", logger.Redact("email", req.Email), "organization", req.Organization)
+			h.recordLogin(req.Organization, LoginOutcomeFailure)
+		} else {
+			logger.Get().Errorw("Failed to validate credentials", "error", err, "email", logger.Redact("email", req.Email), "organization", req.Organization)
+			h.recordLogin(req.Organization, LoginOutcomeError)
+		}
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtManager.GenerateToken(userID, req.Email, req.Organization, role)
+	// Generate JWT token, its lifetime depends on the role
+	token, expiresAt, err := h.jwtManager.GenerateTokenWithExpiry(userID, req.Email, req.Organization, role)
 	if err != nil {
 		logger.Get().Errorw("Failed to generate token", "error", err, "user_id", userID)
+		h.recordLogin(req.Organization, LoginOutcomeError)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	// Calculate expiration time
-	expiresAt := time.Now().Add(h.jwtManager.TokenDuration())
-
 	// Create response
 	response := LoginResponse{
 		Token:        token,
@@ -88,6 +148,8 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		Role:         role,
 	}
 
+	h.recordLogin(req.Organization, LoginOutcomeSuccess)
+
 	// Return response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -99,62 +161,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	logger.Get().Infow("User logged in successfully",
 		"user_id", userID,
-		"email", req.Email,
+		"email", logger.Redact("email", req.Email),
 		"organization", req.Organization,
 		"role", role)
 }
-
-// validateCredentials validates user credentials
-func (h *AuthHandler) validateCredentials(email, password, organization string) (string, string, error) {
-	// Demo credentials: in production, use a proper authentication system
-	demoUsers := map[string]struct {
-		Password     string
-		Organization string
-		Role         string
-	}{
-		"admin@org1.com": {
-			Password:     "admin123",
-			Organization: "org-1",
-			Role:         "admin",
-		},
-		"user@org1.com": {
-			Password:     "user123",
-			Organization: "org-1",
-			Role:         "user",
-		},
-		"admin@org2.com": {
-			Password:     "admin123",
-			Organization: "org-2",
-			Role:         "admin",
-		},
-		"user@org2.com": {
-			Password:     "user123",
-			Organization: "org-2",
-			Role:         "user",
-		},
-		"admin@org3.com": {
-			Password:     "admin123",
-			Organization: "org-3",
-			Role:         "admin",
-		},
-		"user@org3.com": {
-			Password:     "user123",
-			Organization: "org-3",
-			Role:         "user",
-		},
-	}
-
-	user, exists := demoUsers[email]
-	if !exists {
-		return "", "", ErrInvalidCredentials
-	}
-
-	if user.Password != password || user.Organization != organization {
-		return "", "", ErrInvalidCredentials
-	}
-
-	// Generate a simple user ID
-	userID := "user-" + email[:len(email)-4] // Remove @xxx.com
-
-	return userID, user.Role, nil
-}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// fakeValidator records the credentials it was asked about and returns a fixed result
+type fakeValidator struct {
+	email, password, organization string
+	calls                         int
+
+	userID, role string
+	err          error
+}
+
+func (f *fakeValidator) Validate(email, password, organization string) (string, string, error) {
+	f.calls++
+	f.email, f.password, f.organization = email, password, organization
+	return f.userID, f.role, f.err
+}
+
+func login(handler *AuthHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.Login(rec, req)
+	return rec
+}
+
+func TestAuthHandler_Login_DelegatesToValidator(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret-key", time.Hour)
+	validator := &fakeValidator{userID: "ldap-42", role: "admin"}
+	handler := NewAuthHandler(jwtManager, validator)
+
+	rec := login(handler, `{"email":"jane@example.com","password":"s3cret","organization":"org-9"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, 1, validator.calls)
+	assert.Equal(t, "jane@example.com", validator.email)
+	assert.Equal(t, "s3cret", validator.password)
+	assert.Equal(t, "org-9", validator.organization)
+
+	var resp LoginResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "ldap-42", resp.UserID)
+	assert.Equal(t, "admin", resp.Role)
+	assert.Equal(t, "org-9", resp.Organization)
+
+	claims, err := jwtManager.ValidateToken(resp.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "ldap-42", claims.UserID)
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, "jane@example.com", claims.Email)
+}
+
+func TestAuthHandler_Login_ValidatorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "invalid credentials", err: ErrInvalidCredentials},
+		{name: "wrapped invalid credentials", err: errors.Join(errors.New("ldap: bind failed"), ErrInvalidCredentials)},
+		{name: "backend failure", err: errors.New("database unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAuthHandler(NewJWTManager("test-secret-key", time.Hour), &fakeValidator{err: tt.err})
+
+			rec := login(handler, `{"email":"jane@example.com","password":"wrong","organization":"org-9"}`)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "database")
+		})
+	}
+}
+
+func TestDemoCredentials_Validate(t *testing.T) {
+	demo := NewDemoCredentials()
+
+	userID, role, err := demo.Validate("admin@org1.com", "admin123", "org-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-admin@org1", userID)
+	assert.Equal(t, "admin", role)
+
+	_, _, err = demo.Validate("admin@org1.com", "admin123", "org-2")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, _, err = demo.Validate("admin@org1.com", "wrong", "org-1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, _, err = demo.Validate("nobody@org1.com", "admin123", "org-1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestAuthHandler_Login_RoleTokenDuration(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret-key", 24*time.Hour)
+	jwtManager.SetRoleTokenDurations(map[string]time.Duration{"admin": time.Hour})
+	handler := NewAuthHandler(jwtManager, NewDemoCredentials())
+
+	expiry := func(email, password string) time.Time {
+		rec := login(handler, `{"email":"`+email+`","password":"`+password+`","organization":"org-1"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp LoginResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		claims, err := jwtManager.ValidateToken(resp.Token)
+		require.NoError(t, err)
+		assert.WithinDuration(t, claims.ExpiresAt.Time, resp.ExpiresAt, time.Second)
+		return resp.ExpiresAt
+	}
+
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiry("admin@org1.com", "admin123"), 5*time.Second)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiry("user@org1.com", "user123"), 5*time.Second)
+}
+
+func TestAuthHandler_Login_LogsNoSecrets(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	logger.SetRedactedFields([]string{"email"})
+	t.Cleanup(func() {
+		logger.SetLogger(previous)
+		logger.SetRedactedFields(nil)
+	})
+
+	handler := NewAuthHandler(NewJWTManager("test-secret-key", time.Hour), NewDemoCredentials())
+	assert.Equal(t, http.StatusOK, login(handler, `{"email":"admin@org1.com","password":"admin123","organization":"org-1"}`).Code)
+	assert.Equal(t, http.StatusUnauthorized, login(handler, `{"email":"admin@org1.com","password":"hunter2-typo","organization":"org-1"}`).Code)
+
+	require.NotZero(t, logs.Len())
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			s := fmt.Sprint(value)
+			assert.NotContains(t, s, "admin123", "password logged in %q of %q", key, entry.Message)
+			assert.NotContains(t, s, "hunter2", "password logged in %q of %q", key, entry.Message)
+			assert.NotContains(t, s, "admin@org1.com", "email logged in %q of %q", key, entry.Message)
+		}
+	}
+}
+
+// blockingValidator holds every login in Validate until release is closed
+type blockingValidator struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingValidator) Validate(email, password, organization string) (string, string, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return "user-1", "user", nil
+}
+
+func observeLoginMetrics(t *testing.T) func(outcome string) []observer.LoggedEntry {
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	t.Cleanup(func() { logger.SetLogger(previous) })
+
+	return func(outcome string) []observer.LoggedEntry {
+		return logs.FilterMessage("Metric recorded").
+			FilterField(zap.String("metric_name", "auth_login_attempts_total")).
+			FilterField(zap.String("outcome", outcome)).All()
+	}
+}
+
+func TestAuthHandler_Login_Metrics(t *testing.T) {
+	loginMetrics := observeLoginMetrics(t)
+	handler := NewAuthHandler(NewJWTManager("test-secret-key", time.Hour), NewDemoCredentials())
+
+	assert.Equal(t, http.StatusUnauthorized, login(handler, `{"email":"admin@org1.com","password":"wrong","organization":"org-1"}`).Code)
+	failures := loginMetrics(LoginOutcomeFailure)
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "org-1", failures[0].ContextMap()["organization"])
+	}
+	assert.Empty(t, loginMetrics(LoginOutcomeSuccess))
+
+	assert.Equal(t, http.StatusOK, login(handler, `{"email":"admin@org2.com","password":"admin123","organization":"org-2"}`).Code)
+	successes := loginMetrics(LoginOutcomeSuccess)
+	if assert.Len(t, successes, 1) {
+		assert.Equal(t, "org-2", successes[0].ContextMap()["organization"])
+	}
+
+	assert.Equal(t, http.StatusBadRequest, login(handler, `{"email":"admin@org1.com"}`).Code)
+	badRequests := loginMetrics(LoginOutcomeBadRequest)
+	if assert.Len(t, badRequests, 1) {
+		assert.Equal(t, logger.AnonymousOrganization, badRequests[0].ContextMap()["organization"])
+	}
+}
+
+func TestAuthHandler_Login_MaxConcurrentLogins(t *testing.T) {
+	loginMetrics := observeLoginMetrics(t)
+	validator := &blockingValidator{started: make(chan struct{}, 1), release: make(chan struct{})}
+	handler := NewAuthHandler(NewJWTManager("test-secret-key", time.Hour), validator)
+	handler.SetMaxConcurrentLogins(1)
+
+	body := `{"email":"user@org1.com","password":"user123","organization":"org-1"}`
+	done := make(chan int)
+	go func() { done <- login(handler, body).Code }()
+	<-validator.started
+
+	rec := login(handler, body)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Len(t, loginMetrics(LoginOutcomeRateLimited), 1)
+
+	close(validator.release)
+	assert.Equal(t, http.StatusOK, <-done)
+
+	// The slot is free again once the first login finished
+	go func() { <-validator.started }()
+	assert.Equal(t, http.StatusOK, login(handler, body).Code)
+}
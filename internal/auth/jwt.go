@@ -19,6 +19,12 @@ import (
 	"github.com/ankittk/catalog-service/internal/logger"
 )
 
+// grpcHealthCheckMethod is always served without authentication so probes keep working
+const grpcHealthCheckMethod = "/grpc.health.v1.Health/Check"
+
+// DefaultClockSkew is how far the clock of a token's issuer may be ahead of or behind ours by default
+const DefaultClockSkew = 30 * time.Second
+
 // Error definitions
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
@@ -37,49 +43,119 @@ type Claims struct {
 type JWTManager struct {
 	secretKey     []byte
 	tokenDuration time.Duration
+	// roleDurations overrides tokenDuration for tokens issued to these roles
+	roleDurations map[string]time.Duration
+	// exemptPaths are HTTP paths served without a token, entries ending in "/" match every path below them
+	exemptPaths []string
+	// exemptMethods are full gRPC method names served without a token
+	exemptMethods map[string]bool
+	// clockSkew is the leeway applied to the exp, nbf and iat checks of incoming tokens
+	clockSkew time.Duration
 }
 
-// NewJWTManager creates a new JWT manager
+// NewJWTManager creates a new JWT manager exempting /health and the gRPC health check from authentication
 func NewJWTManager(secretKey string, tokenDuration time.Duration) *JWTManager {
 	return &JWTManager{
 		secretKey:     []byte(secretKey),
 		tokenDuration: tokenDuration,
+		exemptPaths:   []string{"/health"},
+		exemptMethods: map[string]bool{grpcHealthCheckMethod: true},
+		clockSkew:     DefaultClockSkew,
+	}
+}
+
+// AddExemptPaths serves the given HTTP paths without authentication, e.g. "/version" or "/docs/";
+// a path ending in "/" exempts every path below it. CORS preflight requests are always exempt.
+func (j *JWTManager) AddExemptPaths(paths ...string) {
+	j.exemptPaths = append(j.exemptPaths, paths...)
+}
+
+// AddExemptGRPCMethods serves the given gRPC methods without authentication, named like
+// "/grpc.health.v1.Health/Watch"
+func (j *JWTManager) AddExemptGRPCMethods(methods ...string) {
+	for _, method := range methods {
+		j.exemptMethods[method] = true
+	}
+}
+
+// isExemptPath reports whether path is served without authentication
+func (j *JWTManager) isExemptPath(path string) bool {
+	for _, exempt := range j.exemptPaths {
+		if path == exempt || (strings.HasSuffix(exempt, "/") && strings.HasPrefix(path, exempt)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRoleTokenDurations issues tokens for the given roles with their own duration, e.g. shorter-lived admin tokens.
+// Roles not in the map keep the default duration.
+func (j *JWTManager) SetRoleTokenDurations(durations map[string]time.Duration) {
+	j.roleDurations = make(map[string]time.Duration, len(durations))
+	for role, d := range durations {
+		j.roleDurations[role] = d
 	}
 }
 
+// SetClockSkew accepts tokens expired, not yet valid or issued in the future by up to d, for issuers whose
+// clock is slightly off; 0 checks the times exactly
+func (j *JWTManager) SetClockSkew(d time.Duration) {
+	j.clockSkew = d
+}
+
 // TokenDuration returns the token duration
 func (j *JWTManager) TokenDuration() time.Duration {
 	return j.tokenDuration
 }
 
+// TokenDurationFor returns the duration of tokens issued to role
+func (j *JWTManager) TokenDurationFor(role string) time.Duration {
+	if d, ok := j.roleDurations[role]; ok {
+		return d
+	}
+	return j.tokenDuration
+}
+
 // GenerateToken creates a new JWT token
 func (j *JWTManager) GenerateToken(userID, email, organization, role string) (string, error) {
+	token, _, err := j.GenerateTokenWithExpiry(userID, email, organization, role)
+	return token, err
+}
+
+// GenerateTokenWithExpiry creates a new JWT token lasting the role's token duration and returns when it expires
+func (j *JWTManager) GenerateTokenWithExpiry(userID, email, organization, role string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(j.TokenDurationFor(role))
 	claims := &Claims{
 		UserID:       userID,
 		Email:        email,
 		Organization: organization,
 		Role:         role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "catalog-service",
 			Subject:   userID,
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secretKey)
+	signed, err := token.SignedString(j.secretKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
 }
 
-// ValidateToken validates and parses a JWT token
+// ValidateToken validates and parses a JWT token, allowing the configured clock skew on its times
 func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return j.secretKey, nil
-	})
+	}, jwt.WithLeeway(j.clockSkew), jwt.WithIssuedAt())
 
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
@@ -93,6 +169,12 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// ClaimsFromContext returns the JWT claims stored in the context by the authentication middleware
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value("user").(*Claims)
+	return claims, ok
+}
+
 // GenerateSecretKey generates a random secret key
 func GenerateSecretKey(length int) (string, error) {
 	if length <= 0 {
@@ -122,8 +204,8 @@ func ExtractTokenFromHeader(authHeader string) (string, error) {
 // HTTPMiddleware creates JWT authentication middleware for HTTP
 func (j *JWTManager) HTTPMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip authentication for health check and OPTIONS requests
-		if r.URL.Path == "/health" || r.Method == "OPTIONS" {
+		// Skip authentication for exempt paths and CORS preflight requests
+		if j.isExemptPath(r.URL.Path) || r.Method == "OPTIONS" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -154,37 +236,66 @@ func (j *JWTManager) HTTPMiddleware(next http.Handler) http.Handler {
 // GRPCUnaryInterceptor creates JWT authentication interceptor for gRPC
 func (j *JWTManager) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Skip authentication for health check
-		if info.FullMethod == "/grpc.health.v1.Health/Check" {
-			return handler(ctx, req)
+		ctx, err := j.authenticateGRPC(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// Extract token from metadata
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+// GRPCStreamInterceptor creates JWT authentication interceptor for streaming gRPC methods
+func (j *JWTManager) GRPCStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := j.authenticateGRPC(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
 		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
-		}
+// authenticatedStream is a server stream whose context carries the caller's claims
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		tokenString, err := ExtractTokenFromHeader(authHeaders[0])
-		if err != nil {
-			logger.Get().Warnw("Invalid authorization header in gRPC", "error", err, "method", info.FullMethod)
-			return nil, status.Errorf(codes.Unauthenticated, "invalid authorization header: %v", err)
-		}
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
 
-		// Validate token
-		claims, err := j.ValidateToken(tokenString)
-		if err != nil {
-			logger.Get().Warnw("Invalid JWT token in gRPC", "error", err, "method", info.FullMethod)
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
-		}
+// authenticateGRPC validates the bearer token in the incoming metadata and returns ctx with the claims added
+func (j *JWTManager) authenticateGRPC(ctx context.Context, fullMethod string) (context.Context, error) {
+	// Skip authentication for exempt methods such as the health check
+	if j.exemptMethods[fullMethod] {
+		return ctx, nil
+	}
 
-		// Add claims to context
-		ctx = context.WithValue(ctx, "user", claims)
-		return handler(ctx, req)
+	// Extract token from metadata
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
 	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	tokenString, err := ExtractTokenFromHeader(authHeaders[0])
+	if err != nil {
+		logger.Get().Warnw("Invalid authorization header in gRPC", "error", err, "method", fullMethod)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization header: %v", err)
+	}
+
+	// Validate token
+	claims, err := j.ValidateToken(tokenString)
+	if err != nil {
+		logger.Get().Warnw("Invalid JWT token in gRPC", "error", err, "method", fullMethod)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	// Add claims to context
+	return context.WithValue(ctx, "user", claims), nil
 }
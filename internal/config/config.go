@@ -2,13 +2,60 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/idgen"
+	"github.com/ankittk/catalog-service/internal/logger"
 )
 
+const (
+	// minJWTSecretLength is the minimum JWT_SECRET_KEY length in bytes when auth is enabled
+	minJWTSecretLength = 32
+	// minJWTSecretDistinctBytes rejects low-entropy secrets such as a repeated character
+	minJWTSecretDistinctBytes = 8
+	// maxJWTClockSkew keeps JWT_CLOCK_SKEW small enough that expired tokens stop working promptly
+	maxJWTClockSkew = 5 * time.Minute
+
+	// defaultIDPattern accepts alphanumerics, hyphens and underscores
+	defaultIDPattern = `[A-Za-z0-9_-]+`
+	// defaultIDMaxLength is the default maximum ID length
+	defaultIDMaxLength = 50
+)
+
+// DefaultMaxMessageSize is the gRPC message size limit used when MAX_MESSAGE_SIZE is not set
+const DefaultMaxMessageSize = 4 << 20
+
+// DefaultGatewayDialTimeout is how long the gateway waits for each connection attempt to the gRPC server
+// when GATEWAY_DIAL_TIMEOUT is not set
+const DefaultGatewayDialTimeout = 5 * time.Second
+
+// Startup service URL check modes for URL_CHECK
+const (
+	// URLCheckOff skips the check, for offline and development environments
+	URLCheckOff = "off"
+	// URLCheckWarn logs each unreachable service URL and starts anyway
+	URLCheckWarn = "warn"
+	// URLCheckFail fails startup when any service URL is unreachable
+	URLCheckFail = "fail"
+)
+
+// DefaultURLCheckTimeout bounds the startup service URL check when URL_CHECK_TIMEOUT is not set
+const DefaultURLCheckTimeout = 10 * time.Second
+
+// DefaultFeatures are the feature flags enabled when FEATURES is not set
+var DefaultFeatures = []string{"service_history", "bulk_activate", "stream", "search", "export"}
+
 type Config struct {
 	// GRPCPort is the port on which the gRPC server listens
 	GRPCPort string
@@ -16,26 +63,280 @@ type Config struct {
 	// HTTPPort is the port on which the HTTP gateway listens
 	HTTPPort string
 
+	// BindAddress is the IP address both servers bind to (empty binds all interfaces)
+	BindAddress string
+
+	// EnableH2C lets the HTTP gateway also serve HTTP/2 cleartext (prior knowledge or h2c upgrade) alongside HTTP/1.1
+	EnableH2C bool
+
+	// EnableGRPCWeb lets the HTTP gateway also serve gRPC-Web requests from browsers
+	EnableGRPCWeb bool
+
 	// LogLevel for logging
 	LogLevel string
 
+	// LogFile writes logs to this file instead of stderr, rotating it at LogFileMaxSizeMB megabytes and keeping
+	// LogFileMaxBackups rotated files for up to LogFileMaxAgeDays days (0 keeps them all / forever)
+	LogFile           string
+	LogFileMaxSizeMB  int
+	LogFileMaxBackups int
+	LogFileMaxAgeDays int
+
 	// Environment for the application
 	Environment string
 
-	// LocalDataStorage is the path to the services data file
+	// Profile is the config file profile applied under the environment, PROFILE or else ENVIRONMENT
+	Profile string
+
+	// LocalDataStorage is the path to the services data file, or to a directory of *.yaml data files
 	LocalDataStorage string
 
 	// CORSOrigins is a comma-separated list of allowed CORS origins
 	CORSOrigins string
 
+	// CORSAllowCredentials lets browsers send cookies and credentials cross-origin (not allowed with "*" origins)
+	CORSAllowCredentials bool
+
+	// CORSMaxAge is how long browsers may cache preflight responses (0 omits the header)
+	CORSMaxAge time.Duration
+
 	// JWTSecretKey is the secret key for JWT token signing
 	JWTSecretKey string
 
+	// JWTSecretKeyFile is a file, such as a mounted secret, holding JWTSecretKey; it takes precedence over
+	// JWT_SECRET_KEY and is only read when auth is enabled
+	JWTSecretKeyFile string
+
+	// JWTSecretAutoGenerate generates a random JWTSecretKey when none is set, only allowed in development
+	JWTSecretAutoGenerate bool
+
+	// JWTSecretGenerated reports that JWTSecretKey was generated at startup, so tokens do not survive a restart
+	JWTSecretGenerated bool
+
 	// JWTTokenDuration is the duration for JWT tokens
 	JWTTokenDuration time.Duration
 
+	// JWTRoleTokenDurations overrides JWTTokenDuration for tokens issued to these roles
+	JWTRoleTokenDurations map[string]time.Duration
+
+	// JWTClockSkew is the leeway for the expiry, not-before and issued-at times of incoming tokens
+	JWTClockSkew time.Duration
+
+	// AuthExemptPaths are HTTP paths served without a token in addition to /health, entries ending in "/"
+	// exempt every path below them
+	AuthExemptPaths []string
+
+	// AuthExemptGRPCMethods are full gRPC method names served without a token in addition to the health check
+	AuthExemptGRPCMethods []string
+
 	// EnableAuth enables JWT authentication
 	EnableAuth bool
+
+	// AdminToken is the shared secret admin endpoints require in the X-Admin-Token header when auth is disabled;
+	// without it those endpoints only serve GET requests
+	AdminToken string
+
+	// AllowAnonymousWrites lets unauthenticated callers use the write RPCs when auth is disabled; it is rejected
+	// in production
+	AllowAnonymousWrites bool
+
+	// CacheControlList is the Cache-Control header of successful list responses (empty omits the header)
+	CacheControlList string
+
+	// CacheControlService is the Cache-Control header of successful single-service responses (empty omits the header)
+	CacheControlService string
+
+	// JSONEmitDefaults includes zero-valued fields in gateway JSON responses instead of omitting them
+	JSONEmitDefaults bool
+	// JSONUseProtoNames renders gateway JSON fields with their proto names ("total_count") instead of camelCase
+	JSONUseProtoNames bool
+	// JSONPretty indents every gateway JSON response; it is rejected in production to keep responses compact
+	JSONPretty bool
+	// JSONPrettyParam indents the responses of requests with ?pretty=true, by default everywhere but production
+	JSONPrettyParam bool
+
+	// RequestTimeout is the server-side deadline applied to requests that arrive without one (0 disables)
+	RequestTimeout time.Duration
+	// StreamTimeout ends streamed responses that have not completed in time, and StreamSendTimeout those whose
+	// client does not take a message in time, with DeadlineExceeded (0 disables either)
+	StreamTimeout     time.Duration
+	StreamSendTimeout time.Duration
+
+	// FieldLimits override or extend the default request field limits, keyed by "Method.field" (0 disables one)
+	FieldLimits map[string]int
+
+	// WarmupWindow is how long after startup or a data reload requests are labeled cold in metrics and logs
+	// (0 labels every request warm)
+	WarmupWindow time.Duration
+
+	// MaxConcurrentRequests caps in-flight gRPC requests, extra requests fail with ResourceExhausted (0 disables)
+	MaxConcurrentRequests int
+
+	// RetryDelay is how long clients are told to wait before retrying a request rejected over the concurrency
+	// limit or while the catalog is loading, sent as google.rpc.RetryInfo and the HTTP Retry-After header
+	RetryDelay time.Duration
+
+	// MaxConcurrentStreams caps concurrent streams per HTTP/2 client connection (0 keeps the gRPC default)
+	MaxConcurrentStreams int
+
+	// MaxConcurrentLogins caps logins checked at once on /auth/login, extra logins fail with 429 (0 disables)
+	MaxConcurrentLogins int
+
+	// MaxMessageSize caps the size in bytes of gRPC messages received and sent (0 keeps the gRPC default of 4MB)
+	MaxMessageSize int64
+
+	// ShutdownDrainDelay is how long readiness reports not-ready before the servers stop on shutdown
+	ShutdownDrainDelay time.Duration
+
+	// GatewayDialTimeout bounds each attempt of the HTTP gateway to connect to the gRPC server
+	// (0 uses DefaultGatewayDialTimeout)
+	GatewayDialTimeout time.Duration
+	// GatewayDialRetries is how many more times the gateway tries to connect, with exponential backoff,
+	// before startup fails
+	GatewayDialRetries int
+	// GatewayKeepalive is how often the gateway pings the gRPC server on an active connection (0 disables)
+	GatewayKeepalive time.Duration
+	// GatewayIdleTimeout is how long the gateway connection may sit unused before it is closed
+	// (0 keeps the gRPC default of 30 minutes)
+	GatewayIdleTimeout time.Duration
+
+	// SearchMinLength is the minimum search_query length accepted by ListServices (0 disables)
+	SearchMinLength int
+
+	// MaxListResults caps the services in one ListServices response whatever its page_size (0 disables)
+	MaxListResults int
+
+	// SearchWildcard enables trailing-wildcard prefix search, e.g. "pay*"
+	SearchWildcard bool
+
+	// SearchFields is the default set of fields search terms match: "name", "name,description" or "name,description,version"
+	SearchFields string
+
+	// SearchMatch is the default way search terms combine: "all" or "any"
+	SearchMatch string
+
+	// StrictSort rejects unrecognized sort_by/sort_order values instead of falling back to defaults
+	StrictSort bool
+
+	// DefaultSortBy and DefaultSortOrder sort listings whose request leaves sort_by or sort_order empty
+	DefaultSortBy    string
+	DefaultSortOrder string
+
+	// ServiceIDPattern and ServiceIDMaxLength define the accepted service ID format
+	ServiceIDPattern   *regexp.Regexp
+	ServiceIDMaxLength int
+
+	// OrganizationIDPattern and OrganizationIDMaxLength define the accepted organization ID format
+	OrganizationIDPattern   *regexp.Regexp
+	OrganizationIDMaxLength int
+
+	// QuietLogMethods are gRPC methods or HTTP paths whose successful requests are only logged at debug level
+	QuietLogMethods []string
+
+	// LogPayloads logs the request and response messages of every unary RPC at debug level, with
+	// RedactLogFields masked; it is rejected in production
+	LogPayloads bool
+
+	// RedactLogFields are log field names, e.g. "email" or "search_query", whose values are logged as a hash
+	RedactLogFields []string
+
+	// MetricsOrganizations are the organizations labeled in request metrics, others count as "other";
+	// empty labels the first logger.MaxOrganizationLabels organizations seen
+	MetricsOrganizations []string
+
+	// DefaultLocale is the locale used when the caller's Accept-Language names no supported locale
+	DefaultLocale string
+
+	// SupportedLocales are the locales a request may select through Accept-Language
+	SupportedLocales []string
+
+	// StrictYAML rejects unknown fields in the data file instead of silently ignoring them
+	StrictYAML bool
+
+	// AllowEmptyCatalog starts with an empty catalog, logging a warning, when the data file does not exist
+	AllowEmptyCatalog bool
+
+	// AuditLogSize is how many catalog changes are kept for ListAuditEvents (0 disables the audit log)
+	AuditLogSize int
+
+	// MaxSnapshots caps the ListServices snapshots kept at once, the least recently used is evicted to make room
+	// (0 disables the cap)
+	MaxSnapshots int
+
+	// IdempotencyTTL is how long a CreateServices response is replayed to retries with the same idempotency_key
+	// (0 ignores idempotency keys)
+	IdempotencyTTL time.Duration
+
+	// IdempotencyMaxKeys caps the idempotency keys kept at once, the oldest is evicted to make room
+	// (0 disables the cap)
+	IdempotencyMaxKeys int
+
+	// IDGenerator generates IDs for added services and versions without one: "ulid" or "uuid"
+	IDGenerator string
+
+	// RequireHTTPSURLs rejects services whose url is set but is not an absolute https URL, at load and when added
+	RequireHTTPSURLs bool
+	// RequireKnownOrganizations rejects services whose organization_id is missing from the data file's
+	// organizations list, at load and when added; without a list every organization is accepted
+	RequireKnownOrganizations bool
+
+	// FutureTimestamps is how data file timestamps later than now plus TimestampSkew are handled: "ignore", "warn" or "reject"
+	FutureTimestamps string
+
+	// TimestampSkew tolerates clock drift before a timestamp counts as being in the future
+	TimestampSkew time.Duration
+
+	// URLCheck sends a HEAD request to every service URL at startup: "off", "warn" or "fail"
+	URLCheck string
+
+	// URLCheckTimeout bounds the whole startup URL check, URLs not checked in time count as unreachable
+	// (0 uses DefaultURLCheckTimeout)
+	URLCheckTimeout time.Duration
+
+	// URLCheckConcurrency caps the URLs checked at once (values below 1 check one at a time)
+	URLCheckConcurrency int
+
+	// NameNormalization is how whitespace in service names and descriptions is normalized on load and when
+	// services are added: "trim", "collapse" (also collapses runs of whitespace inside names) or "none"
+	NameNormalization string
+
+	// MaxServices caps the number of services held in memory, larger data files fail to load (0 means unlimited)
+	MaxServices int
+
+	// ShardCount splits service IDs across shards by consistent hashing (1 serves every service)
+	ShardCount int
+	// ShardIndex is the shard served by this instance, from 0 to ShardCount-1
+	ShardIndex int
+
+	// Features are the enabled feature flags; experimental RPCs behind other flags return Unimplemented
+	Features []string
+
+	// DefaultOrganization scopes ListServices of anonymous callers, i.e. with auth disabled, to one organization
+	// unless the request filters by organization itself; empty lists every organization
+	DefaultOrganization string
+
+	// AnonymousOrganizations are the only organizations whose services requests without JWT claims can read,
+	// others are left out of listings and reported as not found; empty allows every organization
+	AnonymousOrganizations []string
+
+	// CrossOrgAccess is how requests for a service of another organization are answered: "hide" (NotFound)
+	// or "deny" (PermissionDenied)
+	CrossOrgAccess string
+
+	// ReadOnly rejects mutating RPCs at startup; it can be flipped at runtime via the admin endpoint
+	ReadOnly bool
+
+	// WebhookURLs receive a signed POST for every catalog change, empty disables webhooks
+	WebhookURLs []string
+	// WebhookSecret keys the HMAC-SHA256 signature of webhook bodies, required with WebhookURLs
+	WebhookSecret string
+	// WebhookMaxRetries and WebhookRetryBackoff control redelivery of failed webhooks, the backoff doubling per retry
+	WebhookMaxRetries   int
+	WebhookRetryBackoff time.Duration
+	// WebhookTimeout bounds each delivery attempt
+	WebhookTimeout time.Duration
+	// WebhookQueueSize is how many events may wait for delivery before further ones are dropped
+	WebhookQueueSize int
 }
 
 // Load reads environment variables and returns the Config
@@ -45,24 +346,237 @@ func Load() (*Config, error) {
 		fmt.Printf("Note: .env file not found, using system environment variables: %v\n", err)
 	}
 
-	cfg := &Config{
-		GRPCPort:         getEnv("GRPC_PORT", "9000"),
-		HTTPPort:         getEnv("HTTP_PORT", "8000"),
-		LogLevel:         getEnv("LOG_LEVEL", "info"),
-		Environment:      getEnv("ENVIRONMENT", "development"),
-		LocalDataStorage: getEnv("LOCAL_DATA_STORAGE", "data/services.yaml"),
-		CORSOrigins:      getEnv("CORS_ORIGINS", "*"),
-		JWTSecretKey:     getEnv("JWT_SECRET_KEY", ""),
-		EnableAuth:       getEnvBool("ENABLE_AUTH", false),
-	}
-
-	// Parse JWT token duration
-	tokenDurationStr := getEnv("JWT_TOKEN_DURATION", "24h")
-	tokenDuration, err := time.ParseDuration(tokenDurationStr)
+	// Apply the selected config file profile underneath the environment
+	profile, err := loadProfile()
 	if err != nil {
-		return nil, fmt.Errorf("invalid JWT_TOKEN_DURATION: %w", err)
+		return nil, err
+	}
+
+	cfg := &Config{
+		Profile:                   profile,
+		GRPCPort:                  getEnv("GRPC_PORT", "9000"),
+		HTTPPort:                  getEnv("HTTP_PORT", "8000"),
+		BindAddress:               getEnv("BIND_ADDRESS", ""),
+		EnableH2C:                 getEnvBool("ENABLE_H2C", false),
+		EnableGRPCWeb:             getEnvBool("ENABLE_GRPC_WEB", false),
+		LogLevel:                  getEnv("LOG_LEVEL", "info"),
+		LogFile:                   getEnv("LOG_FILE", ""),
+		Environment:               getEnv("ENVIRONMENT", "development"),
+		LocalDataStorage:          getEnv("LOCAL_DATA_STORAGE", "data/services.yaml"),
+		CORSOrigins:               getEnv("CORS_ORIGINS", "*"),
+		CORSAllowCredentials:      getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+		CacheControlList:          getEnv("CACHE_CONTROL_LIST", "max-age=30"),
+		CacheControlService:       getEnv("CACHE_CONTROL_SERVICE", "max-age=300"),
+		JSONEmitDefaults:          getEnvBool("JSON_EMIT_DEFAULTS", false),
+		JSONUseProtoNames:         getEnvBool("JSON_USE_PROTO_NAMES", false),
+		JSONPretty:                getEnvBool("JSON_PRETTY", false),
+		LogPayloads:               getEnvBool("LOG_PAYLOADS", false),
+		JWTSecretKey:              getEnv("JWT_SECRET_KEY", ""),
+		JWTSecretKeyFile:          getEnv("JWT_SECRET_KEY_FILE", ""),
+		JWTSecretAutoGenerate:     getEnvBool("JWT_SECRET_AUTO_GENERATE", false),
+		EnableAuth:                getEnvBool("ENABLE_AUTH", false),
+		AdminToken:                getEnv("ADMIN_TOKEN", ""),
+		AllowAnonymousWrites:      getEnvBool("ALLOW_ANONYMOUS_WRITES", false),
+		SearchWildcard:            getEnvBool("SEARCH_WILDCARD", false),
+		SearchFields:              getEnv("SEARCH_FIELDS", "name,description"),
+		SearchMatch:               getEnv("SEARCH_MATCH", "all"),
+		IDGenerator:               getEnv("ID_GENERATOR", idgen.KindULID),
+		StrictSort:                getEnvBool("STRICT_SORT", false),
+		DefaultSortBy:             getEnv("DEFAULT_SORT_BY", "name"),
+		DefaultSortOrder:          getEnv("DEFAULT_SORT_ORDER", "asc"),
+		StrictYAML:                getEnvBool("STRICT_YAML", false),
+		AllowEmptyCatalog:         getEnvBool("ALLOW_EMPTY_CATALOG", false),
+		RequireHTTPSURLs:          getEnvBool("REQUIRE_HTTPS_URLS", false),
+		RequireKnownOrganizations: getEnvBool("REQUIRE_KNOWN_ORGANIZATIONS", false),
+		FutureTimestamps:          getEnv("FUTURE_TIMESTAMPS", "warn"),
+		URLCheck:                  getEnv("URL_CHECK", URLCheckOff),
+		NameNormalization:         getEnv("NAME_NORMALIZATION", "trim"),
+		DefaultOrganization:       getEnv("DEFAULT_ORGANIZATION", ""),
+		AnonymousOrganizations:    getEnvList("ANONYMOUS_ORGANIZATIONS", nil),
+		CrossOrgAccess:            getEnv("CROSS_ORG_ACCESS", "hide"),
+		ReadOnly:                  getEnvBool("READ_ONLY", false),
+		WebhookURLs:               getEnvList("WEBHOOK_URLS", nil),
+		AuthExemptPaths:           getEnvList("AUTH_EXEMPT_PATHS", nil),
+		AuthExemptGRPCMethods:     getEnvList("AUTH_EXEMPT_GRPC_METHODS", nil),
+		WebhookSecret:             getEnv("WEBHOOK_SECRET", ""),
+	}
+
+	// Parse durations, which also accept days and weeks (e.g. "7d")
+	if cfg.JWTTokenDuration, err = getEnvDuration("JWT_TOKEN_DURATION", 24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.JWTRoleTokenDurations, err = getEnvDurationMap("JWT_ROLE_TOKEN_DURATIONS"); err != nil {
+		return nil, err
+	}
+	if cfg.JWTClockSkew, err = getEnvDuration("JWT_CLOCK_SKEW", auth.DefaultClockSkew); err != nil {
+		return nil, err
+	}
+	if cfg.CORSMaxAge, err = getEnvDuration("CORS_MAX_AGE", 24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.RequestTimeout, err = getEnvDuration("REQUEST_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.StreamTimeout, err = getEnvDuration("STREAM_TIMEOUT", 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.StreamSendTimeout, err = getEnvDuration("STREAM_SEND_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.FieldLimits, err = getEnvIntMap("FIELD_LIMITS"); err != nil {
+		return nil, err
+	}
+	if cfg.WarmupWindow, err = getEnvDuration("WARMUP_WINDOW", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.ShutdownDrainDelay, err = getEnvDuration("SHUTDOWN_DRAIN_DELAY", 0); err != nil {
+		return nil, err
+	}
+	if cfg.GatewayDialTimeout, err = getEnvDuration("GATEWAY_DIAL_TIMEOUT", DefaultGatewayDialTimeout); err != nil {
+		return nil, err
+	}
+	if cfg.GatewayDialRetries, err = getEnvInt("GATEWAY_DIAL_RETRIES", 3); err != nil {
+		return nil, err
+	}
+	if cfg.GatewayKeepalive, err = getEnvDuration("GATEWAY_KEEPALIVE", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.GatewayIdleTimeout, err = getEnvDuration("GATEWAY_IDLE_TIMEOUT", 0); err != nil {
+		return nil, err
+	}
+	if cfg.TimestampSkew, err = getEnvDuration("TIMESTAMP_SKEW", 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.URLCheckTimeout, err = getEnvDuration("URL_CHECK_TIMEOUT", DefaultURLCheckTimeout); err != nil {
+		return nil, err
+	}
+	if cfg.URLCheckConcurrency, err = getEnvInt("URL_CHECK_CONCURRENCY", 8); err != nil {
+		return nil, err
+	}
+	if cfg.RetryDelay, err = getEnvDuration("RETRY_DELAY", time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.IdempotencyTTL, err = getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookRetryBackoff, err = getEnvDuration("WEBHOOK_RETRY_BACKOFF", time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookTimeout, err = getEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Parse message size limits, which accept units (e.g. "4MB")
+	if cfg.MaxMessageSize, err = getEnvSize("MAX_MESSAGE_SIZE", DefaultMaxMessageSize); err != nil {
+		return nil, err
+	}
+
+	// Parse methods excluded from request logging, an empty value logs every request
+	cfg.QuietLogMethods = getEnvList("QUIET_LOG_METHODS", logger.DefaultQuietMethods)
+
+	// Parse log fields masked before logging, e.g. to keep PII out of logs
+	cfg.RedactLogFields = getEnvList("REDACT_LOG_FIELDS", nil)
+
+	// Parse organizations labeled in request metrics
+	cfg.MetricsOrganizations = getEnvList("METRICS_ORGANIZATIONS", nil)
+
+	// Parse locales, the default is always supported
+	cfg.DefaultLocale = getEnv("DEFAULT_LOCALE", "en")
+	cfg.SupportedLocales = getEnvList("SUPPORTED_LOCALES", []string{cfg.DefaultLocale})
+
+	// Parse enabled feature flags, an empty value turns every experimental RPC off
+	cfg.Features = getEnvList("FEATURES", DefaultFeatures)
+
+	// Parse log file rotation
+	if cfg.LogFileMaxSizeMB, err = getEnvInt("LOG_FILE_MAX_SIZE_MB", 100); err != nil {
+		return nil, err
+	}
+	if cfg.LogFileMaxBackups, err = getEnvInt("LOG_FILE_MAX_BACKUPS", 5); err != nil {
+		return nil, err
+	}
+	if cfg.LogFileMaxAgeDays, err = getEnvInt("LOG_FILE_MAX_AGE_DAYS", 30); err != nil {
+		return nil, err
+	}
+
+	// Parse concurrency limits
+	if cfg.MaxConcurrentRequests, err = getEnvInt("MAX_CONCURRENT_REQUESTS", 1000); err != nil {
+		return nil, err
+	}
+	if cfg.MaxConcurrentStreams, err = getEnvInt("MAX_CONCURRENT_STREAMS", 0); err != nil {
+		return nil, err
+	}
+	if cfg.MaxConcurrentLogins, err = getEnvInt("MAX_CONCURRENT_LOGINS", 10); err != nil {
+		return nil, err
+	}
+
+	// Parse store size limit
+	if cfg.MaxServices, err = getEnvInt("MAX_SERVICES", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.AuditLogSize, err = getEnvInt("AUDIT_LOG_SIZE", 1000); err != nil {
+		return nil, err
+	}
+	if cfg.MaxSnapshots, err = getEnvInt("MAX_SNAPSHOTS", 1000); err != nil {
+		return nil, err
+	}
+	if cfg.IdempotencyMaxKeys, err = getEnvInt("IDEMPOTENCY_MAX_KEYS", 10000); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookMaxRetries, err = getEnvInt("WEBHOOK_MAX_RETRIES", 3); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookQueueSize, err = getEnvInt("WEBHOOK_QUEUE_SIZE", 1000); err != nil {
+		return nil, err
+	}
+
+	// Parse sharding, a single shard serves the whole catalog
+	if cfg.ShardCount, err = getEnvInt("SHARD_COUNT", 1); err != nil {
+		return nil, err
+	}
+	if cfg.ShardIndex, err = getEnvInt("SHARD_INDEX", 0); err != nil {
+		return nil, err
+	}
+
+	// Parse minimum search query length
+	if cfg.SearchMinLength, err = getEnvInt("SEARCH_MIN_LENGTH", 1); err != nil {
+		return nil, err
+	}
+	if cfg.MaxListResults, err = getEnvInt("MAX_LIST_RESULTS", 0); err != nil {
+		return nil, err
+	}
+
+	// Compile ID formats once at startup so a bad pattern fails fast
+	if cfg.ServiceIDPattern, err = getEnvAnchoredRegexp("SERVICE_ID_PATTERN", defaultIDPattern); err != nil {
+		return nil, err
+	}
+	if cfg.ServiceIDMaxLength, err = getEnvInt("SERVICE_ID_MAX_LENGTH", defaultIDMaxLength); err != nil {
+		return nil, err
+	}
+	if cfg.OrganizationIDPattern, err = getEnvAnchoredRegexp("ORGANIZATION_ID_PATTERN", defaultIDPattern); err != nil {
+		return nil, err
+	}
+	if cfg.OrganizationIDMaxLength, err = getEnvInt("ORGANIZATION_ID_MAX_LENGTH", defaultIDMaxLength); err != nil {
+		return nil, err
+	}
+
+	// Indented responses are a debugging aid, so production ignores ?pretty=true unless explicitly allowed
+	cfg.JSONPrettyParam = getEnvBool("JSON_PRETTY_PARAM", cfg.Environment != "production")
+
+	// A secret mounted as a file wins over the inline variable
+	if cfg.EnableAuth && cfg.JWTSecretKeyFile != "" {
+		if cfg.JWTSecretKey, err = readSecretFile(cfg.JWTSecretKeyFile); err != nil {
+			return nil, fmt.Errorf("invalid config: JWT_SECRET_KEY_FILE %w", err)
+		}
+	}
+
+	// Generate a throwaway JWT secret for local development when asked to
+	if cfg.EnableAuth && cfg.JWTSecretKey == "" && cfg.JWTSecretAutoGenerate && cfg.Environment == "development" {
+		if cfg.JWTSecretKey, err = auth.GenerateSecretKey(minJWTSecretLength); err != nil {
+			return nil, err
+		}
+		cfg.JWTSecretGenerated = true
 	}
-	cfg.JWTTokenDuration = tokenDuration
 
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
@@ -82,28 +596,264 @@ func (c *Config) Validate() error {
 	if c.LocalDataStorage == "" {
 		return fmt.Errorf("LOCAL_DATA_STORAGE cannot be empty")
 	}
+	if c.BindAddress != "" && net.ParseIP(c.BindAddress) == nil {
+		return fmt.Errorf("BIND_ADDRESS must be an IP address, got %q", c.BindAddress)
+	}
+	if c.LogFile != "" && c.LogFileMaxSizeMB <= 0 {
+		return fmt.Errorf("LOG_FILE_MAX_SIZE_MB must be positive")
+	}
+	if c.LogFileMaxBackups < 0 {
+		return fmt.Errorf("LOG_FILE_MAX_BACKUPS cannot be negative")
+	}
+	if c.LogFileMaxAgeDays < 0 {
+		return fmt.Errorf("LOG_FILE_MAX_AGE_DAYS cannot be negative")
+	}
+
+	if c.CORSMaxAge < 0 {
+		return fmt.Errorf("CORS_MAX_AGE cannot be negative")
+	}
+	if c.CORSAllowCredentials {
+		for _, origin := range strings.Split(c.CORSOrigins, ",") {
+			if strings.TrimSpace(origin) == "*" {
+				return fmt.Errorf("CORS_ALLOW_CREDENTIALS requires explicit CORS_ORIGINS, \"*\" is not allowed")
+			}
+		}
+	}
+
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("REQUEST_TIMEOUT cannot be negative")
+	}
+	if c.StreamTimeout < 0 {
+		return fmt.Errorf("STREAM_TIMEOUT cannot be negative")
+	}
+	if c.StreamSendTimeout < 0 {
+		return fmt.Errorf("STREAM_SEND_TIMEOUT cannot be negative")
+	}
+	for key, limit := range c.FieldLimits {
+		if limit < 0 {
+			return fmt.Errorf("FIELD_LIMITS cannot be negative, got %d for %q", limit, key)
+		}
+	}
+	if c.WarmupWindow < 0 {
+		return fmt.Errorf("WARMUP_WINDOW cannot be negative")
+	}
+	if c.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("MAX_CONCURRENT_REQUESTS cannot be negative")
+	}
+	if c.RetryDelay < 0 {
+		return fmt.Errorf("RETRY_DELAY cannot be negative")
+	}
+	for _, raw := range c.WebhookURLs {
+		if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("WEBHOOK_URLS must contain absolute http(s) URLs, got %q", raw)
+		}
+	}
+	if len(c.WebhookURLs) > 0 && c.WebhookSecret == "" {
+		return fmt.Errorf("WEBHOOK_SECRET is required when WEBHOOK_URLS is set")
+	}
+	if c.WebhookMaxRetries < 0 || c.WebhookRetryBackoff < 0 || c.WebhookTimeout < 0 || c.WebhookQueueSize < 0 {
+		return fmt.Errorf("WEBHOOK_MAX_RETRIES, WEBHOOK_RETRY_BACKOFF, WEBHOOK_TIMEOUT and WEBHOOK_QUEUE_SIZE cannot be negative")
+	}
+	if c.MaxConcurrentStreams < 0 {
+		return fmt.Errorf("MAX_CONCURRENT_STREAMS cannot be negative")
+	}
+	if c.MaxConcurrentLogins < 0 {
+		return fmt.Errorf("MAX_CONCURRENT_LOGINS cannot be negative")
+	}
+	if c.ShutdownDrainDelay < 0 {
+		return fmt.Errorf("SHUTDOWN_DRAIN_DELAY cannot be negative")
+	}
+	if c.GatewayDialTimeout < 0 || c.GatewayDialRetries < 0 || c.GatewayKeepalive < 0 || c.GatewayIdleTimeout < 0 {
+		return fmt.Errorf("GATEWAY_DIAL_TIMEOUT, GATEWAY_DIAL_RETRIES, GATEWAY_KEEPALIVE and GATEWAY_IDLE_TIMEOUT cannot be negative")
+	}
+	if c.GatewayKeepalive > 0 && c.GatewayKeepalive < 10*time.Second {
+		return fmt.Errorf("GATEWAY_KEEPALIVE must be at least 10s, gRPC does not ping more often")
+	}
+	if c.SearchMinLength < 0 || c.SearchMinLength > 100 {
+		return fmt.Errorf("SEARCH_MIN_LENGTH must be between 0 and 100")
+	}
+	if c.MaxListResults < 0 {
+		return fmt.Errorf("MAX_LIST_RESULTS cannot be negative")
+	}
+	switch c.SearchFields {
+	case "", "name", "name,description", "name,description,version":
+	default:
+		return fmt.Errorf("SEARCH_FIELDS must be one of \"name\", \"name,description\" or \"name,description,version\", got %q", c.SearchFields)
+	}
+	if c.SearchMatch != "" && c.SearchMatch != "all" && c.SearchMatch != "any" {
+		return fmt.Errorf("SEARCH_MATCH must be \"all\" or \"any\", got %q", c.SearchMatch)
+	}
+	switch c.DefaultSortBy {
+	case "", "name", "created_at", "updated_at", "version_count":
+	default:
+		return fmt.Errorf("DEFAULT_SORT_BY must be one of \"name\", \"created_at\", \"updated_at\" or \"version_count\", got %q", c.DefaultSortBy)
+	}
+	if c.DefaultSortOrder != "" && c.DefaultSortOrder != "asc" && c.DefaultSortOrder != "desc" {
+		return fmt.Errorf("DEFAULT_SORT_ORDER must be \"asc\" or \"desc\", got %q", c.DefaultSortOrder)
+	}
+	if c.CrossOrgAccess != "" && c.CrossOrgAccess != "hide" && c.CrossOrgAccess != "deny" {
+		return fmt.Errorf("CROSS_ORG_ACCESS must be \"hide\" or \"deny\", got %q", c.CrossOrgAccess)
+	}
+	switch c.NameNormalization {
+	case "", "trim", "collapse", "none":
+	default:
+		return fmt.Errorf("NAME_NORMALIZATION must be \"trim\", \"collapse\" or \"none\", got %q", c.NameNormalization)
+	}
+	switch c.FutureTimestamps {
+	case "", "ignore", "warn", "reject":
+	default:
+		return fmt.Errorf("FUTURE_TIMESTAMPS must be \"ignore\", \"warn\" or \"reject\", got %q", c.FutureTimestamps)
+	}
+	if c.MaxMessageSize < 0 {
+		return fmt.Errorf("MAX_MESSAGE_SIZE cannot be negative")
+	}
+	if c.MaxServices < 0 {
+		return fmt.Errorf("MAX_SERVICES cannot be negative")
+	}
+	if c.MaxSnapshots < 0 {
+		return fmt.Errorf("MAX_SNAPSHOTS cannot be negative")
+	}
+	if c.IdempotencyTTL < 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL cannot be negative")
+	}
+	if c.IdempotencyMaxKeys < 0 {
+		return fmt.Errorf("IDEMPOTENCY_MAX_KEYS cannot be negative")
+	}
+	if c.AuditLogSize < 0 {
+		return fmt.Errorf("AUDIT_LOG_SIZE cannot be negative")
+	}
+	if _, err := idgen.New(c.IDGenerator); err != nil {
+		return fmt.Errorf("invalid ID_GENERATOR: %w", err)
+	}
+	if c.ShardCount < 0 {
+		return fmt.Errorf("SHARD_COUNT cannot be negative")
+	}
+	if c.ShardIndex < 0 || (c.ShardCount > 0 && c.ShardIndex >= c.ShardCount) {
+		return fmt.Errorf("SHARD_INDEX must be between 0 and SHARD_COUNT-1 (%d), got %d", max(c.ShardCount, 1)-1, c.ShardIndex)
+	}
+	if c.TimestampSkew < 0 {
+		return fmt.Errorf("TIMESTAMP_SKEW cannot be negative")
+	}
+	switch c.URLCheck {
+	case "", URLCheckOff, URLCheckWarn, URLCheckFail:
+	default:
+		return fmt.Errorf("URL_CHECK must be %q, %q or %q, got %q", URLCheckOff, URLCheckWarn, URLCheckFail, c.URLCheck)
+	}
+	if c.URLCheckTimeout < 0 || c.URLCheckConcurrency < 0 {
+		return fmt.Errorf("URL_CHECK_TIMEOUT and URL_CHECK_CONCURRENCY cannot be negative")
+	}
+	if c.DefaultLocale != "" && !containsFold(c.SupportedLocales, c.DefaultLocale) {
+		return fmt.Errorf("DEFAULT_LOCALE %q must be one of SUPPORTED_LOCALES %v", c.DefaultLocale, c.SupportedLocales)
+	}
+	if c.ServiceIDPattern != nil && c.ServiceIDMaxLength <= 0 {
+		return fmt.Errorf("SERVICE_ID_MAX_LENGTH must be positive")
+	}
+	if c.OrganizationIDPattern != nil && c.OrganizationIDMaxLength <= 0 {
+		return fmt.Errorf("ORGANIZATION_ID_MAX_LENGTH must be positive")
+	}
+	if c.DefaultOrganization != "" && c.OrganizationIDPattern != nil &&
+		(!c.OrganizationIDPattern.MatchString(c.DefaultOrganization) || len(c.DefaultOrganization) > c.OrganizationIDMaxLength) {
+		return fmt.Errorf("DEFAULT_ORGANIZATION %q does not match ORGANIZATION_ID_PATTERN", c.DefaultOrganization)
+	}
+	for _, orgID := range c.AnonymousOrganizations {
+		if c.OrganizationIDPattern != nil && (!c.OrganizationIDPattern.MatchString(orgID) || len(orgID) > c.OrganizationIDMaxLength) {
+			return fmt.Errorf("ANONYMOUS_ORGANIZATIONS entry %q does not match ORGANIZATION_ID_PATTERN", orgID)
+		}
+	}
+	if c.DefaultOrganization != "" && len(c.AnonymousOrganizations) > 0 && !slices.Contains(c.AnonymousOrganizations, c.DefaultOrganization) {
+		return fmt.Errorf("DEFAULT_ORGANIZATION %q must be one of ANONYMOUS_ORGANIZATIONS %v", c.DefaultOrganization, c.AnonymousOrganizations)
+	}
 
-	// Validate data file exists
-	if _, err := os.Stat(c.LocalDataStorage); os.IsNotExist(err) {
+	// Validate data file exists, unless a fresh deployment may start without one
+	if _, err := os.Stat(c.LocalDataStorage); os.IsNotExist(err) && !c.AllowEmptyCatalog {
 		return fmt.Errorf("data file does not exist: %s", c.LocalDataStorage)
 	}
 
+	if c.JSONPretty && c.Environment == "production" {
+		return fmt.Errorf("JSON_PRETTY is not allowed when ENVIRONMENT is production")
+	}
+	if c.AllowAnonymousWrites && c.Environment == "production" {
+		return fmt.Errorf("ALLOW_ANONYMOUS_WRITES is not allowed when ENVIRONMENT is production")
+	}
+	if c.LogPayloads && c.Environment == "production" {
+		return fmt.Errorf("LOG_PAYLOADS is not allowed when ENVIRONMENT is production")
+	}
+	if c.JWTSecretAutoGenerate && c.Environment != "development" {
+		return fmt.Errorf("JWT_SECRET_AUTO_GENERATE is only allowed when ENVIRONMENT is development, got %q", c.Environment)
+	}
+
+	if c.AdminToken != "" && len(c.AdminToken) < minJWTSecretLength {
+		return fmt.Errorf("ADMIN_TOKEN must be at least %d bytes long for security, got %d", minJWTSecretLength, len(c.AdminToken))
+	}
+
 	// Validate JWT configuration if auth is enabled
 	if c.EnableAuth {
 		if c.JWTSecretKey == "" {
 			return fmt.Errorf("JWT_SECRET_KEY is required when ENABLE_AUTH is true")
 		}
-		if len(c.JWTSecretKey) < 32 {
-			return fmt.Errorf("JWT_SECRET_KEY must be at least 32 characters long for security")
+		if len(c.JWTSecretKey) < minJWTSecretLength {
+			return fmt.Errorf("JWT_SECRET_KEY must be at least %d bytes long for security, got %d (generate one with `make generate-jwt-secret`)",
+				minJWTSecretLength, len(c.JWTSecretKey))
+		}
+		if distinctBytes(c.JWTSecretKey) < minJWTSecretDistinctBytes {
+			return fmt.Errorf("JWT_SECRET_KEY is too predictable, it must contain at least %d distinct characters (generate one with `make generate-jwt-secret`)",
+				minJWTSecretDistinctBytes)
 		}
 		if c.JWTTokenDuration <= 0 {
 			return fmt.Errorf("JWT_TOKEN_DURATION must be positive")
 		}
+		if c.JWTClockSkew < 0 || c.JWTClockSkew > maxJWTClockSkew {
+			return fmt.Errorf("JWT_CLOCK_SKEW must be between 0 and %s, got %s", maxJWTClockSkew, c.JWTClockSkew)
+		}
+		for _, path := range c.AuthExemptPaths {
+			if !strings.HasPrefix(path, "/") {
+				return fmt.Errorf("AUTH_EXEMPT_PATHS must contain absolute paths, got %q", path)
+			}
+		}
+		for _, method := range c.AuthExemptGRPCMethods {
+			if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+				return fmt.Errorf("AUTH_EXEMPT_GRPC_METHODS must contain full method names like /package.Service/Method, got %q", method)
+			}
+		}
+		for role, d := range c.JWTRoleTokenDurations {
+			if d <= 0 {
+				return fmt.Errorf("JWT_ROLE_TOKEN_DURATIONS must be positive, got %s for role %q", d, role)
+			}
+		}
 	}
 
 	return nil
 }
 
+// distinctBytes counts the distinct bytes in s
+func distinctBytes(s string) int {
+	seen := make(map[byte]bool)
+	for i := 0; i < len(s); i++ {
+		seen[s[i]] = true
+	}
+	return len(seen)
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// readSecretFile returns the contents of a secret file without the trailing newline editors and
+// secret managers usually add
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not be read: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
 // getEnv returns the value of the environment variable or fallback if not set
 func getEnv(key, fallback string) string {
 	if val, exists := os.LookupEnv(key); exists {
@@ -112,6 +862,119 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvList returns the comma-separated values of the environment variable or fallback if not set
+func getEnvList(key string, fallback []string) []string {
+	val, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	var values []string
+	for _, v := range strings.Split(val, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getEnvInt returns the integer value of the environment variable or fallback if not set
+func getEnvInt(key string, fallback int) (int, error) {
+	val, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return n, nil
+}
+
+// getEnvDuration returns the duration in the environment variable, parsed with ParseDuration, or fallback if not set
+func getEnvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	val, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback, nil
+	}
+	d, err := ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return d, nil
+}
+
+// getEnvDurationMap returns the comma-separated key=duration pairs in the environment variable, e.g. "admin=1h,user=7d",
+// or nil if not set
+func getEnvDurationMap(key string) (map[string]time.Duration, error) {
+	pairs := getEnvList(key, nil)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	durations := make(map[string]time.Duration, len(pairs))
+	for _, pair := range pairs {
+		name, val, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s: %q is not a name=duration pair", key, pair)
+		}
+		d, err := ParseDuration(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		durations[name] = d
+	}
+	return durations, nil
+}
+
+// getEnvIntMap returns the comma-separated key=integer pairs in the environment variable,
+// e.g. "ListServices.search_query=200", or nil if not set
+func getEnvIntMap(key string) (map[string]int, error) {
+	pairs := getEnvList(key, nil)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]int, len(pairs))
+	for _, pair := range pairs {
+		name, val, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s: %q is not a name=integer pair", key, pair)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		values[name] = n
+	}
+	return values, nil
+}
+
+// getEnvSize returns the byte size in the environment variable, parsed with ParseSize, or fallback if not set
+func getEnvSize(key string, fallback int64) (int64, error) {
+	val, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback, nil
+	}
+	n, err := ParseSize(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return n, nil
+}
+
+// getEnvAnchoredRegexp compiles the environment variable, or fallback if not set, as a regular expression
+// that must match the whole input
+func getEnvAnchoredRegexp(key, fallback string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(`^(?:` + getEnv(key, fallback) + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return re, nil
+}
+
 // getEnvBool returns the boolean value of the environment variable or fallback if not set
 func getEnvBool(key string, fallback bool) bool {
 	if val, exists := os.LookupEnv(key); exists {
@@ -120,6 +983,16 @@ func getEnvBool(key string, fallback bool) bool {
 	return fallback
 }
 
+// GRPCListenAddr returns the address the gRPC server listens on
+func (c *Config) GRPCListenAddr() string {
+	return net.JoinHostPort(c.BindAddress, c.GRPCPort)
+}
+
+// HTTPListenAddr returns the address the HTTP gateway listens on
+func (c *Config) HTTPListenAddr() string {
+	return net.JoinHostPort(c.BindAddress, c.HTTPPort)
+}
+
 // GetDataFileAbsPath returns the absolute path to the data file
 func (c *Config) GetDataFileAbsPath() (string, error) {
 	if filepath.IsAbs(c.LocalDataStorage) {
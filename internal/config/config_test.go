@@ -0,0 +1,646 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+)
+
+func TestConfig_ListenAddr(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindAddress string
+		wantGRPC    string
+		wantHTTP    string
+	}{
+		{name: "all interfaces", bindAddress: "", wantGRPC: ":9000", wantHTTP: ":8000"},
+		{name: "loopback", bindAddress: "127.0.0.1", wantGRPC: "127.0.0.1:9000", wantHTTP: "127.0.0.1:8000"},
+		{name: "ipv6", bindAddress: "::1", wantGRPC: "[::1]:9000", wantHTTP: "[::1]:8000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", BindAddress: tt.bindAddress}
+			assert.Equal(t, tt.wantGRPC, cfg.GRPCListenAddr())
+			assert.Equal(t, tt.wantHTTP, cfg.HTTPListenAddr())
+		})
+	}
+}
+
+func TestConfig_Validate_BindAddress(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		bindAddress string
+		wantErr     bool
+	}{
+		{name: "empty binds all interfaces", bindAddress: ""},
+		{name: "ipv4 address", bindAddress: "127.0.0.1"},
+		{name: "ipv6 address", bindAddress: "::1"},
+		{name: "hostname rejected", bindAddress: "localhost", wantErr: true},
+		{name: "address with port rejected", bindAddress: "127.0.0.1:9000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, BindAddress: tt.bindAddress}
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "BIND_ADDRESS")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_IDFormats(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "false")
+
+	uuidOrg := "urn:uuid:3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+	t.Run("strict default rejects uuid urn", func(t *testing.T) {
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.True(t, cfg.OrganizationIDPattern.MatchString("org-1"))
+		assert.False(t, cfg.OrganizationIDPattern.MatchString(uuidOrg))
+		assert.Equal(t, 50, cfg.OrganizationIDMaxLength)
+	})
+
+	t.Run("relaxed pattern is anchored", func(t *testing.T) {
+		t.Setenv("ORGANIZATION_ID_PATTERN", `urn:uuid:[0-9a-f-]{36}`)
+		t.Setenv("ORGANIZATION_ID_MAX_LENGTH", "64")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.True(t, cfg.OrganizationIDPattern.MatchString(uuidOrg))
+		assert.False(t, cfg.OrganizationIDPattern.MatchString("tenant/"+uuidOrg))
+		assert.Equal(t, 64, cfg.OrganizationIDMaxLength)
+	})
+
+	t.Run("invalid pattern fails startup", func(t *testing.T) {
+		t.Setenv("SERVICE_ID_PATTERN", `[a-z`)
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SERVICE_ID_PATTERN")
+	})
+
+	t.Run("non-positive max length fails startup", func(t *testing.T) {
+		t.Setenv("SERVICE_ID_MAX_LENGTH", "0")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SERVICE_ID_MAX_LENGTH")
+	})
+}
+
+func TestConfig_Validate_CORSCredentials(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, CORSOrigins: "https://app.example.com, *", CORSAllowCredentials: true}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CORS_ALLOW_CREDENTIALS")
+
+	cfg.CORSOrigins = "https://app.example.com"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_AllowEmptyCatalog(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "services.yaml")
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: missing}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "data file does not exist")
+
+	cfg.AllowEmptyCatalog = true
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_Search(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, SearchFields: "name,version"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SEARCH_FIELDS")
+
+	cfg.SearchFields = "name,description,version"
+	cfg.SearchMatch = "most"
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SEARCH_MATCH")
+
+	cfg.SearchMatch = "any"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxListResults = -1
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MAX_LIST_RESULTS")
+}
+
+func TestConfig_Validate_NameNormalization(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, NameNormalization: "squash"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "NAME_NORMALIZATION")
+
+	cfg.NameNormalization = "collapse"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_Gateway(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, GatewayDialRetries: -1}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GATEWAY_DIAL_RETRIES")
+
+	cfg.GatewayDialRetries = 3
+	cfg.GatewayKeepalive = time.Second
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GATEWAY_KEEPALIVE")
+
+	cfg.GatewayKeepalive = 30 * time.Second
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_Locale(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile,
+		DefaultLocale: "en", SupportedLocales: []string{"de", "fr"}}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DEFAULT_LOCALE")
+
+	cfg.SupportedLocales = []string{"de", "EN"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_FutureTimestamps(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, FutureTimestamps: "strict"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "FUTURE_TIMESTAMPS")
+
+	cfg.FutureTimestamps = "reject"
+	cfg.TimestampSkew = -time.Minute
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TIMESTAMP_SKEW")
+
+	cfg.TimestampSkew = 5 * time.Minute
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_URLCheck(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, URLCheck: "strict"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "URL_CHECK")
+
+	cfg.URLCheck = URLCheckFail
+	cfg.URLCheckTimeout = -time.Second
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "URL_CHECK_TIMEOUT")
+
+	cfg.URLCheckTimeout = time.Second
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_LogFile(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, LogFile: "catalog.log"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_FILE_MAX_SIZE_MB")
+
+	cfg.LogFileMaxSizeMB = 100
+	cfg.LogFileMaxBackups = -1
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_FILE_MAX_BACKUPS")
+
+	cfg.LogFileMaxBackups = 5
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_CrossOrgAccess(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, CrossOrgAccess: "allow"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CROSS_ORG_ACCESS")
+
+	for _, policy := range []string{"hide", "deny"} {
+		cfg.CrossOrgAccess = policy
+		assert.NoError(t, cfg.Validate())
+	}
+}
+
+func TestConfig_Validate_AdminToken(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, AdminToken: "short"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ADMIN_TOKEN")
+
+	cfg.AdminToken = strings.Repeat("k", 32)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_AnonymousWrites(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, AllowAnonymousWrites: true, Environment: "development"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Environment = "production"
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ALLOW_ANONYMOUS_WRITES")
+}
+
+func TestConfig_Validate_DefaultSort(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, DefaultSortBy: "popularity"}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DEFAULT_SORT_BY")
+
+	cfg.DefaultSortBy = "updated_at"
+	cfg.DefaultSortOrder = "newest"
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DEFAULT_SORT_ORDER")
+
+	cfg.DefaultSortOrder = "desc"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_Sharding(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, ShardCount: 4, ShardIndex: 4}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SHARD_INDEX")
+
+	cfg.ShardIndex = 3
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ShardCount = -1
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SHARD_COUNT")
+}
+
+func TestConfig_Validate_Webhooks(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, WebhookURLs: []string{"https://hooks.example.com/catalog"}}
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "WEBHOOK_SECRET")
+
+	cfg.WebhookSecret = "secret"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.WebhookURLs = []string{"hooks.example.com/catalog"}
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "WEBHOOK_URLS")
+
+	cfg.WebhookURLs = nil
+	cfg.WebhookMaxRetries = -1
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "WEBHOOK_MAX_RETRIES")
+}
+
+func TestConfig_Validate_JWTSecret(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr string
+	}{
+		{name: "missing", secret: "", wantErr: "JWT_SECRET_KEY is required"},
+		{name: "too short", secret: "my-secret-key", wantErr: "at least 32 bytes"},
+		{name: "repeated character", secret: strings.Repeat("a", 40), wantErr: "too predictable"},
+		{name: "adequate", secret: "kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile,
+				EnableAuth: true, JWTSecretKey: tt.secret, JWTTokenDuration: time.Hour}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_AuthExemptions(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		paths   []string
+		methods []string
+		wantErr string
+	}{
+		{name: "valid", paths: []string{"/version", "/docs/"}, methods: []string{"/grpc.health.v1.Health/Watch"}},
+		{name: "relative path", paths: []string{"version"}, wantErr: "AUTH_EXEMPT_PATHS"},
+		{name: "short method name", methods: []string{"Watch"}, wantErr: "AUTH_EXEMPT_GRPC_METHODS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, EnableAuth: true,
+				JWTSecretKey: "kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA", JWTTokenDuration: time.Hour,
+				AuthExemptPaths: tt.paths, AuthExemptGRPCMethods: tt.methods}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_JWTSecretAutoGenerate(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "true")
+	t.Setenv("JWT_SECRET_KEY", "")
+	t.Setenv("JWT_SECRET_AUTO_GENERATE", "true")
+
+	t.Run("development", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "development")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.True(t, cfg.JWTSecretGenerated)
+		assert.GreaterOrEqual(t, len(cfg.JWTSecretKey), minJWTSecretLength)
+	})
+
+	t.Run("production", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "production")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_SECRET_AUTO_GENERATE")
+	})
+}
+
+func TestLoad_JWTSecretKeyFile(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "true")
+	t.Setenv("JWT_SECRET_KEY", "inline-kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA")
+
+	t.Run("file takes precedence", func(t *testing.T) {
+		secretFile := filepath.Join(t.TempDir(), "jwt-secret")
+		assert.NoError(t, os.WriteFile(secretFile, []byte("kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA\n"), 0o600))
+		t.Setenv("JWT_SECRET_KEY_FILE", secretFile)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA", cfg.JWTSecretKey)
+
+		jwtManager := auth.NewJWTManager(cfg.JWTSecretKey, time.Hour)
+		token, err := jwtManager.GenerateToken("user-1", "user@org1.com", "org-1", "user")
+		require.NoError(t, err)
+		claims, err := auth.NewJWTManager("kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA", time.Hour).ValidateToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.UserID)
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		t.Setenv("JWT_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_SECRET_KEY_FILE could not be read")
+	})
+
+	t.Run("ignored with auth disabled", func(t *testing.T) {
+		t.Setenv("ENABLE_AUTH", "false")
+		t.Setenv("JWT_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load()
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoad_JWTRoleTokenDurations(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "true")
+	t.Setenv("JWT_SECRET_KEY", "kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA")
+
+	t.Run("per role", func(t *testing.T) {
+		t.Setenv("JWT_ROLE_TOKEN_DURATIONS", "admin=1h, user=7d")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]time.Duration{"admin": time.Hour, "user": 7 * 24 * time.Hour}, cfg.JWTRoleTokenDurations)
+	})
+
+	t.Run("malformed pair", func(t *testing.T) {
+		t.Setenv("JWT_ROLE_TOKEN_DURATIONS", "admin")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_ROLE_TOKEN_DURATIONS")
+	})
+
+	t.Run("not positive", func(t *testing.T) {
+		t.Setenv("JWT_ROLE_TOKEN_DURATIONS", "admin=0s")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_ROLE_TOKEN_DURATIONS")
+	})
+}
+
+func TestLoad_JWTClockSkew(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "true")
+	t.Setenv("JWT_SECRET_KEY", "kQ9vN2xR7tB4mW8zL1pH6sJ3fD5gY0cA")
+
+	t.Run("default", func(t *testing.T) {
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.JWTClockSkew)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv("JWT_CLOCK_SKEW", "0s")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Zero(t, cfg.JWTClockSkew)
+	})
+
+	for _, value := range []string{"-1s", "10m"} {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv("JWT_CLOCK_SKEW", value)
+
+			_, err := Load()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "JWT_CLOCK_SKEW")
+		})
+	}
+}
+
+func TestConfig_Validate_DefaultOrganization(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile,
+		OrganizationIDPattern: regexp.MustCompile(`^[A-Za-z0-9_-]+$`), OrganizationIDMaxLength: 64, DefaultOrganization: "org-1"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DefaultOrganization = "org 1"
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DEFAULT_ORGANIZATION")
+}
+
+func TestConfig_Validate_AnonymousOrganizations(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile,
+		OrganizationIDPattern: regexp.MustCompile(`^[A-Za-z0-9_-]+$`), OrganizationIDMaxLength: 64,
+		AnonymousOrganizations: []string{"org-1", "org-2"}, DefaultOrganization: "org-1"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DefaultOrganization = "org-3"
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ANONYMOUS_ORGANIZATIONS")
+
+	cfg.DefaultOrganization = ""
+	cfg.AnonymousOrganizations = []string{"org 1"}
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ANONYMOUS_ORGANIZATIONS")
+}
+
+func TestConfig_Validate_LogPayloads(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	cfg := &Config{GRPCPort: "9000", HTTPPort: "8000", LocalDataStorage: dataFile, Environment: "development", LogPayloads: true}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Environment = "production"
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_PAYLOADS")
+}
+
+func TestLoad_JSONPretty(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "false")
+
+	t.Run("parameter honored outside production", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "development")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.False(t, cfg.JSONPretty)
+		assert.True(t, cfg.JSONPrettyParam)
+	})
+
+	t.Run("parameter ignored in production by default", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "production")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.False(t, cfg.JSONPrettyParam)
+	})
+
+	t.Run("parameter can be allowed in production", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "production")
+		t.Setenv("JSON_PRETTY_PARAM", "true")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.True(t, cfg.JSONPrettyParam)
+	})
+
+	t.Run("always pretty rejected in production", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "production")
+		t.Setenv("JSON_PRETTY", "true")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JSON_PRETTY")
+	})
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultConfigFile is the profiles file read when CONFIG_FILE is not set; it is optional
+	defaultConfigFile = "config.yaml"
+
+	// defaultProfileSection holds settings shared by every profile
+	defaultProfileSection = "default"
+)
+
+// loadProfile reads the profiles file and exports the settings of the selected profile, merged over the
+// default section, as environment variables. Like godotenv, variables already set in the environment win.
+// The profile is PROFILE, falling back to ENVIRONMENT, and the selected profile name is returned.
+// An empty PROFILE or CONFIG_FILE counts as unset.
+func loadProfile() (string, error) {
+	path := os.Getenv("CONFIG_FILE")
+	explicitFile := path != ""
+	if !explicitFile {
+		path = defaultConfigFile
+	}
+
+	profile := os.Getenv("PROFILE")
+	explicitProfile := profile != ""
+	if !explicitProfile {
+		profile = getEnv("ENVIRONMENT", "development")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// The profiles file is optional unless CONFIG_FILE points at one
+		if os.IsNotExist(err) && !explicitFile {
+			return profile, nil
+		}
+		return "", fmt.Errorf("failed to read CONFIG_FILE %s: %w", path, err)
+	}
+
+	var sections map[string]map[string]string
+	if err := yaml.Unmarshal(data, &sections); err != nil {
+		return "", fmt.Errorf("failed to parse CONFIG_FILE %s: %w", path, err)
+	}
+
+	selected, ok := sections[profile]
+	if !ok && explicitProfile && profile != defaultProfileSection {
+		return "", fmt.Errorf("PROFILE %q not found in %s", profile, path)
+	}
+
+	merged := make(map[string]string, len(sections[defaultProfileSection])+len(selected))
+	for key, val := range sections[defaultProfileSection] {
+		merged[key] = val
+	}
+	for key, val := range selected {
+		merged[key] = val
+	}
+
+	for key, val := range merged {
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, val); err != nil {
+			return "", fmt.Errorf("failed to apply %s from profile %q: %w", key, profile, err)
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unsetEnv clears the variables for the test and restores them afterwards, so profile values can apply
+func unsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		t.Setenv(key, "")
+		assert.NoError(t, os.Unsetenv(key))
+	}
+}
+
+func TestLoad_Profiles(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+
+	configFile := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte(`
+default:
+  LOCAL_DATA_STORAGE: `+dataFile+`
+  ENABLE_AUTH: false
+  LOG_LEVEL: debug
+  HTTP_PORT: 8000
+  REQUEST_TIMEOUT: 30s
+production:
+  LOG_LEVEL: warn
+  REQUEST_TIMEOUT: 5s
+  STRICT_SORT: true
+staging:
+  SEARCH_MIN_LENGTH: 500
+`), 0o600))
+
+	setup := func(t *testing.T) {
+		unsetEnv(t, "PROFILE", "ENVIRONMENT", "LOCAL_DATA_STORAGE", "ENABLE_AUTH", "LOG_LEVEL", "HTTP_PORT",
+			"REQUEST_TIMEOUT", "STRICT_SORT", "SEARCH_MIN_LENGTH")
+		t.Setenv("CONFIG_FILE", configFile)
+	}
+
+	t.Run("default section alone", func(t *testing.T) {
+		setup(t)
+		t.Setenv("PROFILE", "default")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, "debug", cfg.LogLevel)
+		assert.Equal(t, "30s", cfg.RequestTimeout.String())
+		assert.False(t, cfg.StrictSort)
+	})
+
+	t.Run("prod profile overrides default values", func(t *testing.T) {
+		setup(t)
+		t.Setenv("PROFILE", "production")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, "production", cfg.Profile)
+		assert.Equal(t, "warn", cfg.LogLevel)
+		assert.Equal(t, "5s", cfg.RequestTimeout.String())
+		assert.True(t, cfg.StrictSort)
+		// values the profile leaves out come from the default section
+		assert.Equal(t, "8000", cfg.HTTPPort)
+		assert.Equal(t, dataFile, cfg.LocalDataStorage)
+	})
+
+	t.Run("environment selects the profile", func(t *testing.T) {
+		setup(t)
+		t.Setenv("ENVIRONMENT", "production")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, "production", cfg.Profile)
+		assert.Equal(t, "warn", cfg.LogLevel)
+	})
+
+	t.Run("env vars win over the profile", func(t *testing.T) {
+		setup(t)
+		t.Setenv("PROFILE", "production")
+		t.Setenv("LOG_LEVEL", "error")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, "error", cfg.LogLevel)
+		assert.Equal(t, "5s", cfg.RequestTimeout.String())
+	})
+
+	t.Run("validation runs on the merged result", func(t *testing.T) {
+		setup(t)
+		t.Setenv("PROFILE", "staging")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SEARCH_MIN_LENGTH")
+	})
+
+	t.Run("unknown explicit profile", func(t *testing.T) {
+		setup(t)
+		t.Setenv("PROFILE", "prod")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `PROFILE "prod" not found`)
+	})
+
+	t.Run("missing explicit config file", func(t *testing.T) {
+		setup(t)
+		t.Setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CONFIG_FILE")
+	})
+}
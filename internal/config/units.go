@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// longDurationUnit matches a day or week component such as "7d" or "1.5w", which time.ParseDuration lacks
+var longDurationUnit = regexp.MustCompile(`(\d+(?:\.\d*)?|\.\d+)([dw])`)
+
+// hoursPerUnit is the length of each long duration unit in hours
+var hoursPerUnit = map[string]float64{"d": 24, "w": 7 * 24}
+
+// sizeUnits are the accepted size suffixes, in binary multiples so "4MB" is gRPC's default 4 MiB message size
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	// Longer suffixes first so "MB" is not read as "B"
+	{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+	{"B", 1},
+}
+
+// ParseDuration parses a duration like time.ParseDuration, additionally accepting days ("d") and weeks ("w"),
+// alone or combined with other units, e.g. "7d", "2w" or "1d12h". A day is always 24 hours.
+func ParseDuration(s string) (time.Duration, error) {
+	var convErr error
+	expanded := longDurationUnit.ReplaceAllStringFunc(s, func(component string) string {
+		match := longDurationUnit.FindStringSubmatch(component)
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			convErr = err
+			return component
+		}
+		return strconv.FormatFloat(value*hoursPerUnit[match[2]], 'f', -1, 64) + "h"
+	})
+
+	d, err := time.ParseDuration(expanded)
+	if convErr != nil || err != nil {
+		return 0, fmt.Errorf("%q is not a duration, use a number with a unit such as 30s, 15m, 24h, 7d or 2w", s)
+	}
+	return d, nil
+}
+
+// ParseSize parses a byte size such as "512", "64KB" or "4MB". Suffixes are case-insensitive binary
+// multiples: KB, MB and GB (or KiB, MiB, GiB) are 1024, 1024² and 1024³ bytes.
+func ParseSize(s string) (int64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(trimmed, unit.suffix) {
+			trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || value < 0 || value > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("%q is not a size, use a whole number of bytes with an optional unit such as 512KB or 4MB", s)
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"7d", 168 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"90m", 90 * time.Minute},
+		{"30s", 30 * time.Second},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"", "7", "7days", "d", "1y"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseDuration(input)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "7d")
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"4MB", 4 * 1024 * 1024},
+		{"512KB", 512 * 1024},
+		{"1GB", 1024 * 1024 * 1024},
+		{"4mb", 4 * 1024 * 1024},
+		{"4 MiB", 4 * 1024 * 1024},
+		{"100B", 100},
+		{"2048", 2048},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSize(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"", "MB", "-1KB", "1.5MB", "4TB", "lots"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseSize(input)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "4MB")
+		})
+	}
+}
+
+func TestLoad_Units(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("services: []\n"), 0o600))
+	t.Setenv("LOCAL_DATA_STORAGE", dataFile)
+	t.Setenv("ENABLE_AUTH", "false")
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, cfg.JWTTokenDuration)
+		assert.Equal(t, int64(DefaultMaxMessageSize), cfg.MaxMessageSize)
+	})
+
+	t.Run("days and megabytes", func(t *testing.T) {
+		t.Setenv("JWT_TOKEN_DURATION", "7d")
+		t.Setenv("MAX_MESSAGE_SIZE", "16MB")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 168*time.Hour, cfg.JWTTokenDuration)
+		assert.Equal(t, int64(16*1024*1024), cfg.MaxMessageSize)
+	})
+
+	t.Run("invalid duration fails startup", func(t *testing.T) {
+		t.Setenv("CORS_MAX_AGE", "1 day")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CORS_MAX_AGE")
+	})
+
+	t.Run("invalid size fails startup", func(t *testing.T) {
+		t.Setenv("MAX_MESSAGE_SIZE", "4 megs")
+
+		_, err := Load()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "MAX_MESSAGE_SIZE")
+	})
+}
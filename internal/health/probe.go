@@ -0,0 +1,89 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// Probe tracks process readiness for Kubernetes-style liveness and readiness checks.
+// Liveness only reports that the process is serving; readiness reports whether it should receive traffic.
+// Readiness is also reported through the standard gRPC health service, for load balancers that check it.
+type Probe struct {
+	ready atomic.Bool
+	// grpcHealth reports SERVING while ready and NOT_SERVING otherwise, for the server as a whole ("")
+	// and for each of grpcServices
+	grpcHealth   *grpchealth.Server
+	grpcServices []string
+}
+
+// NewProbe creates a probe that starts out not ready. grpcServices are the fully qualified gRPC service
+// names, e.g. "v1.CatalogService", whose health status follows readiness alongside the overall status.
+func NewProbe(grpcServices ...string) *Probe {
+	p := &Probe{grpcHealth: grpchealth.NewServer(), grpcServices: grpcServices}
+	p.setGRPCStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return p
+}
+
+// GRPCHealthServer returns the standard gRPC health service reporting the probe's readiness
+func (p *Probe) GRPCHealthServer() healthpb.HealthServer {
+	return p.grpcHealth
+}
+
+// Ready reports whether the application is ready to receive traffic
+func (p *Probe) Ready() bool {
+	return p.ready.Load()
+}
+
+// SetReady marks the application as ready or not ready to receive traffic
+func (p *Probe) SetReady(ready bool) {
+	if p.ready.Swap(ready) != ready {
+		logger.Get().Infow("Readiness changed", "ready", ready)
+	}
+	if ready {
+		p.setGRPCStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		p.setGRPCStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// setGRPCStatus sets the gRPC health status of the server and every registered service
+func (p *Probe) setGRPCStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	p.grpcHealth.SetServingStatus("", status)
+	for _, service := range p.grpcServices {
+		p.grpcHealth.SetServingStatus(service, status)
+	}
+}
+
+// LivenessHandler always responds 200 while the process is serving HTTP
+func (p *Probe) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, "alive")
+	})
+}
+
+// ReadinessHandler responds 200 once ready and 503 before startup completes or during shutdown
+func (p *Probe) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.Ready() {
+			writeStatus(w, http.StatusServiceUnavailable, "not_ready")
+			return
+		}
+		writeStatus(w, http.StatusOK, "ready")
+	})
+}
+
+// writeStatus writes a {"status": ...} JSON body with the given HTTP status code
+func writeStatus(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": status}); err != nil {
+		logger.Get().Errorw("Failed to write probe response", "error", err)
+	}
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestProbe_Readiness(t *testing.T) {
+	probe := NewProbe()
+	handler := probe.ReadinessHandler()
+
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return rec
+	}
+
+	// before data is loaded
+	rec := get()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"not_ready"}`, rec.Body.String())
+
+	// after startup completes
+	probe.SetReady(true)
+	rec = get()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ready"}`, rec.Body.String())
+
+	// during graceful shutdown
+	probe.SetReady(false)
+	assert.Equal(t, http.StatusServiceUnavailable, get().Code)
+}
+
+func TestProbe_Liveness(t *testing.T) {
+	probe := NewProbe()
+
+	for _, ready := range []bool{false, true} {
+		probe.SetReady(ready)
+		rec := httptest.NewRecorder()
+		probe.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"alive"}`, rec.Body.String())
+	}
+}
+
+func TestProbe_GRPCHealth(t *testing.T) {
+	probe := NewProbe("v1.CatalogService")
+	server := probe.GRPCHealthServer()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	// before startup completes
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("v1.CatalogService"))
+
+	probe.SetReady(true)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("v1.CatalogService"))
+
+	// during graceful shutdown
+	probe.SetReady(false)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("v1.CatalogService"))
+}
@@ -0,0 +1,114 @@
+// Package idgen generates collision-resistant, time-sortable IDs for new catalog entries.
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of ID generators accepted by New
+const (
+	KindULID = "ulid"
+	KindUUID = "uuid"
+)
+
+// Generator creates new unique IDs. Implementations are safe for concurrent use.
+type Generator interface {
+	NewID() string
+}
+
+// New returns the generator of the given kind: "ulid" (the default when empty) or "uuid"
+func New(kind string) (Generator, error) {
+	switch kind {
+	case "", KindULID:
+		return NewULID(), nil
+	case KindUUID:
+		return UUID{}, nil
+	default:
+		return nil, fmt.Errorf("unknown ID generator %q, use %q or %q", kind, KindULID, KindUUID)
+	}
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID generates 26-character ULIDs such as "01J9Z3K8Q4X7N2B5C6D7E8F9G0": a 48-bit millisecond timestamp
+// followed by 80 random bits. IDs generated in the same millisecond increment the random part, so IDs from
+// one generator are unique and strictly increasing.
+type ULID struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	lastMs  uint64
+	entropy [10]byte
+}
+
+// NewULID creates a ULID generator
+func NewULID() *ULID {
+	return &ULID{now: time.Now}
+}
+
+// NewID returns the next ULID
+func (g *ULID) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMs {
+		g.lastMs = ms
+		if _, err := rand.Read(g.entropy[:]); err != nil {
+			panic(fmt.Sprintf("idgen: reading random bytes: %v", err))
+		}
+	} else if !increment(g.entropy[:]) {
+		// The random part overflowed within one millisecond (or the clock went back): borrow the next one
+		g.lastMs++
+	}
+
+	var id [16]byte
+	for i := 0; i < 6; i++ {
+		id[i] = byte(g.lastMs >> (40 - 8*i))
+	}
+	copy(id[6:], g.entropy[:])
+	return encodeULID(id)
+}
+
+// increment adds one to a big-endian number, reporting false when it wrapped around to zero
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID renders 128 bits as 26 Crockford base32 characters, most significant first
+func encodeULID(id [16]byte) string {
+	out := make([]byte, 26)
+	// 130 bits of output for 128 bits of input: the first character carries only the top 3 bits
+	var acc uint64
+	bits := 2
+	pos := 0
+	for _, b := range id {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>uint(bits))&31]
+			pos++
+		}
+	}
+	return string(out)
+}
+
+// UUID generates version 7 UUIDs, which start with a millisecond timestamp and so sort by creation time
+type UUID struct{}
+
+// NewID returns a new UUID such as "01927b4e-8f3a-7c21-9d4e-5f6a7b8c9d0e"
+func (UUID) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
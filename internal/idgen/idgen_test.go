@@ -0,0 +1,83 @@
+package idgen
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validID is the catalog's default ID format
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,50}$`)
+
+func TestGenerators_UniqueAndValidUnderConcurrency(t *testing.T) {
+	for _, kind := range []string{KindULID, KindUUID} {
+		t.Run(kind, func(t *testing.T) {
+			gen, err := New(kind)
+			require.NoError(t, err)
+
+			const workers, perWorker = 16, 2000
+			ids := make([][]string, workers)
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for i := 0; i < perWorker; i++ {
+						ids[w] = append(ids[w], gen.NewID())
+					}
+				}(w)
+			}
+			wg.Wait()
+
+			seen := make(map[string]bool, workers*perWorker)
+			for _, batch := range ids {
+				for _, id := range batch {
+					assert.Regexp(t, validID, id)
+					assert.False(t, seen[id], "duplicate ID %s", id)
+					seen[id] = true
+				}
+			}
+			assert.Len(t, seen, workers*perWorker)
+		})
+	}
+}
+
+func TestULID_Sortable(t *testing.T) {
+	gen := NewULID()
+	clock := time.UnixMilli(1_700_000_000_000)
+	gen.now = func() time.Time { return clock }
+
+	var ids []string
+	for i := 0; i < 100; i++ {
+		if i%10 == 0 {
+			clock = clock.Add(time.Millisecond)
+		}
+		ids = append(ids, gen.NewID())
+	}
+
+	assert.Len(t, ids[0], 26)
+	assert.True(t, sort.StringsAreSorted(ids), "IDs increase within and across milliseconds")
+
+	// The clock going back does not break ordering
+	clock = clock.Add(-time.Hour)
+	assert.Greater(t, gen.NewID(), ids[len(ids)-1])
+}
+
+func TestEncodeULID(t *testing.T) {
+	assert.Equal(t, "00000000000000000000000000", encodeULID([16]byte{}))
+	max := [16]byte{}
+	for i := range max {
+		max[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID(max))
+}
+
+func TestNew_UnknownKind(t *testing.T) {
+	_, err := New("counter")
+	assert.Error(t, err)
+}
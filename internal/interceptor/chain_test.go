@@ -0,0 +1,34 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestChainUnary(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ExportServices"}
+	resp, err := ChainUnary(record("first"), record("second"))(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "req", resp)
+	assert.Equal(t, []string{"first /v1.CatalogService/ExportServices", "second /v1.CatalogService/ExportServices", "handler"}, calls)
+
+	resp, err = ChainUnary()(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "req", resp)
+}
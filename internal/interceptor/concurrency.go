@@ -0,0 +1,92 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// ConcurrencyLimiter allows at most a fixed number of requests in flight at once, unary calls and streams
+// counting against the same limit. Requests beyond it fail immediately with ResourceExhausted instead of
+// queueing, carrying the retry delay as a google.rpc.RetryInfo hint when positive.
+type ConcurrencyLimiter struct {
+	limit      int
+	retryDelay time.Duration
+	// slots holds a token per request in flight, nil when there is no limit
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter for limit requests in flight; limit <= 0 disables it
+func NewConcurrencyLimiter(limit int, retryDelay time.Duration) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{limit: limit, retryDelay: retryDelay}
+	if limit > 0 {
+		l.slots = make(chan struct{}, limit)
+	}
+	return l
+}
+
+// acquire takes a slot for a request to method, returning the function freeing it or the error rejecting
+// the request when every slot is taken
+func (l *ConcurrencyLimiter) acquire(method string) (func(), error) {
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	default:
+		logger.Get().Warnw("Rejected request over concurrency limit", "method", method, "limit", l.limit)
+		st := status.Newf(codes.ResourceExhausted, "server is handling the maximum of %d concurrent requests, retry later", l.limit)
+		return nil, withRetryDelay(st, l.retryDelay).Err()
+	}
+}
+
+// UnaryInterceptor returns a gRPC interceptor holding a slot while a unary call runs
+func (l *ConcurrencyLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := l.acquire(info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a gRPC stream interceptor holding a slot until a stream ends
+func (l *ConcurrencyLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := l.acquire(info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
+
+// ConcurrencyLimit returns a gRPC interceptor that allows at most limit requests in flight at once,
+// see ConcurrencyLimiter; limit <= 0 disables it
+func ConcurrencyLimit(limit int, retryDelay time.Duration) grpc.UnaryServerInterceptor {
+	return NewConcurrencyLimiter(limit, retryDelay).UnaryInterceptor()
+}
+
+// withRetryDelay attaches a google.rpc.RetryInfo detail telling clients how long to wait before retrying,
+// leaving the status unchanged when delay is not positive
+func withRetryDelay(st *status.Status, delay time.Duration) *status.Status {
+	if delay <= 0 {
+		return st
+	}
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
@@ -0,0 +1,112 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	const limit = 3
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	limiter := ConcurrencyLimit(limit, 2*time.Second)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocked := func(ctx context.Context, req interface{}) (interface{}, error) {
+		started <- struct{}{}
+		<-release
+		return "ok", nil
+	}
+
+	// saturate the limit with handlers that block until released
+	var wg sync.WaitGroup
+	for i := 0; i < limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := limiter(context.Background(), nil, info, blocked)
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", resp)
+		}()
+		<-started
+	}
+
+	immediate := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	_, err := limiter(context.Background(), nil, info, immediate)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the rejection tells clients when to retry
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if r, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, 2*time.Second, retry.GetRetryDelay().AsDuration())
+	}
+
+	// finished requests free their slots
+	close(release)
+	wg.Wait()
+	resp, err := limiter(context.Background(), nil, info, immediate)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestConcurrencyLimit_Disabled(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	resp, err := ConcurrencyLimit(0, time.Second)(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestConcurrencyLimiter_StreamsShareTheLimit(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 0)
+	unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServiceVersions", IsServerStream: true}
+	stream := &contextStream{ctx: context.Background()}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- limiter.StreamInterceptor()(nil, stream, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	// the open stream holds the only slot
+	_, err := limiter.UnaryInterceptor()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	err = limiter.StreamInterceptor()(nil, stream, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the ended stream frees it
+	close(release)
+	assert.NoError(t, <-done)
+	resp, err := limiter.UnaryInterceptor()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
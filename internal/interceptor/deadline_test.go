@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestDefaultDeadline(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+
+	var gotDeadline time.Time
+	var hasDeadline bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotDeadline, hasDeadline = ctx.Deadline()
+		return nil, nil
+	}
+
+	t.Run("applies default when client sends none", func(t *testing.T) {
+		before := time.Now()
+		_, err := DefaultDeadline(5*time.Second)(context.Background(), nil, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, before.Add(5*time.Second), gotDeadline, time.Second)
+	})
+
+	t.Run("keeps client deadline", func(t *testing.T) {
+		clientDeadline := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithDeadline(context.Background(), clientDeadline)
+		defer cancel()
+
+		_, err := DefaultDeadline(5*time.Second)(ctx, nil, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, hasDeadline)
+		assert.Equal(t, clientDeadline, gotDeadline)
+	})
+
+	t.Run("disabled with zero timeout", func(t *testing.T) {
+		_, err := DefaultDeadline(0)(context.Background(), nil, info, handler)
+		assert.NoError(t, err)
+		assert.False(t, hasDeadline)
+	})
+}
@@ -0,0 +1,114 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// FeatureFlags gates experimental RPCs behind named flags so they can ship dark and be enabled per environment
+type FeatureFlags struct {
+	enabled map[string]bool
+	// gated maps full gRPC method names to the flag that enables them
+	gated map[string]string
+}
+
+// featureState is the JSON body served by the feature flags admin endpoint
+type featureState struct {
+	Features map[string]bool `json:"features"`
+	// Methods maps each gated gRPC method to its flag
+	Methods map[string]string `json:"methods"`
+}
+
+// NewFeatureFlags creates feature flags with the given flags on and every other flag off.
+// gated maps full gRPC method names to the flag they require; methods not in it are never gated.
+func NewFeatureFlags(enabled []string, gated map[string]string) *FeatureFlags {
+	f := &FeatureFlags{enabled: make(map[string]bool, len(enabled)), gated: gated}
+	for _, feature := range enabled {
+		f.enabled[feature] = true
+	}
+
+	known := make(map[string]bool, len(gated))
+	for _, feature := range gated {
+		known[feature] = true
+	}
+	for _, feature := range enabled {
+		if !known[feature] {
+			logger.Get().Warnw("Unknown feature flag enabled", "feature", feature)
+		}
+	}
+	return f
+}
+
+// Enabled reports whether a feature flag is on
+func (f *FeatureFlags) Enabled(feature string) bool {
+	return f.enabled[feature]
+}
+
+// UnaryInterceptor returns a gRPC interceptor that fails gated methods with Unimplemented while their flag is off
+func (f *FeatureFlags) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := f.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods
+func (f *FeatureFlags) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := f.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check returns the Unimplemented error rejecting a gated method whose flag is off
+func (f *FeatureFlags) check(fullMethod string) error {
+	if feature, ok := f.gated[fullMethod]; ok && !f.Enabled(feature) {
+		logger.Get().Debugw("Rejected request for disabled feature", "method", fullMethod, "feature", feature)
+		return status.Errorf(codes.Unimplemented, "%s is not enabled on this server", fullMethod)
+	}
+	return nil
+}
+
+// ServeHTTP reports every known flag with its state and the methods it gates, for debugging
+func (f *FeatureFlags) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state := featureState{Features: make(map[string]bool), Methods: f.gated}
+	for _, feature := range f.gated {
+		state.Features[feature] = f.Enabled(feature)
+	}
+	for feature := range f.enabled {
+		state.Features[feature] = true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		logger.Get().Errorw("Failed to encode feature flags", "error", err)
+	}
+}
+
+// Features returns the enabled flags, sorted
+func (f *FeatureFlags) Features() []string {
+	features := make([]string, 0, len(f.enabled))
+	for feature := range f.enabled {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	return features
+}
@@ -0,0 +1,79 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testGatedMethods = map[string]string{
+	"/v1.CatalogService/SearchServices": "search",
+	"/v1.CatalogService/StreamServices": "stream",
+}
+
+func TestFeatureFlags_UnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	interceptor := NewFeatureFlags([]string{"search"}, testGatedMethods).UnaryInterceptor()
+
+	tests := []struct {
+		name     string
+		method   string
+		wantCode codes.Code
+	}{
+		{"enabled feature passes through", "/v1.CatalogService/SearchServices", codes.OK},
+		{"disabled feature unimplemented", "/v1.CatalogService/StreamServices", codes.Unimplemented},
+		{"ungated method passes through", "/v1.CatalogService/ListServices", codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "ok", resp)
+			}
+		})
+	}
+}
+
+func TestFeatureFlags_StreamInterceptor(t *testing.T) {
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+	interceptor := NewFeatureFlags([]string{"search"}, testGatedMethods).StreamInterceptor()
+	stream := &contextStream{ctx: context.Background()}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServices"}, handler)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	err = interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/SearchServices"}, handler)
+	assert.NoError(t, err)
+
+	err = interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/ListServices"}, handler)
+	assert.NoError(t, err)
+}
+
+func TestFeatureFlags_ServeHTTP(t *testing.T) {
+	flags := NewFeatureFlags([]string{"search", "beta"}, testGatedMethods)
+
+	rec := httptest.NewRecorder()
+	flags.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/features", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"features": {"search": true, "stream": false, "beta": true},
+		"methods": {"/v1.CatalogService/SearchServices": "search", "/v1.CatalogService/StreamServices": "stream"}
+	}`, rec.Body.String())
+	assert.Equal(t, []string{"beta", "search"}, flags.Features())
+
+	rec = httptest.NewRecorder()
+	flags.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/features", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,183 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// fieldLimit caps the size of one request field: characters of a string, bytes of a bytes field,
+// or entries of a repeated field, counting distinct values of a repeated string field
+type fieldLimit struct {
+	field protoreflect.FieldDescriptor
+	max   int
+}
+
+// FieldLimits rejects requests with an oversized field before they reach the handler, complementing gRPC's
+// limit on the size of the whole message
+type FieldLimits struct {
+	// limits are keyed by full gRPC method name
+	limits map[string][]fieldLimit
+}
+
+// NewFieldLimits resolves limits keyed by "Method.field", e.g. "ListServices.search_query", against the
+// methods of svc. A limit <= 0 disables the field's limit. Unknown methods or fields, and fields that are
+// neither strings, bytes nor repeated, are an error.
+func NewFieldLimits(svc protoreflect.ServiceDescriptor, limits map[string]int) (*FieldLimits, error) {
+	f := &FieldLimits{limits: make(map[string][]fieldLimit)}
+
+	keys := make([]string, 0, len(limits))
+	for key := range limits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		methodName, fieldName, ok := strings.Cut(key, ".")
+		if !ok {
+			return nil, fmt.Errorf("field limit %q is not Method.field", key)
+		}
+		method := svc.Methods().ByName(protoreflect.Name(methodName))
+		if method == nil {
+			return nil, fmt.Errorf("field limit %q: unknown method %s", key, methodName)
+		}
+		field := method.Input().Fields().ByName(protoreflect.Name(fieldName))
+		if field == nil {
+			return nil, fmt.Errorf("field limit %q: %s has no field %s", key, method.Input().Name(), fieldName)
+		}
+		if !field.IsList() && field.Kind() != protoreflect.StringKind && field.Kind() != protoreflect.BytesKind {
+			return nil, fmt.Errorf("field limit %q: %s is not a string, bytes or repeated field", key, fieldName)
+		}
+		if limits[key] <= 0 {
+			continue
+		}
+
+		fullMethod := fmt.Sprintf("/%s/%s", svc.FullName(), method.Name())
+		f.limits[fullMethod] = append(f.limits[fullMethod], fieldLimit{field: field, max: limits[key]})
+	}
+	return f, nil
+}
+
+// check returns the first field of msg over its limit as an InvalidArgument error naming the field
+func (f *FieldLimits) check(fullMethod string, msg proto.Message) error {
+	limits := f.limits[fullMethod]
+	if len(limits) == 0 {
+		return nil
+	}
+
+	m := msg.ProtoReflect()
+	for _, l := range limits {
+		if !m.Has(l.field) {
+			continue
+		}
+
+		v := m.Get(l.field)
+		var size int
+		var unit string
+		switch {
+		case l.field.IsList() && l.field.Kind() == protoreflect.StringKind:
+			size, unit = distinctValues(v.List()), "distinct entries"
+		case l.field.IsList():
+			size, unit = v.List().Len(), "entries"
+		case l.field.Kind() == protoreflect.BytesKind:
+			size, unit = len(v.Bytes()), "bytes"
+		default:
+			size, unit = utf8.RuneCountInString(v.String()), "characters"
+		}
+		if size <= l.max {
+			continue
+		}
+
+		description := fmt.Sprintf("%s has %d %s, the limit is %d", l.field.Name(), size, unit, l.max)
+		st := status.New(codes.InvalidArgument, description)
+		withDetails, err := st.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       string(l.field.Name()),
+				Description: description,
+			}},
+		})
+		if err == nil {
+			st = withDetails
+		}
+		return st.Err()
+	}
+	return nil
+}
+
+// distinctValues counts the distinct values of a repeated string field, reading comma-separated entries
+// as several trimmed values like the service reads ID lists, so duplicates never count against the limit
+func distinctValues(list protoreflect.List) int {
+	seen := make(map[string]bool, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		for _, value := range strings.Split(list.Get(i).String(), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				seen[value] = true
+			}
+		}
+	}
+	return len(seen)
+}
+
+// UnaryInterceptor returns a gRPC interceptor failing requests with a field over its limit with InvalidArgument
+func (f *FieldLimits) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := f.check(info.FullMethod, msg); err != nil {
+				logger.Get().Warnw("Rejected request over a field limit", "method", info.FullMethod, "error", err)
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UnaryInterceptorAs is UnaryInterceptor checking every request against the limits of fullMethod, for handlers
+// outside gRPC that take the requests of an RPC, such as the export taking ListServices requests
+func (f *FieldLimits) UnaryInterceptorAs(fullMethod string) grpc.UnaryServerInterceptor {
+	check := f.UnaryInterceptor()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return check(ctx, req, &grpc.UnaryServerInfo{Server: info.Server, FullMethod: fullMethod}, handler)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods, checking each message received from the client
+func (f *FieldLimits) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if len(f.limits[info.FullMethod]) == 0 {
+			return handler(srv, ss)
+		}
+		return handler(srv, &fieldLimitStream{ServerStream: ss, limits: f, method: info.FullMethod})
+	}
+}
+
+// fieldLimitStream checks the messages a stream receives against the field limits of its method
+type fieldLimitStream struct {
+	grpc.ServerStream
+	limits *FieldLimits
+	method string
+}
+
+// RecvMsg receives m, failing with InvalidArgument when a field of it is over its limit
+func (s *fieldLimitStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if err := s.limits.check(s.method, msg); err != nil {
+			logger.Get().Warnw("Rejected request over a field limit", "method", s.method, "error", err)
+			return err
+		}
+	}
+	return nil
+}
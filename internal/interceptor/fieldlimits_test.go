@@ -0,0 +1,139 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+var catalogServiceDescriptor = v1.File_v1_catalog_proto.Services().ByName("CatalogService")
+
+func TestFieldLimits(t *testing.T) {
+	limits, err := NewFieldLimits(catalogServiceDescriptor, map[string]int{
+		"ListServices.search_query": 10,
+		"ListServices.version":      0,
+		"BatchGetServices.ids":      2,
+	})
+	require.NoError(t, err)
+
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return "ok", nil
+	}
+	listServices := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	batchGet := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/BatchGetServices"}
+
+	tests := []struct {
+		name      string
+		info      *grpc.UnaryServerInfo
+		req       interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{"string within limit", listServices, &v1.ListServicesRequest{SearchQuery: "user"}, "", ""},
+		{"string at limit counts characters", listServices, &v1.ListServicesRequest{SearchQuery: strings.Repeat("é", 10)}, "", ""},
+		{"string over limit", listServices, &v1.ListServicesRequest{SearchQuery: strings.Repeat("a", 11)}, "search_query", "search_query has 11 characters, the limit is 10"},
+		{"disabled limit", listServices, &v1.ListServicesRequest{Version: strings.Repeat("1", 500)}, "", ""},
+		{"repeated within limit", batchGet, &v1.BatchGetServicesRequest{Ids: []string{"1", "2"}}, "", ""},
+		{"repeated over limit", batchGet, &v1.BatchGetServicesRequest{Ids: []string{"1", "2", "3"}}, "ids", "ids has 3 distinct entries, the limit is 2"},
+		{"repeated duplicates count once", batchGet, &v1.BatchGetServicesRequest{Ids: []string{"1", "2", "1", " 2", "1,2"}}, "", ""},
+		{"repeated comma-separated entries", batchGet, &v1.BatchGetServicesRequest{Ids: []string{"1,2,3"}}, "ids", "ids has 3 distinct entries, the limit is 2"},
+		{"method without limits", &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/GetService"}, &v1.GetServiceRequest{Id: strings.Repeat("a", 100)}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = false
+			resp, err := limits.UnaryInterceptor()(context.Background(), tt.req, tt.info, handler)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "ok", resp)
+				assert.True(t, handled)
+				return
+			}
+
+			assert.False(t, handled, "the handler must not run")
+			st := status.Convert(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Equal(t, tt.wantMsg, st.Message())
+			require.Len(t, st.Details(), 1)
+			badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+			require.True(t, ok)
+			require.Len(t, badRequest.FieldViolations, 1)
+			assert.Equal(t, tt.wantField, badRequest.FieldViolations[0].Field)
+		})
+	}
+}
+
+// recvStream is a server stream receiving msg from the client
+type recvStream struct {
+	grpc.ServerStream
+	msg *v1.StreamServiceVersionsRequest
+}
+
+func (s *recvStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	m.(*v1.StreamServiceVersionsRequest).ServiceId = s.msg.GetServiceId()
+	return nil
+}
+
+func TestFieldLimits_StreamInterceptor(t *testing.T) {
+	limits, err := NewFieldLimits(catalogServiceDescriptor, map[string]int{"StreamServiceVersions.service_id": 10})
+	require.NoError(t, err)
+	info := &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServiceVersions", IsServerStream: true}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&v1.StreamServiceVersionsRequest{})
+	}
+
+	err = limits.StreamInterceptor()(nil, &recvStream{msg: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1"}}, info, handler)
+	assert.NoError(t, err)
+
+	err = limits.StreamInterceptor()(nil, &recvStream{msg: &v1.StreamServiceVersionsRequest{ServiceId: strings.Repeat("a", 11)}}, info, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "service_id has 11 characters, the limit is 10", st.Message())
+}
+
+func TestFieldLimits_UnaryInterceptorAs(t *testing.T) {
+	limits, err := NewFieldLimits(catalogServiceDescriptor, map[string]int{"ListServices.search_query": 10})
+	require.NoError(t, err)
+	interceptor := limits.UnaryInterceptorAs("/v1.CatalogService/ListServices")
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ExportServices"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	resp, err := interceptor(context.Background(), &v1.ListServicesRequest{SearchQuery: "user"}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), &v1.ListServicesRequest{SearchQuery: strings.Repeat("a", 11)}, info, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "search_query has 11 characters, the limit is 10", st.Message())
+}
+
+func TestNewFieldLimits_Invalid(t *testing.T) {
+	for _, key := range []string{
+		"ListServices",
+		"ListWidgets.search_query",
+		"ListServices.query",
+		"ListServices.page_size",
+	} {
+		_, err := NewFieldLimits(catalogServiceDescriptor, map[string]int{key: 10})
+		assert.Error(t, err, key)
+	}
+}
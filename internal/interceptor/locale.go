@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// localeMetadataKeys are where the caller's language preference arrives: Accept-Language sent directly over
+// gRPC, and as forwarded by the HTTP gateway
+var localeMetadataKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+type localeKey struct{}
+
+// LocaleFromContext returns the locale chosen for the request, or "" when no locale interceptor ran
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Locale returns a gRPC interceptor that picks the request locale from the caller's Accept-Language,
+// restricted to the supported locales, and stores it in the context. Requests without a supported
+// preference get defaultLocale. Messages are not localized yet, the locale is only recorded.
+func Locale(defaultLocale string, supported []string) grpc.UnaryServerInterceptor {
+	withLocale := localeResolver(defaultLocale, supported)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withLocale(ctx), req)
+	}
+}
+
+// LocaleStream is Locale for streaming methods
+func LocaleStream(defaultLocale string, supported []string) grpc.StreamServerInterceptor {
+	withLocale := localeResolver(defaultLocale, supported)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withLocale(ss.Context())})
+	}
+}
+
+// localeResolver returns a function storing the locale chosen from the Accept-Language of a request in its context
+func localeResolver(defaultLocale string, supported []string) func(context.Context) context.Context {
+	byTag := make(map[string]string, len(supported))
+	for _, locale := range supported {
+		byTag[strings.ToLower(locale)] = locale
+	}
+
+	return func(ctx context.Context) context.Context {
+		locale := defaultLocale
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, key := range localeMetadataKeys {
+				if values := md.Get(key); len(values) > 0 {
+					if match := matchLocale(strings.Join(values, ","), byTag); match != "" {
+						locale = match
+					}
+					break
+				}
+			}
+		}
+		return context.WithValue(ctx, localeKey{}, locale)
+	}
+}
+
+// matchLocale returns the supported locale best matching an Accept-Language header, trying each language
+// range in preference order and falling back from a regional tag such as "en-US" to its base "en".
+// It returns "" when nothing matches.
+func matchLocale(header string, byTag map[string]string) string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			ranges = append(ranges, languageRange{tag: tag, quality: quality})
+		}
+	}
+	// Stable so equally weighted ranges keep the caller's order
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		if locale, ok := byTag[r.tag]; ok {
+			return locale
+		}
+		if base, _, found := strings.Cut(r.tag, "-"); found {
+			if locale, ok := byTag[base]; ok {
+				return locale
+			}
+		}
+	}
+	return ""
+}
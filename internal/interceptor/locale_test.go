@@ -0,0 +1,66 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLocale(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	locale := Locale("en", []string{"en", "de", "pt-BR"})
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{name: "no metadata", want: "en"},
+		{name: "supported locale", md: metadata.Pairs("accept-language", "de"), want: "de"},
+		{name: "case insensitive", md: metadata.Pairs("accept-language", "PT-br"), want: "pt-BR"},
+		{name: "regional tag falls back to base", md: metadata.Pairs("accept-language", "de-AT"), want: "de"},
+		{name: "quality weights", md: metadata.Pairs("accept-language", "fr;q=0.9, en;q=0.5, de;q=0.8"), want: "de"},
+		{name: "equal weights keep order", md: metadata.Pairs("accept-language", "fr, pt-BR, de"), want: "pt-BR"},
+		{name: "zero weight excluded", md: metadata.Pairs("accept-language", "de;q=0, fr"), want: "en"},
+		{name: "unsupported falls back to default", md: metadata.Pairs("accept-language", "fr-FR, ja"), want: "en"},
+		{name: "malformed falls back to default", md: metadata.Pairs("accept-language", ";q=1,de;q=abc"), want: "en"},
+		{name: "forwarded by gateway", md: metadata.Pairs("grpcgateway-accept-language", "de-DE,en;q=0.8"), want: "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var got string
+			_, err := locale(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = LocaleFromContext(ctx)
+				return nil, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocaleStream(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServiceVersions", IsServerStream: true}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "de-AT"))
+
+	var got string
+	err := LocaleStream("en", []string{"en", "de"})(nil, &contextStream{ctx: ctx}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		got = LocaleFromContext(ss.Context())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "de", got)
+}
+
+func TestLocaleFromContext_NoInterceptor(t *testing.T) {
+	assert.Equal(t, "", LocaleFromContext(context.Background()))
+}
@@ -0,0 +1,71 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// payloadMarshaler renders messages with the field names used in the proto file, matching the redacted log fields
+var payloadMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// LogPayloads returns a gRPC interceptor that logs the request and response messages of unary RPCs,
+// with every field listed in REDACT_LOG_FIELDS masked (see logger.Redact). Payloads are only ever logged
+// at debug level, and are not even converted unless the logger has debug enabled.
+func LogPayloads() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		log := logger.Get()
+		if !log.Desugar().Core().Enabled(zapcore.DebugLevel) {
+			return handler(ctx, req)
+		}
+
+		log.Debugw("gRPC request payload", "method", info.FullMethod, "request", payloadFields(req))
+		resp, err := handler(ctx, req)
+		if err == nil {
+			log.Debugw("gRPC response payload", "method", info.FullMethod, "response", payloadFields(resp))
+		}
+		return resp, err
+	}
+}
+
+// payloadFields converts a message to its JSON fields with redacted values masked
+func payloadFields(msg interface{}) interface{} {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%T", msg)
+	}
+	data, err := payloadMarshaler.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("unloggable %T: %v", msg, err)
+	}
+	var fields interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Sprintf("unloggable %T: %v", msg, err)
+	}
+	return redactPayload("", fields)
+}
+
+// redactPayload masks the values of redacted fields at any depth; list items are redacted by their field's name
+func redactPayload(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, fieldValue := range v {
+			v[k] = redactPayload(k, fieldValue)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactPayload(key, item)
+		}
+		return v
+	default:
+		return logger.Redact(key, v)
+	}
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+func TestLogPayloads(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+	req := &v1.ListServicesRequest{SearchQuery: "payments", OrganizationId: "org-1"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &v1.ListServicesResponse{Services: []*v1.Service{{Id: "svc-2", Name: "Payment Gateway"}}, TotalCount: 1}, nil
+	}
+
+	observe := func(t *testing.T, level zapcore.Level) *observer.ObservedLogs {
+		core, logs := observer.New(level)
+		previous := logger.Get()
+		logger.SetLogger(zap.New(core).Sugar())
+		t.Cleanup(func() { logger.SetLogger(previous) })
+		return logs
+	}
+
+	t.Run("logged at debug with redaction", func(t *testing.T) {
+		logs := observe(t, zapcore.DebugLevel)
+		logger.SetRedactedFields([]string{"organization_id"})
+		t.Cleanup(func() { logger.SetRedactedFields(nil) })
+
+		_, err := LogPayloads()(context.Background(), req, info, handler)
+		require.NoError(t, err)
+
+		entries := logs.All()
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, zapcore.DebugLevel, entry.Level)
+			assert.Equal(t, info.FullMethod, entry.ContextMap()["method"])
+		}
+
+		request := entries[0].ContextMap()["request"].(map[string]interface{})
+		assert.Equal(t, "payments", request["search_query"])
+		assert.Equal(t, logger.Redact("organization_id", "org-1"), request["organization_id"])
+		assert.NotEqual(t, "org-1", request["organization_id"])
+
+		response := entries[1].ContextMap()["response"].(map[string]interface{})
+		services := response["services"].([]interface{})
+		assert.Equal(t, "Payment Gateway", services[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("nothing logged above debug", func(t *testing.T) {
+		logs := observe(t, zapcore.InfoLevel)
+
+		_, err := LogPayloads()(context.Background(), req, info, handler)
+		require.NoError(t, err)
+		assert.Zero(t, logs.Len())
+	})
+}
@@ -0,0 +1,106 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// ReadOnlyMode rejects mutating RPCs while enabled, leaving read paths untouched
+type ReadOnlyMode struct {
+	enabled atomic.Bool
+	// mutating holds the full gRPC method names of the RPCs that change catalog state
+	mutating map[string]bool
+}
+
+// readOnlyState is the JSON body used by the read-only admin endpoint
+type readOnlyState struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// NewReadOnlyMode creates a new read-only switch with the given initial state, rejecting the given full gRPC
+// method names while enabled
+func NewReadOnlyMode(enabled bool, mutatingMethods []string) *ReadOnlyMode {
+	r := &ReadOnlyMode{mutating: make(map[string]bool, len(mutatingMethods))}
+	for _, method := range mutatingMethods {
+		r.mutating[method] = true
+	}
+	r.enabled.Store(enabled)
+	return r
+}
+
+// Enabled reports whether read-only mode is currently on
+func (r *ReadOnlyMode) Enabled() bool {
+	return r.enabled.Load()
+}
+
+// SetEnabled flips read-only mode at runtime
+func (r *ReadOnlyMode) SetEnabled(enabled bool) {
+	if r.enabled.Swap(enabled) != enabled {
+		logger.Get().Infow("Read-only mode changed", "read_only", enabled)
+	}
+}
+
+// IsMutatingMethod reports whether a full gRPC method name refers to a state-changing RPC
+func (r *ReadOnlyMode) IsMutatingMethod(fullMethod string) bool {
+	return r.mutating[fullMethod]
+}
+
+// UnaryInterceptor returns a gRPC interceptor that fails mutating RPCs with FailedPrecondition while read-only
+func (r *ReadOnlyMode) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods
+func (r *ReadOnlyMode) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := r.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check returns the FailedPrecondition error rejecting a mutating method while read-only
+func (r *ReadOnlyMode) check(fullMethod string) error {
+	if r.Enabled() && r.IsMutatingMethod(fullMethod) {
+		logger.Get().Warnw("Rejected mutating request in read-only mode", "method", fullMethod)
+		return status.Errorf(codes.FailedPrecondition, "service is in read-only mode, %s is not allowed", fullMethod)
+	}
+	return nil
+}
+
+// ServeHTTP reports the current read-only state on GET and changes it on PUT or POST
+func (r *ReadOnlyMode) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var body readOnlyState
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		r.SetEnabled(body.ReadOnly)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(readOnlyState{ReadOnly: r.Enabled()}); err != nil {
+		logger.Get().Errorw("Failed to encode read-only state", "error", err)
+	}
+}
@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadOnlyMode_UnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		method   string
+		wantCode codes.Code
+	}{
+		{"create rejected in read-only mode", true, "/v1.CatalogService/CreateServices", codes.FailedPrecondition},
+		{"get allowed in read-only mode", true, "/v1.CatalogService/GetService", codes.OK},
+		{"list allowed in read-only mode", true, "/v1.CatalogService/ListServices", codes.OK},
+		{"unlisted method allowed whatever its name", true, "/v1.CatalogService/CreateWidget", codes.OK},
+		{"create allowed when disabled", false, "/v1.CatalogService/CreateServices", codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewReadOnlyMode(tt.enabled, []string{"/v1.CatalogService/CreateServices"}).UnaryInterceptor()
+			resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "ok", resp)
+			}
+		})
+	}
+}
+
+func TestReadOnlyMode_StreamInterceptor(t *testing.T) {
+	readOnly := NewReadOnlyMode(true, []string{"/v1.CatalogService/ImportServices"})
+	handled := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+
+	err := readOnly.StreamInterceptor()(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/ImportServices", IsClientStream: true}, handler)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.False(t, handled)
+
+	err = readOnly.StreamInterceptor()(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServiceVersions", IsServerStream: true}, handler)
+	assert.NoError(t, err)
+	assert.True(t, handled)
+}
+
+func TestReadOnlyMode_ServeHTTP(t *testing.T) {
+	mode := NewReadOnlyMode(false, nil)
+
+	// enable at runtime
+	rec := httptest.NewRecorder()
+	mode.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/read-only", strings.NewReader(`{"read_only":true}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"read_only":true}`, rec.Body.String())
+	assert.True(t, mode.Enabled())
+
+	// read current state
+	rec = httptest.NewRecorder()
+	mode.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/read-only", nil))
+	assert.JSONEq(t, `{"read_only":true}`, rec.Body.String())
+
+	// invalid body leaves state unchanged
+	rec = httptest.NewRecorder()
+	mode.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/read-only", strings.NewReader(`nope`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.True(t, mode.Enabled())
+
+	rec = httptest.NewRecorder()
+	mode.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/read-only", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
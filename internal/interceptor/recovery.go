@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// panicsTotal counts panics recovered from gRPC handlers
+var panicsTotal atomic.Int64
+
+// PanicsTotal returns the number of panics recovered from gRPC handlers since startup
+func PanicsTotal() int64 {
+	return panicsTotal.Load()
+}
+
+// Recovery returns a gRPC interceptor that turns a panicking handler into a codes.Internal error instead of
+// crashing the process. The panic is logged with its stack trace and counted in panics_total.
+// It should be the first interceptor in the chain so it also covers the interceptors after it.
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, recoveredError(info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStream is Recovery for streaming methods
+func RecoveryStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredError(info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// recoveredError logs and counts a recovered panic and returns the error sent to the client
+func recoveredError(method string, r interface{}) error {
+	panicsTotal.Add(1)
+	logger.NewMetricsLogger().LogCounter("panics_total", 1, map[string]string{"method": method})
+	logger.Get().Errorw("Recovered from panic in gRPC handler",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()))
+
+	// Do not leak panic details to clients
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,63 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecovery(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/ListServices"}
+
+	t.Run("converts a panic to Internal", func(t *testing.T) {
+		before := PanicsTotal()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			var services map[string]string
+			services["svc-1"] = "boom" // assignment to nil map panics
+			return "unreachable", nil
+		}
+
+		resp, err := Recovery()(context.Background(), nil, info, handler)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, err.Error(), "nil map")
+		assert.Equal(t, before+1, PanicsTotal())
+	})
+
+	t.Run("passes through normal responses", func(t *testing.T) {
+		before := PanicsTotal()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", status.Error(codes.NotFound, "not found")
+		}
+
+		resp, err := Recovery()(context.Background(), nil, info, handler)
+		assert.Equal(t, "ok", resp)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, before, PanicsTotal())
+	})
+}
+
+func TestRecoveryStream(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/StreamServiceVersions", IsServerStream: true}
+
+	before := PanicsTotal()
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		var versions []string
+		_ = versions[1] // index out of range panics
+		return nil
+	}
+
+	err := RecoveryStream()(nil, nil, info, handler)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, before+1, PanicsTotal())
+
+	err = RecoveryStream()(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.NotFound, "not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, before+1, PanicsTotal())
+}
@@ -0,0 +1,134 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/stats"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// ServerStats counts gRPC connections and RPCs as a grpc stats.Handler and serves them, with the uptime and
+// the process's goroutine and memory statistics, on the admin stats endpoint. HTTP API requests are counted
+// too since the gateway forwards them over its own gRPC connection.
+type ServerStats struct {
+	started time.Time
+	now     func() time.Time
+
+	activeConns atomic.Int64
+	totalConns  atomic.Int64
+	inFlight    atomic.Int64
+	requests    atomic.Int64
+	failed      atomic.Int64
+}
+
+// serverStatsState is the JSON body served by the stats admin endpoint
+type serverStatsState struct {
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds float64   `json:"uptime_seconds"`
+	// Connections counts gRPC client connections, the HTTP gateway holding one of them
+	ActiveConnections int64 `json:"active_connections"`
+	TotalConnections  int64 `json:"total_connections"`
+	// Requests counts finished RPCs, failed ones returned an error
+	RequestsInFlight int64       `json:"requests_in_flight"`
+	RequestsTotal    int64       `json:"requests_total"`
+	RequestsFailed   int64       `json:"requests_failed"`
+	Goroutines       int         `json:"goroutines"`
+	Memory           memoryState `json:"memory"`
+}
+
+// memoryState is the subset of runtime.MemStats served by the stats admin endpoint
+type memoryState struct {
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	SysBytes       uint64 `json:"sys_bytes"`
+	NumGC          uint32 `json:"num_gc"`
+}
+
+// NewServerStats creates server statistics whose uptime starts now
+func NewServerStats() *ServerStats {
+	return newServerStats(time.Now)
+}
+
+// newServerStats creates server statistics reading the time from now
+func newServerStats(now func() time.Time) *ServerStats {
+	return &ServerStats{started: now(), now: now}
+}
+
+// TagRPC implements stats.Handler
+func (s *ServerStats) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC counts RPCs as they begin and end
+func (s *ServerStats) HandleRPC(_ context.Context, rs stats.RPCStats) {
+	switch st := rs.(type) {
+	case *stats.Begin:
+		s.inFlight.Add(1)
+	case *stats.End:
+		s.inFlight.Add(-1)
+		s.requests.Add(1)
+		if st.Error != nil {
+			s.failed.Add(1)
+		}
+	}
+}
+
+// TagConn implements stats.Handler
+func (s *ServerStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn counts connections as they open and close
+func (s *ServerStats) HandleConn(_ context.Context, cs stats.ConnStats) {
+	switch cs.(type) {
+	case *stats.ConnBegin:
+		s.activeConns.Add(1)
+		s.totalConns.Add(1)
+	case *stats.ConnEnd:
+		s.activeConns.Add(-1)
+	}
+}
+
+// state collects the current statistics
+func (s *ServerStats) state() serverStatsState {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return serverStatsState{
+		StartedAt:         s.started.UTC(),
+		UptimeSeconds:     s.now().Sub(s.started).Seconds(),
+		ActiveConnections: s.activeConns.Load(),
+		TotalConnections:  s.totalConns.Load(),
+		RequestsInFlight:  s.inFlight.Load(),
+		RequestsTotal:     s.requests.Load(),
+		RequestsFailed:    s.failed.Load(),
+		Goroutines:        runtime.NumGoroutine(),
+		Memory: memoryState{
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapInuseBytes: mem.HeapInuse,
+			SysBytes:       mem.Sys,
+			NumGC:          mem.NumGC,
+		},
+	}
+}
+
+// ServeHTTP reports the server statistics, for operators
+func (s *ServerStats) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(s.state()); err != nil {
+		logger.Get().Errorw("Failed to encode server stats", "error", err)
+	}
+}
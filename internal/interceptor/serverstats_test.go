@@ -0,0 +1,53 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/stats"
+)
+
+func TestServerStats(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	s := newServerStats(clock.Now)
+	ctx := context.Background()
+
+	// two connections open and one closes; three RPCs start, one fails and one is still running
+	s.HandleConn(ctx, &stats.ConnBegin{})
+	s.HandleConn(ctx, &stats.ConnBegin{})
+	s.HandleConn(ctx, &stats.ConnEnd{})
+	for i := 0; i < 3; i++ {
+		s.HandleRPC(ctx, &stats.Begin{})
+	}
+	s.HandleRPC(ctx, &stats.End{})
+	s.HandleRPC(ctx, &stats.End{Error: errors.New("not found")})
+	clock.Advance(90 * time.Second)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/stats", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var state serverStatsState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Equal(t, 90.0, state.UptimeSeconds)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), state.StartedAt)
+	assert.Equal(t, int64(1), state.ActiveConnections)
+	assert.Equal(t, int64(2), state.TotalConnections)
+	assert.Equal(t, int64(1), state.RequestsInFlight)
+	assert.Equal(t, int64(2), state.RequestsTotal)
+	assert.Equal(t, int64(1), state.RequestsFailed)
+	assert.Positive(t, state.Goroutines)
+	assert.Positive(t, state.Memory.SysBytes)
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/stats", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,18 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// contextStream is a server stream whose handlers see ctx, e.g. the stream context with values added
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the replaced stream context
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,137 @@
+package interceptor
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ResponseTimeoutHeader announces the server's limit on a streamed response in seconds, so clients know how long
+// they have to consume it
+const ResponseTimeoutHeader = "x-response-timeout"
+
+// FormatResponseTimeout formats a timeout for ResponseTimeoutHeader
+func FormatResponseTimeout(timeout time.Duration) string {
+	return strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64)
+}
+
+// StreamDeadline returns a gRPC stream interceptor that keeps slow consumers from pinning server resources:
+// each message must be taken by the transport within sendTimeout and the whole stream must end within
+// streamTimeout, otherwise the stream ends with DeadlineExceeded. Sends run on one goroutine per stream, so
+// a send blocked on a client that stopped reading is left behind: the handler returns DeadlineExceeded and
+// grpc tears the stream down, which ends the blocked send. Zero disables either limit, and the stream limit
+// is announced in the ResponseTimeoutHeader response header.
+func StreamDeadline(streamTimeout, sendTimeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if streamTimeout <= 0 && sendTimeout <= 0 {
+			return handler(srv, ss)
+		}
+
+		ctx, cancel := context.WithCancelCause(ss.Context())
+		defer cancel(nil)
+		if streamTimeout > 0 {
+			var cancelTimeout context.CancelFunc
+			ctx, cancelTimeout = context.WithTimeoutCause(ctx, streamTimeout,
+				status.Errorf(codes.DeadlineExceeded, "stream did not complete within %s", streamTimeout))
+			defer cancelTimeout()
+			// A stream that cannot send headers fails on its first message anyway
+			_ = ss.SetHeader(metadata.Pairs(ResponseTimeoutHeader, FormatResponseTimeout(streamTimeout)))
+		}
+
+		stream := &deadlineStream{ServerStream: ss, ctx: ctx, sendTimeout: sendTimeout}
+		if sendTimeout > 0 {
+			// One watchdog for the whole stream, armed only while a send is in progress
+			stream.watchdog = time.AfterFunc(sendTimeout, func() {
+				cancel(status.Errorf(codes.DeadlineExceeded, "client did not receive a message within %s", sendTimeout))
+			})
+			stream.watchdog.Stop()
+			defer stream.watchdog.Stop()
+		}
+		defer stream.close()
+
+		err := handler(srv, stream)
+		if err == nil && stream.failed != nil {
+			// Ending the RPC with an error is what unblocks the send left behind
+			return stream.failed
+		}
+		return err
+	}
+}
+
+// deadlineStream ends its context, and fails its sends, when a SendMsg or the whole stream takes too long
+type deadlineStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	sendTimeout time.Duration
+	// watchdog cancels ctx when a send runs past sendTimeout, nil without a send timeout
+	watchdog *time.Timer
+
+	// sends feeds the goroutine sending messages, started by the first SendMsg, which answers on results
+	sends   chan interface{}
+	results chan error
+	// failed is the error returned by the SendMsg that gave up on a send still in progress
+	failed error
+}
+
+// Context returns the stream context, ending at the stream deadline or when a send times out
+func (s *deadlineStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends m, giving up once the stream context ends while the client is not reading
+func (s *deadlineStream) SendMsg(m interface{}) error {
+	if s.failed != nil {
+		return s.failed
+	}
+	if s.ctx.Err() != nil {
+		return s.contextError()
+	}
+
+	if s.sends == nil {
+		s.sends = make(chan interface{})
+		s.results = make(chan error, 1)
+		go s.sendLoop()
+	}
+	if s.watchdog != nil {
+		s.watchdog.Reset(s.sendTimeout)
+		defer s.watchdog.Stop()
+	}
+
+	s.sends <- m
+	select {
+	case err := <-s.results:
+		return err
+	case <-s.ctx.Done():
+		s.failed = s.contextError()
+		return s.failed
+	}
+}
+
+// sendLoop sends the messages of SendMsg until the stream ends
+func (s *deadlineStream) sendLoop() {
+	for m := range s.sends {
+		s.results <- s.ServerStream.SendMsg(m)
+	}
+}
+
+// close stops the send goroutine once its last send returns
+func (s *deadlineStream) close() {
+	if s.sends != nil {
+		close(s.sends)
+	}
+}
+
+// contextError returns the status ending the stream: the timeout that cancelled the stream context, or the
+// cancellation of the RPC itself
+func (s *deadlineStream) contextError() error {
+	cause := context.Cause(s.ctx)
+	if _, ok := status.FromError(cause); ok {
+		return cause
+	}
+	return status.FromContextError(cause).Err()
+}
@@ -0,0 +1,193 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// streamTestServer serves every method as a server stream sending 32 KiB messages: "/test.Stream/Flood" until
+// a send fails, "/test.Stream/Five" five times. The error each handler returned is sent on handled.
+type streamTestServer struct {
+	conn    *grpc.ClientConn
+	handled chan error
+}
+
+func newStreamTestServer(t *testing.T, interceptors ...grpc.StreamServerInterceptor) *streamTestServer {
+	s := &streamTestServer{handled: make(chan error, 10)}
+	message := wrapperspb.Bytes(make([]byte, 32<<10))
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		err := func() error {
+			for i := 0; !strings.HasSuffix(method, "/Five") || i < 5; i++ {
+				if err := stream.SendMsg(message); err != nil {
+					return err
+				}
+			}
+			return nil
+		}()
+		s.handled <- err
+		return err
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainStreamInterceptor(interceptors...), grpc.UnknownServiceHandler(handler))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	// A fixed window keeps the client from buffering what it does not read
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithInitialWindowSize(64<<10),
+		grpc.WithInitialConnWindowSize(64<<10))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	s.conn = conn
+	return s
+}
+
+// open starts a stream of method, without reading from it
+func (s *streamTestServer) open(t *testing.T, ctx context.Context, method string) grpc.ClientStream {
+	stream, err := s.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method)
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+	return stream
+}
+
+// receiveAll reads a stream to its end, returning the messages read and the final status
+func receiveAll(stream grpc.ClientStream) (int, error) {
+	received := 0
+	for {
+		if err := stream.RecvMsg(&wrapperspb.BytesValue{}); err != nil {
+			if errors.Is(err, io.EOF) {
+				return received, nil
+			}
+			return received, err
+		}
+		received++
+	}
+}
+
+// waitHandled returns the error of the next handler to return, failing the test after a few seconds
+func (s *streamTestServer) waitHandled(t *testing.T) error {
+	select {
+	case err := <-s.handled:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler still running")
+		return nil
+	}
+}
+
+func TestStreamDeadline(t *testing.T) {
+	t.Run("client not reading cut off after the send timeout", func(t *testing.T) {
+		limiter := NewConcurrencyLimiter(1, 0)
+		srv := newStreamTestServer(t, limiter.StreamInterceptor(), StreamDeadline(0, 100*time.Millisecond))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		start := time.Now()
+		stuck := srv.open(t, ctx, "/test.Stream/Flood")
+		err := srv.waitHandled(t)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Contains(t, err.Error(), "within 100ms")
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		// The stuck client still holds its stream, yet the handler's concurrency slot is free again
+		received, err := receiveAll(srv.open(t, ctx, "/test.Stream/Five"))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, received)
+		assert.NoError(t, srv.waitHandled(t))
+
+		_, err = receiveAll(stuck)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("client not reading cut off after the stream timeout", func(t *testing.T) {
+		srv := newStreamTestServer(t, StreamDeadline(200*time.Millisecond, 0))
+
+		stream := srv.open(t, context.Background(), "/test.Stream/Flood")
+		header, err := stream.Header()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0.2"}, header.Get(ResponseTimeoutHeader))
+
+		err = srv.waitHandled(t)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Contains(t, err.Error(), "stream did not complete within 200ms")
+	})
+
+	t.Run("client reading completes", func(t *testing.T) {
+		srv := newStreamTestServer(t, StreamDeadline(time.Minute, time.Second))
+
+		received, err := receiveAll(srv.open(t, context.Background(), "/test.Stream/Five"))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, received)
+		assert.NoError(t, srv.waitHandled(t))
+	})
+
+	t.Run("sends after a timeout fail at once", func(t *testing.T) {
+		var errs []error
+		done := make(chan struct{})
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			defer close(done)
+			for len(errs) == 0 {
+				if err := stream.SendMsg(wrapperspb.Bytes(make([]byte, 32<<10))); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			start := time.Now()
+			errs = append(errs, stream.SendMsg(wrapperspb.Bytes(nil)))
+			assert.Less(t, time.Since(start), 10*time.Millisecond)
+			return nil
+		}
+		srv := newStreamTestServer(t, StreamDeadline(0, 50*time.Millisecond), func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, _ grpc.StreamHandler) error {
+			return handler(srv, ss)
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream := srv.open(t, ctx, "/test.Stream/Flood")
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler still running")
+		}
+		_, err := receiveAll(stream)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "the handler's nil error is replaced")
+		if assert.Len(t, errs, 2) {
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(errs[0]))
+			assert.Equal(t, errs[0], errs[1])
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var wrapped bool
+		srv := newStreamTestServer(t, StreamDeadline(0, 0), func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			_, wrapped = ss.(*deadlineStream)
+			return handler(srv, ss)
+		})
+
+		stream := srv.open(t, context.Background(), "/test.Stream/Five")
+		received, err := receiveAll(stream)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, received)
+		assert.False(t, wrapped)
+		header, err := stream.Header()
+		assert.NoError(t, err)
+		assert.Empty(t, header.Get(ResponseTimeoutHeader))
+	})
+}
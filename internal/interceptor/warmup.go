@@ -0,0 +1,84 @@
+package interceptor
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+const (
+	// PhaseCold labels requests served within the warmup window after startup or a reload
+	PhaseCold = "cold"
+	// PhaseWarm labels every later request
+	PhaseWarm = "warm"
+)
+
+// Warmup labels requests as cold while they arrive within a window after startup or the last data reload,
+// and warm afterwards, so the latency of the two can be compared
+type Warmup struct {
+	window time.Duration
+	now    func() time.Time
+	// since is when the current window started, in Unix nanoseconds
+	since atomic.Int64
+}
+
+// NewWarmup starts a warmup window of the given length now; a window <= 0 labels every request warm
+func NewWarmup(window time.Duration) *Warmup {
+	return newWarmup(window, time.Now)
+}
+
+// newWarmup is NewWarmup reading the time from now
+func newWarmup(window time.Duration, now func() time.Time) *Warmup {
+	w := &Warmup{window: window, now: now}
+	w.Reset()
+	return w
+}
+
+// Reset starts a new warmup window, e.g. after the catalog was reloaded
+func (w *Warmup) Reset() {
+	w.since.Store(w.now().UnixNano())
+}
+
+// Phase returns PhaseCold while the warmup window is open, PhaseWarm afterwards
+func (w *Warmup) Phase() string {
+	if w.window > 0 && w.now().Sub(time.Unix(0, w.since.Load())) < w.window {
+		return PhaseCold
+	}
+	return PhaseWarm
+}
+
+// UnaryInterceptor returns a gRPC interceptor recording each request's latency labeled by method and phase,
+// and logging the requests served cold
+func (w *Warmup) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		phase := w.Phase()
+		start := w.now()
+		resp, err := handler(ctx, req)
+		w.observe(info.FullMethod, phase, w.now().Sub(start))
+		return resp, err
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods, timing each stream from start to end under
+// the phase it started in
+func (w *Warmup) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		phase := w.Phase()
+		start := w.now()
+		err := handler(srv, ss)
+		w.observe(info.FullMethod, phase, w.now().Sub(start))
+		return err
+	}
+}
+
+// observe records the latency of a request served in phase, logging it when cold
+func (w *Warmup) observe(method, phase string, duration time.Duration) {
+	logger.RequestPhaseLatency().WithLabelValues(method, phase).Observe(duration.Seconds())
+	if phase == PhaseCold {
+		logger.Get().Infow("Cold request served", "method", method, "phase", phase, "duration_ms", duration.Milliseconds())
+	}
+}
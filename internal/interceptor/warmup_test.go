@@ -0,0 +1,100 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// fakeClock is a settable time source for the warmup window
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestWarmup_Phase(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	w := newWarmup(30*time.Second, clock.Now)
+
+	assert.Equal(t, PhaseCold, w.Phase())
+
+	clock.Advance(29 * time.Second)
+	assert.Equal(t, PhaseCold, w.Phase())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, PhaseWarm, w.Phase())
+
+	// a reload opens a new window
+	w.Reset()
+	assert.Equal(t, PhaseCold, w.Phase())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, PhaseWarm, w.Phase())
+}
+
+func TestWarmup_Disabled(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	w := newWarmup(0, clock.Now)
+
+	assert.Equal(t, PhaseWarm, w.Phase())
+	w.Reset()
+	assert.Equal(t, PhaseWarm, w.Phase())
+}
+
+func TestWarmup_UnaryInterceptor(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	w := newWarmup(10*time.Second, clock.Now)
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.CatalogService/TestWarmup_UnaryInterceptor"}
+
+	// each request takes 2s on the fake clock
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		clock.Advance(2 * time.Second)
+		return "ok", nil
+	}
+
+	// starts at 0s, 2s, 4s, 6s, 8s are cold; 10s and 12s are warm
+	for i := 0; i < 7; i++ {
+		resp, err := w.UnaryInterceptor()(context.Background(), nil, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	}
+
+	cold := logger.RequestPhaseLatency().WithLabelValues(info.FullMethod, PhaseCold).Snapshot()
+	warm := logger.RequestPhaseLatency().WithLabelValues(info.FullMethod, PhaseWarm).Snapshot()
+	assert.Equal(t, uint64(5), cold.Count)
+	assert.Equal(t, uint64(2), warm.Count)
+	assert.InDelta(t, 10.0, cold.Sum, 1e-9)
+	assert.InDelta(t, 4.0, warm.Sum, 1e-9)
+
+	// after a reload the next request is cold again
+	w.Reset()
+	_, err := w.UnaryInterceptor()(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	cold = logger.RequestPhaseLatency().WithLabelValues(info.FullMethod, PhaseCold).Snapshot()
+	assert.Equal(t, uint64(6), cold.Count)
+}
+
+func TestWarmup_StreamInterceptor(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	w := newWarmup(10*time.Second, clock.Now)
+	info := &grpc.StreamServerInfo{FullMethod: "/v1.CatalogService/TestWarmup_StreamInterceptor", IsServerStream: true}
+
+	// the stream starts cold and runs past the window, it is timed whole under the phase it started in
+	err := w.StreamInterceptor()(nil, &contextStream{ctx: context.Background()}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		clock.Advance(15 * time.Second)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	cold := logger.RequestPhaseLatency().WithLabelValues(info.FullMethod, PhaseCold).Snapshot()
+	assert.Equal(t, uint64(1), cold.Count)
+	assert.InDelta(t, 15.0, cold.Sum, 1e-9)
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing count, safe for concurrent use
+type Counter struct {
+	value atomic.Int64
+}
+
+// Add increases the counter by n
+func (c *Counter) Add(n int64) {
+	c.value.Add(n)
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// CounterVec is a set of counters partitioned by label values
+type CounterVec struct {
+	mu       sync.RWMutex
+	counters map[string]*Counter
+}
+
+// NewCounterVec creates an empty counter vector
+func NewCounterVec() *CounterVec {
+	return &CounterVec{counters: make(map[string]*Counter)}
+}
+
+// WithLabelValues returns the counter for the given label values, creating it on first use
+func (v *CounterVec) WithLabelValues(values ...string) *Counter {
+	key := strings.Join(values, "\xff")
+
+	v.mu.RLock()
+	c, ok := v.counters[key]
+	v.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if c, ok := v.counters[key]; ok {
+		return c
+	}
+	c = &Counter{}
+	v.counters[key] = c
+	return c
+}
+
+// cacheHits, cacheMisses and coalescedRequests track shared read computations, labeled by method
+var (
+	cacheHits         = NewCounterVec()
+	cacheMisses       = NewCounterVec()
+	coalescedRequests = NewCounterVec()
+)
+
+// CacheHits counts requests served a response computed for another identical request, labeled by method
+func CacheHits() *CounterVec {
+	return cacheHits
+}
+
+// CacheMisses counts requests whose response was computed for them, labeled by method
+func CacheMisses() *CounterVec {
+	return cacheMisses
+}
+
+// CoalescedRequests counts computations shared by more than one request, labeled by method
+func CoalescedRequests() *CounterVec {
+	return coalescedRequests
+}
+
+// CacheHitRatio returns the share of a method's requests that were cache hits, 0 before any request
+func CacheHitRatio(method string) float64 {
+	hits := cacheHits.WithLabelValues(method).Value()
+	total := hits + cacheMisses.WithLabelValues(method).Value()
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterVec(t *testing.T) {
+	v := NewCounterVec()
+	v.WithLabelValues("GetService").Add(2)
+	v.WithLabelValues("GetService").Add(1)
+	v.WithLabelValues("ListServices").Add(1)
+
+	assert.Equal(t, int64(3), v.WithLabelValues("GetService").Value())
+	assert.Equal(t, int64(1), v.WithLabelValues("ListServices").Value())
+	assert.Equal(t, int64(0), v.WithLabelValues("GetServiceVersions").Value())
+}
+
+func TestCacheHitRatio(t *testing.T) {
+	const method = "TestCacheHitRatio"
+	assert.Equal(t, 0.0, CacheHitRatio(method))
+
+	CacheMisses().WithLabelValues(method).Add(1)
+	assert.Equal(t, 0.0, CacheHitRatio(method))
+
+	CacheHits().WithLabelValues(method).Add(3)
+	assert.Equal(t, 0.75, CacheHitRatio(method))
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MetricsContentType is the content type of the Prometheus text exposition format written by WriteMetrics
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WriteMetrics writes the request latency histograms in the Prometheus text exposition format
+func WriteMetrics(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	writeHistogramVec(bw, "catalog_request_duration_seconds",
+		"Request latency in seconds by method, status and organization.", requestLatency)
+	writeHistogramVec(bw, "catalog_request_phase_duration_seconds",
+		"Request latency in seconds by method and warmup phase.", requestPhaseLatency)
+	return bw.Flush()
+}
+
+// writeHistogramVec writes one histogram metric family, with a bucket series per upper bound plus the sum and count
+func writeHistogramVec(w *bufio.Writer, name, help string, v *HistogramVec) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	v.Each(func(values []string, s HistogramSnapshot) {
+		labels := formatLabels(v.LabelNames(), values)
+		for i, upper := range s.Buckets {
+			fmt.Fprintf(w, "%s_bucket{%sle=%q} %d\n", name, labels, formatFloat(upper), s.Counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, s.Counts[len(s.Counts)-1])
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, strings.TrimSuffix(labels, ","), formatFloat(s.Sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, strings.TrimSuffix(labels, ","), s.Count)
+	})
+}
+
+// formatLabels renders label pairs each followed by a comma, e.g. `method="GetService",`
+func formatLabels(names, values []string) string {
+	var b strings.Builder
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(labelValueEscaper.Replace(value))
+		b.WriteString(`",`)
+	}
+	return b.String()
+}
+
+// labelValueEscaper escapes label values as the text exposition format requires
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatFloat renders a sample value, spelling infinities the way the text exposition format does
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
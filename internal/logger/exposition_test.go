@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	RequestLatency().WithLabelValues("TestWriteMetrics", "OK", `org "1"`).Observe(0.003)
+	RequestLatency().WithLabelValues("TestWriteMetrics", "OK", `org "1"`).Observe(10)
+	RequestPhaseLatency().WithLabelValues("TestWriteMetrics", "cold").Observe(0.2)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetrics(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE catalog_request_duration_seconds histogram\n")
+	labels := `method="TestWriteMetrics",status="OK",organization="org \"1\""`
+	assert.Contains(t, out, `catalog_request_duration_seconds_bucket{`+labels+`,le="0.0025"} 0`+"\n")
+	assert.Contains(t, out, `catalog_request_duration_seconds_bucket{`+labels+`,le="0.005"} 1`+"\n")
+	assert.Contains(t, out, `catalog_request_duration_seconds_bucket{`+labels+`,le="5"} 1`+"\n")
+	assert.Contains(t, out, `catalog_request_duration_seconds_bucket{`+labels+`,le="+Inf"} 2`+"\n")
+	assert.Contains(t, out, `catalog_request_duration_seconds_sum{`+labels+`} 10.003`+"\n")
+	assert.Contains(t, out, `catalog_request_duration_seconds_count{`+labels+`} 2`+"\n")
+
+	assert.Contains(t, out, "# TYPE catalog_request_phase_duration_seconds histogram\n")
+	assert.Contains(t, out, `catalog_request_phase_duration_seconds_count{method="TestWriteMetrics",phase="cold"} 1`+"\n")
+}
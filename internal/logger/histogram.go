@@ -0,0 +1,191 @@
+package logger
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the histogram upper bounds, in seconds, used for request latency (1ms to 5s)
+var DefaultLatencyBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// Histogram counts observations into fixed buckets and tracks their count and sum
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64 // counts[i] holds observations <= buckets[i]; the last slot is the +Inf bucket
+	count   uint64
+	sum     float64
+}
+
+// HistogramSnapshot is a point-in-time copy of a histogram's state
+type HistogramSnapshot struct {
+	// Buckets are the bucket upper bounds, excluding +Inf
+	Buckets []float64
+
+	// Counts are the cumulative observation counts per bucket, with a trailing +Inf entry
+	Counts []uint64
+
+	// Count is the total number of observations
+	Count uint64
+
+	// Sum is the sum of all observed values
+	Sum float64
+}
+
+// NewHistogram creates a histogram with the given bucket upper bounds
+func NewHistogram(buckets []float64) *Histogram {
+	b := make([]float64, len(buckets))
+	copy(b, buckets)
+	sort.Float64s(b)
+
+	return &Histogram{
+		buckets: b,
+		counts:  make([]uint64, len(b)+1),
+	}
+}
+
+// Observe records a single value
+func (h *Histogram) Observe(value float64) {
+	// first bucket whose upper bound is >= value, or len(buckets) for +Inf
+	idx := sort.SearchFloat64s(h.buckets, value)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[idx]++
+	h.count++
+	h.sum += value
+}
+
+// Snapshot returns a copy of the histogram state with cumulative bucket counts
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts := make([]uint64, len(h.counts))
+	var cumulative uint64
+	for i, c := range h.counts {
+		cumulative += c
+		counts[i] = cumulative
+	}
+
+	buckets := make([]float64, len(h.buckets))
+	copy(buckets, h.buckets)
+
+	return HistogramSnapshot{
+		Buckets: buckets,
+		Counts:  counts,
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+}
+
+// Quantile estimates the q-th quantile (0 <= q <= 1) by linear interpolation within the matching bucket.
+// Observations in the +Inf bucket are reported as the largest finite bound.
+func (s HistogramSnapshot) Quantile(q float64) float64 {
+	if s.Count == 0 || len(s.Buckets) == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	q = math.Max(0, math.Min(1, q))
+
+	rank := q * float64(s.Count)
+	for i, upper := range s.Buckets {
+		if float64(s.Counts[i]) < rank {
+			continue
+		}
+
+		lower, prev := 0.0, uint64(0)
+		if i > 0 {
+			lower, prev = s.Buckets[i-1], s.Counts[i-1]
+		}
+		inBucket := s.Counts[i] - prev
+		if inBucket == 0 {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(prev))/float64(inBucket)
+	}
+
+	return s.Buckets[len(s.Buckets)-1]
+}
+
+// HistogramVec is a set of histograms sharing the same buckets, partitioned by label values
+type HistogramVec struct {
+	mu         sync.RWMutex
+	buckets    []float64
+	labelNames []string
+	histograms map[string]*Histogram
+}
+
+// NewHistogramVec creates a new histogram vector with the given bucket upper bounds, partitioned by the
+// named labels
+func NewHistogramVec(buckets []float64, labelNames ...string) *HistogramVec {
+	return &HistogramVec{
+		buckets:    buckets,
+		labelNames: labelNames,
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+// LabelNames returns the names of the labels the histograms are partitioned by
+func (v *HistogramVec) LabelNames() []string {
+	return v.labelNames
+}
+
+// Each calls fn with the label values and a snapshot of every histogram, sorted by label values
+func (v *HistogramVec) Each(fn func(values []string, snapshot HistogramSnapshot)) {
+	v.mu.RLock()
+	keys := make([]string, 0, len(v.histograms))
+	for key := range v.histograms {
+		keys = append(keys, key)
+	}
+	histograms := make(map[string]*Histogram, len(v.histograms))
+	for key, h := range v.histograms {
+		histograms[key] = h
+	}
+	v.mu.RUnlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		fn(strings.Split(key, "\xff"), histograms[key].Snapshot())
+	}
+}
+
+// WithLabelValues returns the histogram for the given label values, creating it on first use
+func (v *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	key := strings.Join(values, "\xff")
+
+	v.mu.RLock()
+	h, ok := v.histograms[key]
+	v.mu.RUnlock()
+	if ok {
+		return h
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if h, ok := v.histograms[key]; ok {
+		return h
+	}
+	h = NewHistogram(v.buckets)
+	v.histograms[key] = h
+	return h
+}
+
+// requestLatency tracks request durations in seconds, labeled by method, status and organization
+var requestLatency = NewHistogramVec(DefaultLatencyBuckets, "method", "status", "organization")
+
+// RequestLatency returns the request latency histogram, labeled by method, status and organization
+func RequestLatency() *HistogramVec {
+	return requestLatency
+}
+
+// requestPhaseLatency tracks request durations in seconds, labeled by method and warmup phase
+var requestPhaseLatency = NewHistogramVec(DefaultLatencyBuckets, "method", "phase")
+
+// RequestPhaseLatency returns the request latency histogram labeled by method and warmup phase ("cold" or "warm")
+func RequestPhaseLatency() *HistogramVec {
+	return requestPhaseLatency
+}
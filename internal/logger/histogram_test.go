@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram_Observe(t *testing.T) {
+	h := NewHistogram(DefaultLatencyBuckets)
+
+	durations := []time.Duration{
+		500 * time.Microsecond,
+		3 * time.Millisecond,
+		20 * time.Millisecond,
+		200 * time.Millisecond,
+		10 * time.Second,
+	}
+	var wantSum float64
+	for _, d := range durations {
+		h.Observe(d.Seconds())
+		wantSum += d.Seconds()
+	}
+
+	snap := h.Snapshot()
+	assert.Equal(t, uint64(len(durations)), snap.Count)
+	assert.InDelta(t, wantSum, snap.Sum, 1e-9)
+
+	// cumulative counts: 0.5ms falls in the first bucket, 10s only in +Inf
+	assert.Equal(t, uint64(1), snap.Counts[0])
+	assert.Equal(t, uint64(4), snap.Counts[len(snap.Counts)-2])
+	assert.Equal(t, uint64(5), snap.Counts[len(snap.Counts)-1])
+}
+
+func TestHistogramSnapshot_Quantile(t *testing.T) {
+	h := NewHistogram([]float64{1, 2, 4})
+	for i := 0; i < 10; i++ {
+		h.Observe(0.5)
+	}
+	for i := 0; i < 10; i++ {
+		h.Observe(3)
+	}
+
+	snap := h.Snapshot()
+	assert.InDelta(t, 1.0, snap.Quantile(0.5), 1e-9)
+	assert.InDelta(t, 3.8, snap.Quantile(0.95), 1e-9)
+	assert.True(t, math.IsNaN(NewHistogram([]float64{1}).Snapshot().Quantile(0.5)))
+}
+
+func TestRequestLogger_RecordsLatency(t *testing.T) {
+	rl := NewRequestLogger("TestRecordsLatency", "/test")
+	rl.LogResponse(0, nil)
+	rl.LogResponse(0, nil)
+	rl.LogResponse(5, nil)
+
+	ok := RequestLatency().WithLabelValues("TestRecordsLatency", "OK", AnonymousOrganization).Snapshot()
+	assert.Equal(t, uint64(2), ok.Count)
+	assert.True(t, ok.Sum >= 0)
+
+	notFound := RequestLatency().WithLabelValues("TestRecordsLatency", "NotFound", AnonymousOrganization).Snapshot()
+	assert.Equal(t, uint64(1), notFound.Count)
+}
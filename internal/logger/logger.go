@@ -1,12 +1,16 @@
 package logger
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -15,32 +19,41 @@ var (
 	mu           sync.RWMutex
 )
 
+// FileOutput writes logs to a file rotated by size, instead of stderr
+type FileOutput struct {
+	// Path of the log file; rotated files are kept beside it with a timestamp in their name
+	Path string
+	// MaxSizeMB is the size in megabytes at which the file is rotated
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept, 0 keeps all of them
+	MaxBackups int
+	// MaxAgeDays removes rotated files older than this many days, 0 keeps them regardless of age
+	MaxAgeDays int
+}
+
+// InitOption configures the global logger built by Init
+type InitOption func(*initOptions)
+
+type initOptions struct {
+	file *FileOutput
+}
+
+// WithFileOutput writes logs to a rotated file instead of stderr; an empty path keeps stderr
+func WithFileOutput(f FileOutput) InitOption {
+	return func(o *initOptions) {
+		if f.Path != "" {
+			o.file = &f
+		}
+	}
+}
+
 // Init initializes the global logger instance with proper error handling
-func Init(logLevel string) error {
+func Init(logLevel string, opts ...InitOption) error {
 	var err error
 	once.Do(func() {
-		config := zap.NewProductionConfig()
-
-		// Parse log level
-		level, err := zapcore.ParseLevel(logLevel)
-		if err != nil {
-			err = fmt.Errorf("invalid log level %s: %w", logLevel, err)
-			return
-		}
-		config.Level = zap.NewAtomicLevelAt(level)
-
-		// Configure structured logging
-		config.EncoderConfig.TimeKey = "timestamp"
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		config.EncoderConfig.LevelKey = "level"
-		config.EncoderConfig.MessageKey = "message"
-		config.EncoderConfig.CallerKey = "caller"
-		config.EncoderConfig.StacktraceKey = "stacktrace"
-
-		// Create logger
-		zapLogger, loggerErr := config.Build()
-		if loggerErr != nil {
-			err = fmt.Errorf("failed to create logger: %w", loggerErr)
+		zapLogger, buildErr := newLogger(logLevel, opts...)
+		if buildErr != nil {
+			err = buildErr
 			return
 		}
 
@@ -52,6 +65,54 @@ func Init(logLevel string) error {
 	return err
 }
 
+// newLogger builds a JSON logger at the given level writing to stderr or to the file of WithFileOutput
+func newLogger(logLevel string, opts ...InitOption) (*zap.Logger, error) {
+	var o initOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	config := zap.NewProductionConfig()
+
+	// Parse log level
+	level, err := zapcore.ParseLevel(logLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %s: %w", logLevel, err)
+	}
+	config.Level = zap.NewAtomicLevelAt(level)
+
+	// Configure structured logging
+	config.EncoderConfig.TimeKey = "timestamp"
+	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	config.EncoderConfig.LevelKey = "level"
+	config.EncoderConfig.MessageKey = "message"
+	config.EncoderConfig.CallerKey = "caller"
+	config.EncoderConfig.StacktraceKey = "stacktrace"
+
+	var buildOpts []zap.Option
+	if o.file != nil {
+		// Swap the stderr core for one writing to the rotated file, keeping the production sampling
+		rotated := zapcore.AddSync(&lumberjack.Logger{
+			Filename:   o.file.Path,
+			MaxSize:    o.file.MaxSizeMB,
+			MaxBackups: o.file.MaxBackups,
+			MaxAge:     o.file.MaxAgeDays,
+		})
+		fileCore := zapcore.NewCore(zapcore.NewJSONEncoder(config.EncoderConfig), rotated, config.Level)
+		if config.Sampling != nil {
+			fileCore = zapcore.NewSamplerWithOptions(fileCore, time.Second, config.Sampling.Initial, config.Sampling.Thereafter)
+		}
+		buildOpts = append(buildOpts, zap.WrapCore(func(zapcore.Core) zapcore.Core { return fileCore }))
+	}
+
+	// Create logger
+	zapLogger, err := config.Build(buildOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return zapLogger, nil
+}
+
 // Get returns the global logger instance with thread safety
 func Get() *zap.SugaredLogger {
 	mu.RLock()
@@ -87,18 +148,150 @@ func SetLogger(logger *zap.SugaredLogger) {
 	globalLogger = logger
 }
 
+// DefaultQuietMethods are probe and reflection methods/paths whose successful requests are not logged by default
+var DefaultQuietMethods = []string{
+	"/health",
+	"/healthz",
+	"/ready",
+	"/grpc.health.v1.Health/Check",
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+var (
+	quietMu      sync.RWMutex
+	quietMethods = toSet(DefaultQuietMethods)
+)
+
+// SetQuietMethods replaces the methods or paths whose successful requests are logged at debug level only.
+// Their latency is still recorded and their failures are still logged as errors.
+func SetQuietMethods(methods []string) {
+	quietMu.Lock()
+	defer quietMu.Unlock()
+	quietMethods = toSet(methods)
+}
+
+// isQuiet reports whether request logging is suppressed for the method or path
+func isQuiet(method, path string) bool {
+	quietMu.RLock()
+	defer quietMu.RUnlock()
+	return quietMethods[method] || quietMethods[path]
+}
+
+var (
+	redactMu       sync.RWMutex
+	redactedFields = map[string]bool{}
+)
+
+// SetRedactedFields replaces the log field names, e.g. "email" or "search_query", whose values are replaced
+// by a short hash before logging. Equal values keep hashing alike, so requests can still be correlated.
+func SetRedactedFields(fields []string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	redactedFields = toSet(fields)
+}
+
+// Redact returns value masked as "sha256:<hash prefix>" if key is a redacted field, otherwise value unchanged.
+// Empty values stay empty so it remains visible that a field was not set.
+func Redact(key string, value interface{}) interface{} {
+	redactMu.RLock()
+	redacted := redactedFields[key]
+	redactMu.RUnlock()
+	if !redacted {
+		return value
+	}
+
+	s := fmt.Sprint(value)
+	if value == nil || s == "" {
+		return value
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// Organization labels of request metrics for callers without an organization and for organizations
+// beyond the label limit
+const (
+	AnonymousOrganization = "anonymous"
+	OtherOrganization     = "other"
+)
+
+// MaxOrganizationLabels is how many distinct organizations get their own metrics label without an allowlist
+const MaxOrganizationLabels = 100
+
+var (
+	orgLabelsMu sync.Mutex
+	// orgAllowlist, when set, names the only organizations labeled in metrics
+	orgAllowlist map[string]bool
+	// orgLabeled are the organizations labeled so far without an allowlist
+	orgLabeled = map[string]bool{}
+)
+
+// SetMetricsOrganizations limits the organization label of request metrics to the given organizations,
+// others are counted as "other". Without an allowlist the first MaxOrganizationLabels organizations seen
+// are labeled, keeping the number of metric series bounded either way.
+func SetMetricsOrganizations(organizations []string) {
+	orgLabelsMu.Lock()
+	defer orgLabelsMu.Unlock()
+	orgAllowlist = nil
+	if len(organizations) > 0 {
+		orgAllowlist = toSet(organizations)
+	}
+	orgLabeled = map[string]bool{}
+}
+
+// OrganizationLabel returns the metrics label for a caller's organization
+func OrganizationLabel(organization string) string {
+	if organization == "" {
+		return AnonymousOrganization
+	}
+
+	orgLabelsMu.Lock()
+	defer orgLabelsMu.Unlock()
+	if orgAllowlist != nil {
+		if orgAllowlist[organization] {
+			return organization
+		}
+		return OtherOrganization
+	}
+	if !orgLabeled[organization] {
+		if len(orgLabeled) >= MaxOrganizationLabels {
+			return OtherOrganization
+		}
+		orgLabeled[organization] = true
+	}
+	return organization
+}
+
+// toSet builds a lookup set from a list of values
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
 // RequestLogger provides structured logging for HTTP/gRPC requests
 type RequestLogger struct {
 	logger *zap.SugaredLogger
+	method string
 	start  time.Time
 	fields map[string]interface{}
+	// organization is the metrics label of the caller's organization
+	organization string
+	// quiet demotes successful request logs to debug level for noisy methods such as probes
+	quiet bool
 }
 
 // NewRequestLogger creates a new request logger with tracing
 func NewRequestLogger(method, path string) *RequestLogger {
 	return &RequestLogger{
-		logger: Get(),
-		start:  time.Now(),
+		logger:       Get(),
+		method:       method,
+		start:        time.Now(),
+		organization: AnonymousOrganization,
+		quiet:        isQuiet(method, path),
 		fields: map[string]interface{}{
 			"method":   method,
 			"path":     path,
@@ -107,6 +300,40 @@ func NewRequestLogger(method, path string) *RequestLogger {
 	}
 }
 
+// TraceID returns the request's trace ID, generated or adopted from the caller
+func (rl *RequestLogger) TraceID() string {
+	id, _ := rl.fields["trace_id"].(string)
+	return id
+}
+
+// SetTraceID replaces the generated trace ID, e.g. with a request ID propagated by the caller
+func (rl *RequestLogger) SetTraceID(id string) {
+	rl.fields["trace_id"] = id
+}
+
+// Locale returns the locale recorded for the request, or "" when none was
+func (rl *RequestLogger) Locale() string {
+	locale, _ := rl.fields["locale"].(string)
+	return locale
+}
+
+// SetLocale records the locale chosen for the request
+func (rl *RequestLogger) SetLocale(locale string) {
+	rl.fields["locale"] = locale
+}
+
+// Organization returns the metrics label of the caller's organization, "anonymous" until one is recorded
+func (rl *RequestLogger) Organization() string {
+	return rl.organization
+}
+
+// SetOrganization records the caller's organization in the request log and, bounded by
+// OrganizationLabel, in the request metrics
+func (rl *RequestLogger) SetOrganization(organization string) {
+	rl.fields["organization"] = organization
+	rl.organization = OrganizationLabel(organization)
+}
+
 // AddField adds a field to the request log
 func (rl *RequestLogger) AddField(key string, value interface{}) {
 	rl.fields[key] = value
@@ -114,6 +341,10 @@ func (rl *RequestLogger) AddField(key string, value interface{}) {
 
 // LogRequest logs the start of a request
 func (rl *RequestLogger) LogRequest() {
+	if rl.quiet {
+		rl.logger.Debugw("Request started", rl.getFields()...)
+		return
+	}
 	rl.logger.Infow("Request started", rl.getFields()...)
 }
 
@@ -121,6 +352,9 @@ func (rl *RequestLogger) LogRequest() {
 func (rl *RequestLogger) LogResponse(statusCode int, err error) {
 	duration := time.Since(rl.start)
 
+	// Record latency in seconds, labeled by method, gRPC status name and organization
+	requestLatency.WithLabelValues(rl.method, codes.Code(statusCode).String(), rl.organization).Observe(duration.Seconds())
+
 	fields := rl.getFields()
 	fields = append(fields,
 		"status_code", statusCode,
@@ -131,16 +365,18 @@ func (rl *RequestLogger) LogResponse(statusCode int, err error) {
 	if err != nil {
 		fields = append(fields, "error", err.Error())
 		rl.logger.Errorw("Request failed", fields...)
+	} else if rl.quiet {
+		rl.logger.Debugw("Request completed", fields...)
 	} else {
 		rl.logger.Infow("Request completed", fields...)
 	}
 }
 
-// getFields converts the fields map to key-value pairs
+// getFields converts the fields map to key-value pairs, masking redacted fields
 func (rl *RequestLogger) getFields() []interface{} {
 	fields := make([]interface{}, 0, len(rl.fields)*2)
 	for k, v := range rl.fields {
-		fields = append(fields, k, v)
+		fields = append(fields, k, Redact(k, v))
 	}
 	return fields
 }
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLogger_RedactedFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := Get()
+	SetLogger(zap.New(core).Sugar())
+	SetRedactedFields([]string{"search_query", "organization_id"})
+	t.Cleanup(func() {
+		SetLogger(previous)
+		SetRedactedFields(nil)
+	})
+
+	rl := NewRequestLogger("ListServices", "/v1/services")
+	rl.AddField("search_query", "jane.doe@example.com")
+	rl.AddField("organization_id", "")
+	rl.AddField("sort_by", "name")
+	rl.LogRequest()
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, fields["search_query"])
+	assert.NotContains(t, fields["search_query"], "jane")
+	assert.Equal(t, "", fields["organization_id"], "empty values stay visible as empty")
+	assert.Equal(t, "name", fields["sort_by"])
+
+	// Equal values hash alike so requests can be correlated
+	assert.Equal(t, Redact("search_query", "jane.doe@example.com"), fields["search_query"])
+	assert.NotEqual(t, Redact("search_query", "john@example.com"), fields["search_query"])
+}
+
+func TestRedact_NotConfigured(t *testing.T) {
+	assert.Equal(t, "jane.doe@example.com", Redact("email", "jane.doe@example.com"))
+}
+
+func TestOrganizationLabel(t *testing.T) {
+	t.Cleanup(func() { SetMetricsOrganizations(nil) })
+
+	t.Run("allowlist", func(t *testing.T) {
+		SetMetricsOrganizations([]string{"org-1", "org-2"})
+		assert.Equal(t, "org-1", OrganizationLabel("org-1"))
+		assert.Equal(t, OtherOrganization, OrganizationLabel("org-3"))
+		assert.Equal(t, AnonymousOrganization, OrganizationLabel(""))
+	})
+
+	t.Run("first organizations seen without an allowlist", func(t *testing.T) {
+		SetMetricsOrganizations(nil)
+		for i := 0; i < MaxOrganizationLabels; i++ {
+			org := fmt.Sprintf("org-%d", i)
+			assert.Equal(t, org, OrganizationLabel(org))
+		}
+		assert.Equal(t, OtherOrganization, OrganizationLabel("org-new"))
+		assert.Equal(t, "org-0", OrganizationLabel("org-0"), "labeled organizations keep their label")
+	})
+}
+
+func TestRequestLogger_OrganizationLabel(t *testing.T) {
+	SetMetricsOrganizations([]string{"org-1"})
+	t.Cleanup(func() { SetMetricsOrganizations(nil) })
+
+	rl := NewRequestLogger("TestOrganizationLabel", "/test")
+	assert.Equal(t, AnonymousOrganization, rl.Organization())
+
+	rl.SetOrganization("org-1")
+	rl.LogResponse(0, nil)
+	assert.Equal(t, uint64(1), RequestLatency().WithLabelValues("TestOrganizationLabel", "OK", "org-1").Snapshot().Count)
+
+	rl = NewRequestLogger("TestOrganizationLabel", "/test")
+	rl.SetOrganization("org-9")
+	rl.LogResponse(0, nil)
+	assert.Equal(t, uint64(1), RequestLatency().WithLabelValues("TestOrganizationLabel", "OK", OtherOrganization).Snapshot().Count)
+}
+
+func TestNewLogger_FileRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "catalog.log")
+
+	zapLogger, err := newLogger("info", WithFileOutput(FileOutput{Path: path, MaxSizeMB: 1, MaxBackups: 2}))
+	require.NoError(t, err)
+
+	// ~3.5MB of distinct messages, so sampling keeps them all, rotates the 1MB file three times
+	payload := strings.Repeat("x", 1024)
+	for i := 0; i < 3500; i++ {
+		zapLogger.Info(fmt.Sprintf("entry %d", i), zap.String("payload", payload))
+	}
+	require.NoError(t, zapLogger.Sync())
+
+	// backups beyond MaxBackups are removed in the background
+	var backups []string
+	require.Eventually(t, func() bool {
+		backups, err = filepath.Glob(filepath.Join(dir, "catalog-*.log"))
+		return err == nil && len(backups) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+	for _, backup := range backups {
+		info, err := os.Stat(backup)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+		assert.Greater(t, info.Size(), int64(900*1024))
+	}
+
+	// the current file continues where the last backup stopped
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), `"message":"entry 3499"`)
+}
+
+func TestNewLogger_InvalidLevel(t *testing.T) {
+	_, err := newLogger("verbose")
+	assert.Error(t, err)
+}
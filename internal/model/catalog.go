@@ -1,7 +1,16 @@
 package model
 
 import (
-	"time"
+	"errors"
+	"fmt"
+)
+
+const (
+	// CurrentSchemaVersion is the services file schema version this release understands
+	CurrentSchemaVersion = 1
+
+	// MinSupportedSchemaVersion is the oldest services file schema version this release can load
+	MinSupportedSchemaVersion = 1
 )
 
 // Service represents a service in the catalog.
@@ -11,8 +20,8 @@ type Service struct {
 	Description    string            `yaml:"description"`
 	OrganizationID string            `yaml:"organization_id"`
 	URL            string            `yaml:"url"`
-	CreatedAt      time.Time         `yaml:"created_at"`
-	UpdatedAt      time.Time         `yaml:"updated_at"`
+	CreatedAt      Timestamp         `yaml:"created_at"`
+	UpdatedAt      Timestamp         `yaml:"updated_at"`
 	Versions       []*ServiceVersion `yaml:"versions"`
 }
 
@@ -23,18 +32,130 @@ type ServiceVersion struct {
 	ServiceID   string    `yaml:"service_id"`
 	Description string    `yaml:"description"`
 	IsActive    bool      `yaml:"is_active"`
-	CreatedAt   time.Time `yaml:"created_at"`
-	UpdatedAt   time.Time `yaml:"updated_at"`
+	CreatedAt   Timestamp `yaml:"created_at"`
+	UpdatedAt   Timestamp `yaml:"updated_at"`
 }
 
 // ServicesFile represents the structure of the services YAML file.
 type ServicesFile struct {
-	Services []*Service `yaml:"services"`
+	// SchemaVersion of the file, 0 (absent) is treated as CurrentSchemaVersion
+	SchemaVersion int        `yaml:"schema_version"`
+	Services      []*Service `yaml:"services"`
+	// Organizations gives organization IDs display names, organizations not listed are shown by ID
+	Organizations []*Organization `yaml:"organizations"`
+}
+
+// EffectiveSchemaVersion returns the declared schema version, defaulting to CurrentSchemaVersion when absent.
+func (f *ServicesFile) EffectiveSchemaVersion() int {
+	if f.SchemaVersion == 0 {
+		return CurrentSchemaVersion
+	}
+	return f.SchemaVersion
+}
+
+// CheckSchemaVersion returns an error if the file's schema version is outside the supported range.
+func (f *ServicesFile) CheckSchemaVersion() error {
+	version := f.EffectiveSchemaVersion()
+	if version < MinSupportedSchemaVersion {
+		return fmt.Errorf("services file schema_version %d is older than the minimum supported version %d: migrate the file to schema_version %d",
+			version, MinSupportedSchemaVersion, CurrentSchemaVersion)
+	}
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("services file schema_version %d is newer than the latest supported version %d: upgrade catalog-service or use a file written for schema_version %d",
+			version, CurrentSchemaVersion, CurrentSchemaVersion)
+	}
+	return nil
+}
+
+// CheckVersionIDs returns an error naming the first service with two versions sharing an ID
+func (f *ServicesFile) CheckVersionIDs() error {
+	for _, s := range f.Services {
+		if err := s.CheckVersionIDs(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+// CheckVersionIDs returns an error naming the service and the ID if two of its versions share an ID.
+// Versions without an ID are not compared; the same ID may be used by versions of different services.
+func (s *Service) CheckVersionIDs() error {
+	seen := make(map[string]bool, len(s.Versions))
+	for _, v := range s.Versions {
+		if v == nil || v.ID == "" {
+			continue
+		}
+		if seen[v.ID] {
+			return fmt.Errorf("service %q has more than one version with id %q", s.ID, v.ID)
+		}
+		seen[v.ID] = true
+	}
+	return nil
+}
+
+// ErrStoreFull is returned when adding services would exceed the store's size limit
+var ErrStoreFull = errors.New("service store is full")
+
 // Store is a simple in-memory store for services.
 type Store struct {
-	services []*Service
+	services      []*Service
+	organizations []*Organization
+	// maxServices is a hard cap on the number of services, 0 means unlimited
+	maxServices int
+	// shards locates the shard of each service ID, nil means a single shard, localShard is this node's shard
+	shards     ShardLocator
+	localShard int
+}
+
+// NewStore creates a store holding at most maxServices services, 0 means unlimited.
+// The cap is a guard against oversized imports: nothing is evicted, additions past it fail with ErrStoreFull.
+func NewStore(maxServices int) *Store {
+	return &Store{maxServices: maxServices}
+}
+
+// MaxServices returns the store's size limit, 0 means unlimited
+func (s *Store) MaxServices() int {
+	return s.maxServices
+}
+
+// CheckCapacity returns an error wrapping ErrStoreFull if count services exceed the size limit
+func (s *Store) CheckCapacity(count int) error {
+	if s.maxServices > 0 && count > s.maxServices {
+		return fmt.Errorf("%w: %d services exceed the limit of %d", ErrStoreFull, count, s.maxServices)
+	}
+	return nil
+}
+
+// SetSharding splits service IDs across the locator's shards and makes localShard the shard this store serves
+func (s *Store) SetSharding(shards ShardLocator, localShard int) {
+	s.shards = shards
+	s.localShard = localShard
+}
+
+// ShardOf returns the shard owning serviceID, always 0 for an unsharded store
+func (s *Store) ShardOf(serviceID string) int {
+	if s.shards == nil {
+		return 0
+	}
+	return s.shards.ShardOf(serviceID)
+}
+
+// LocalShard returns the shard this store serves
+func (s *Store) LocalShard() int {
+	return s.localShard
+}
+
+// ShardCount returns the number of shards service IDs are split across, 1 for an unsharded store
+func (s *Store) ShardCount() int {
+	if s.shards == nil {
+		return 1
+	}
+	return s.shards.ShardCount()
+}
+
+// OwnsService reports whether serviceID belongs to the local shard. An unsharded store owns every ID.
+func (s *Store) OwnsService(serviceID string) bool {
+	return s.ShardOf(serviceID) == s.localShard
 }
 
 // ListServices returns a list of all services in the store.
@@ -42,7 +163,37 @@ func (s *Store) ListServices() []*Service {
 	return s.services
 }
 
-// SetServices sets the services in the store
-func (s *Store) SetServices(services []*Service) {
+// SetServices sets the services in the store, leaving it unchanged if they exceed the size limit
+func (s *Store) SetServices(services []*Service) error {
+	if err := s.CheckCapacity(len(services)); err != nil {
+		return err
+	}
 	s.services = services
+	return nil
+}
+
+// ListOrganizations returns the organizations given display names
+func (s *Store) ListOrganizations() []*Organization {
+	return s.organizations
+}
+
+// SetOrganizations sets the organizations given display names
+func (s *Store) SetOrganizations(orgs []*Organization) {
+	s.organizations = orgs
+}
+
+// AddService adds a service to the store, replacing any service with the same ID.
+// Adding a new service to a full store fails with ErrStoreFull.
+func (s *Store) AddService(service *Service) error {
+	for i, existing := range s.services {
+		if existing.ID == service.ID {
+			s.services[i] = service
+			return nil
+		}
+	}
+	if err := s.CheckCapacity(len(s.services) + 1); err != nil {
+		return err
+	}
+	s.services = append(s.services, service)
+	return nil
 }
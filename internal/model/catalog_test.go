@@ -1,9 +1,12 @@
 package model
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -25,3 +28,283 @@ services:
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot parse")
 }
+
+func TestServicesFile_CheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		wantVersion int
+		wantErr     string
+	}{
+		{
+			name:        "absent version defaults to current",
+			yaml:        "services: []\n",
+			wantVersion: CurrentSchemaVersion,
+		},
+		{
+			name:        "current version",
+			yaml:        "schema_version: 1\nservices: []\n",
+			wantVersion: 1,
+		},
+		{
+			name:        "newer version rejected",
+			yaml:        "schema_version: 2\nservices: []\n",
+			wantVersion: 2,
+			wantErr:     "upgrade catalog-service",
+		},
+		{
+			name:        "older version rejected",
+			yaml:        "schema_version: -1\nservices: []\n",
+			wantVersion: -1,
+			wantErr:     "migrate the file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sf ServicesFile
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &sf))
+			assert.Equal(t, tt.wantVersion, sf.EffectiveSchemaVersion())
+
+			err := sf.CheckSchemaVersion()
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServicesFile_TimestampRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 8, 1, 9, 30, 15, 123456789, time.FixedZone("", 5*3600+30*60))
+	sf := ServicesFile{
+		SchemaVersion: CurrentSchemaVersion,
+		Services: []*Service{{
+			ID:        "svc-1",
+			CreatedAt: Timestamp{Time: ts},
+			UpdatedAt: Timestamp{Time: ts.Add(time.Nanosecond)},
+			Versions:  []*ServiceVersion{{ID: "ver-1", CreatedAt: Timestamp{Time: ts}, UpdatedAt: Timestamp{Time: ts}}},
+		}},
+	}
+
+	data, err := yaml.Marshal(&sf)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "2025-08-01T09:30:15.123456789+05:30")
+
+	var got ServicesFile
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	require.Len(t, got.Services, 1)
+	svc := got.Services[0]
+	// Compare the formatted value too, Equal alone would accept a changed offset
+	assert.True(t, ts.Equal(svc.CreatedAt.Time))
+	assert.Equal(t, ts.Format(time.RFC3339Nano), svc.CreatedAt.Format(time.RFC3339Nano))
+	assert.Equal(t, ts.Add(time.Nanosecond).Format(time.RFC3339Nano), svc.UpdatedAt.Format(time.RFC3339Nano))
+	assert.Equal(t, ts.Format(time.RFC3339Nano), svc.Versions[0].CreatedAt.Format(time.RFC3339Nano))
+}
+
+func TestTimestamp_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "quoted RFC 3339 with fraction and offset",
+			yaml: "created_at: \"2025-08-01T09:00:00.5+02:00\"\n",
+			want: time.Date(2025, 8, 1, 9, 0, 0, 500000000, time.FixedZone("", 2*3600)),
+		},
+		{
+			name: "unquoted RFC 3339",
+			yaml: "created_at: 2025-08-01T09:00:00Z\n",
+			want: time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "date only",
+			yaml: "created_at: 2025-08-01\n",
+			want: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "quoted date only",
+			yaml: "created_at: \"2025-08-01\"\n",
+			want: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "space separated without offset",
+			yaml: "created_at: 2025-08-01 09:00:00\n",
+			want: time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "absent",
+			yaml: "id: ver-1\nupdated_at:\n",
+		},
+		{
+			name:    "not a timestamp",
+			yaml:    "id: ver-1\ncreated_at: yesterday\n",
+			wantErr: `line 2: cannot parse "yesterday" as an RFC 3339 timestamp`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v ServiceVersion
+			err := yaml.Unmarshal([]byte(tt.yaml), &v)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Format(time.RFC3339Nano), v.CreatedAt.Format(time.RFC3339Nano))
+		})
+	}
+}
+
+func TestStore_MaxServices(t *testing.T) {
+	store := NewStore(2)
+
+	err := store.SetServices([]*Service{{ID: "svc-1"}, {ID: "svc-2"}, {ID: "svc-3"}})
+	assert.ErrorIs(t, err, ErrStoreFull)
+	assert.Empty(t, store.ListServices())
+
+	require.NoError(t, store.SetServices([]*Service{{ID: "svc-1"}}))
+	require.NoError(t, store.AddService(&Service{ID: "svc-2"}))
+
+	err = store.AddService(&Service{ID: "svc-3"})
+	assert.ErrorIs(t, err, ErrStoreFull)
+	assert.Contains(t, err.Error(), "3 services exceed the limit of 2")
+
+	// Replacing an existing service does not grow the store
+	require.NoError(t, store.AddService(&Service{ID: "svc-2", Name: "Payment Gateway"}))
+	assert.Len(t, store.ListServices(), 2)
+	assert.Equal(t, "Payment Gateway", store.ListServices()[1].Name)
+
+	unlimited := &Store{}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, unlimited.AddService(&Service{ID: fmt.Sprintf("svc-%d", i)}))
+	}
+}
+
+func TestHashRing_ShardOf(t *testing.T) {
+	t.Run("assignment is stable", func(t *testing.T) {
+		ring := NewHashRing(4)
+		want := map[string]int{"svc-1": 1, "svc-2": 2, "svc-3": 1, "svc-4": 2}
+		for id, shard := range want {
+			assert.Equal(t, shard, ring.ShardOf(id), id)
+			// A ring built elsewhere with the same shard count agrees
+			assert.Equal(t, shard, NewHashRing(4).ShardOf(id), id)
+		}
+	})
+
+	t.Run("single shard owns everything", func(t *testing.T) {
+		for _, count := range []int{0, 1} {
+			ring := NewHashRing(count)
+			assert.Equal(t, 1, ring.ShardCount())
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, 0, ring.ShardOf(fmt.Sprintf("svc-%d", i)))
+			}
+		}
+	})
+
+	t.Run("adding a shard moves a minority of IDs", func(t *testing.T) {
+		four, five := NewHashRing(4), NewHashRing(5)
+		moved := 0
+		for i := 0; i < 1000; i++ {
+			id := fmt.Sprintf("svc-%d", i)
+			if four.ShardOf(id) != five.ShardOf(id) {
+				moved++
+				// IDs only move to the new shard
+				assert.Equal(t, 4, five.ShardOf(id), id)
+			}
+		}
+		assert.Less(t, moved, 350)
+	})
+}
+
+func TestStore_Sharding(t *testing.T) {
+	unsharded := NewStore(0)
+	assert.Equal(t, 1, unsharded.ShardCount())
+	assert.True(t, unsharded.OwnsService("svc-1"))
+	assert.True(t, unsharded.OwnsService("svc-2"))
+
+	store := NewStore(0)
+	store.SetSharding(NewHashRing(4), 1)
+	assert.Equal(t, 4, store.ShardCount())
+	assert.Equal(t, 1, store.LocalShard())
+	assert.True(t, store.OwnsService("svc-1"))
+	assert.False(t, store.OwnsService("svc-2"))
+	assert.Equal(t, 2, store.ShardOf("svc-2"))
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		httpsOnly bool
+		wantErr   string
+	}{
+		{url: "", httpsOnly: true},
+		{url: "https://catalog.example.com/services/svc-1", httpsOnly: true},
+		{url: "http://catalog.example.com", httpsOnly: false},
+		{url: "http://catalog.example.com", httpsOnly: true, wantErr: `must use https, got "http"`},
+		{url: "HTTP://catalog.example.com", httpsOnly: true, wantErr: "must use https"},
+		{url: "catalog.example.com/svc-1", httpsOnly: false, wantErr: "must be absolute"},
+		{url: "https://", httpsOnly: true, wantErr: "must be absolute"},
+		{url: "https://catalog.example.com/%zz", httpsOnly: true, wantErr: "is not a valid URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckURL(tt.url, tt.httpsOnly)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServicesFile_CheckVersionIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []*Service
+		wantErr  string
+	}{
+		{
+			name: "unique within each service",
+			services: []*Service{
+				{ID: "svc-1", Versions: []*ServiceVersion{{ID: "v1"}, {ID: "v2"}}},
+				{ID: "svc-2", Versions: []*ServiceVersion{{ID: "v1"}}},
+			},
+		},
+		{
+			name:     "versions without an ID",
+			services: []*Service{{ID: "svc-1", Versions: []*ServiceVersion{{Version: "v1.0.0"}, {Version: "v2.0.0"}}}},
+		},
+		{
+			name: "duplicate within a service",
+			services: []*Service{
+				{ID: "svc-1", Versions: []*ServiceVersion{{ID: "v1"}}},
+				{ID: "svc-2", Versions: []*ServiceVersion{{ID: "v1"}, {ID: "v2"}, {ID: "v1"}}},
+			},
+			wantErr: `service "svc-2" has more than one version with id "v1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ServicesFile{Services: tt.services}).CheckVersionIDs()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NameNormalization is how whitespace in service names and descriptions is normalized when services are
+// loaded or added
+type NameNormalization string
+
+const (
+	// NameNormalizationTrim removes leading and trailing whitespace from names and descriptions
+	NameNormalizationTrim NameNormalization = "trim"
+	// NameNormalizationCollapse trims like NameNormalizationTrim and also replaces each run of whitespace
+	// inside a name with a single space; descriptions keep their internal whitespace
+	NameNormalizationCollapse NameNormalization = "collapse"
+	// NameNormalizationNone keeps names and descriptions as written
+	NameNormalizationNone NameNormalization = "none"
+)
+
+// Normalize applies the normalization to every service of the file
+func (f *ServicesFile) Normalize(n NameNormalization) {
+	for _, s := range f.Services {
+		if s != nil {
+			s.Normalize(n)
+		}
+	}
+}
+
+// Normalize applies the normalization to the service's name and description, "" behaves like
+// NameNormalizationTrim. Versions are left unchanged.
+func (s *Service) Normalize(n NameNormalization) {
+	if n == NameNormalizationNone {
+		return
+	}
+	s.Name = strings.TrimSpace(s.Name)
+	s.Description = strings.TrimSpace(s.Description)
+	if n == NameNormalizationCollapse {
+		s.Name = strings.Join(strings.Fields(s.Name), " ")
+	}
+}
+
+// CheckNames returns an error naming the first service without a name
+func (f *ServicesFile) CheckNames() error {
+	for _, s := range f.Services {
+		if err := s.CheckName(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckName returns an error naming the service if its name is empty. Names are checked after
+// normalization, so with trimming a whitespace-only name counts as empty.
+func (s *Service) CheckName() error {
+	if s.Name == "" {
+		return fmt.Errorf("service %q has no name", s.ID)
+	}
+	return nil
+}
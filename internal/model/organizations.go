@@ -0,0 +1,67 @@
+package model
+
+import "fmt"
+
+// Organization gives an organization ID a display name for UIs
+type Organization struct {
+	ID          string `yaml:"id"`
+	DisplayName string `yaml:"display_name"`
+}
+
+// CheckOrganizations returns an error naming the first organization without an ID or listed more than once
+func (f *ServicesFile) CheckOrganizations() error {
+	seen := make(map[string]bool, len(f.Organizations))
+	for i, o := range f.Organizations {
+		if o == nil || o.ID == "" {
+			return fmt.Errorf("organization %d has no id", i+1)
+		}
+		if seen[o.ID] {
+			return fmt.Errorf("organization %q is listed more than once", o.ID)
+		}
+		seen[o.ID] = true
+	}
+	return nil
+}
+
+// OrganizationNames maps the IDs of organizations with a display name to that name
+func OrganizationNames(orgs []*Organization) map[string]string {
+	names := make(map[string]string, len(orgs))
+	for _, o := range orgs {
+		if o != nil && o.DisplayName != "" {
+			names[o.ID] = o.DisplayName
+		}
+	}
+	return names
+}
+
+// OrganizationIDs returns the set of listed organization IDs
+func OrganizationIDs(orgs []*Organization) map[string]bool {
+	ids := make(map[string]bool, len(orgs))
+	for _, o := range orgs {
+		if o != nil && o.ID != "" {
+			ids[o.ID] = true
+		}
+	}
+	return ids
+}
+
+// CheckKnownOrganizations returns an error naming the first service whose organization is not listed in
+// organizations. Services without an organization pass, and so does every service when none are listed.
+func (f *ServicesFile) CheckKnownOrganizations() error {
+	known := OrganizationIDs(f.Organizations)
+	for _, s := range f.Services {
+		if err := s.CheckKnownOrganization(known); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckKnownOrganization returns an error naming the service if it has an organization missing from known.
+// An empty known set accepts every organization.
+func (s *Service) CheckKnownOrganization(known map[string]bool) error {
+	if len(known) == 0 || s.OrganizationID == "" || known[s.OrganizationID] {
+		return nil
+	}
+	return fmt.Errorf("service %q belongs to unknown organization %q", s.ID, s.OrganizationID)
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// virtualNodesPerShard is how many points each shard places on the hash ring. More points spread service IDs
+// more evenly across shards at the cost of a larger ring.
+const virtualNodesPerShard = 128
+
+// ShardLocator reports which shard a service ID belongs to
+type ShardLocator interface {
+	// ShardOf returns the shard owning serviceID, in [0, ShardCount())
+	ShardOf(serviceID string) int
+	// ShardCount returns the number of shards
+	ShardCount() int
+}
+
+// HashRing assigns service IDs to shards by consistent hashing, so changing the shard count only moves
+// the IDs claimed by the added or removed shards rather than reshuffling the whole catalog
+type HashRing struct {
+	shardCount int
+	// points are the sorted ring positions, owners[i] is the shard that placed points[i]
+	points []uint64
+	owners []int
+}
+
+// NewHashRing creates a hash ring over shardCount shards, values below 1 mean a single shard
+func NewHashRing(shardCount int) *HashRing {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	type point struct {
+		hash  uint64
+		shard int
+	}
+	ring := make([]point, 0, shardCount*virtualNodesPerShard)
+	for shard := 0; shard < shardCount; shard++ {
+		for i := 0; i < virtualNodesPerShard; i++ {
+			ring = append(ring, point{hash: hashKey(fmt.Sprintf("shard-%d-%d", shard, i)), shard: shard})
+		}
+	}
+	// Ties between points are broken by shard so the ring is the same on every node
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash != ring[j].hash {
+			return ring[i].hash < ring[j].hash
+		}
+		return ring[i].shard < ring[j].shard
+	})
+
+	r := &HashRing{
+		shardCount: shardCount,
+		points:     make([]uint64, len(ring)),
+		owners:     make([]int, len(ring)),
+	}
+	for i, p := range ring {
+		r.points[i] = p.hash
+		r.owners[i] = p.shard
+	}
+	return r
+}
+
+// ShardOf returns the shard owning serviceID: the owner of the first ring point at or after the ID's hash
+func (r *HashRing) ShardOf(serviceID string) int {
+	if r.shardCount == 1 {
+		return 0
+	}
+	h := hashKey(serviceID)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		// Wrap around to the start of the ring
+		i = 0
+	}
+	return r.owners[i]
+}
+
+// ShardCount returns the number of shards on the ring
+func (r *HashRing) ShardCount() int {
+	return r.shardCount
+}
+
+// hashKey places a key on the ring. FNV-1a alone clusters similar keys such as "svc-1" and "svc-2",
+// so its result is passed through the MurmurHash3 finalizer to spread them around the ring.
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
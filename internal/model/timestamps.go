@@ -0,0 +1,92 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// timestampLayouts are the accepted timestamp formats, tried in order: RFC 3339 with optional fractional
+// seconds, then the looser forms YAML allows for unquoted timestamps, so a quoted value loads like an unquoted one
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-1-2T15:4:5.999999999Z07:00",
+	"2006-1-2t15:4:5.999999999Z07:00",
+	"2006-1-2 15:4:5.999999999",
+	"2006-1-2",
+}
+
+// Timestamp is a time read from and written to the services file. It is written with time.RFC3339Nano, keeping
+// nanoseconds and the UTC offset, so an exported file loads back unchanged. Values without an offset,
+// including date-only ones, are UTC.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalYAML writes the timestamp as an unquoted RFC 3339 timestamp with nanoseconds and the offset
+func (t Timestamp) MarshalYAML() (interface{}, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: t.Format(time.RFC3339Nano)}, nil
+}
+
+// UnmarshalYAML reads a timestamp in any of timestampLayouts, quoted or not, naming the line of any other value
+func (t *Timestamp) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		for _, layout := range timestampLayouts {
+			if parsed, err := time.Parse(layout, node.Value); err == nil {
+				t.Time = parsed
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("line %d: cannot parse %q as an RFC 3339 timestamp (e.g. 2025-08-01T09:00:00.123456789+02:00)",
+		node.Line, node.Value)
+}
+
+// FutureTimestampPolicy is how timestamps later than the current time are handled when loading data
+type FutureTimestampPolicy string
+
+const (
+	// FutureTimestampsIgnore accepts future timestamps
+	FutureTimestampsIgnore FutureTimestampPolicy = "ignore"
+	// FutureTimestampsWarn accepts future timestamps and logs a warning
+	FutureTimestampsWarn FutureTimestampPolicy = "warn"
+	// FutureTimestampsReject fails the load on a future timestamp
+	FutureTimestampsReject FutureTimestampPolicy = "reject"
+)
+
+// CheckFutureTimestamps returns an error naming the first service or version with a created_at or
+// updated_at after limit, usually now plus a tolerance for clock drift
+func (f *ServicesFile) CheckFutureTimestamps(limit time.Time) error {
+	for _, s := range f.Services {
+		if err := s.CheckFutureTimestamps(limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckFutureTimestamps returns an error naming the service or its first version with a created_at or
+// updated_at after limit
+func (s *Service) CheckFutureTimestamps(limit time.Time) error {
+	if err := checkNotAfter(limit, s.CreatedAt.Time, s.UpdatedAt.Time); err != nil {
+		return fmt.Errorf("service %q %w", s.ID, err)
+	}
+	for _, v := range s.Versions {
+		if err := checkNotAfter(limit, v.CreatedAt.Time, v.UpdatedAt.Time); err != nil {
+			return fmt.Errorf("version %q of service %q %w", v.ID, s.ID, err)
+		}
+	}
+	return nil
+}
+
+// checkNotAfter reports which of createdAt and updatedAt is after limit
+func checkNotAfter(limit, createdAt, updatedAt time.Time) error {
+	if createdAt.After(limit) {
+		return fmt.Errorf("created_at %s is in the future", createdAt.Format(time.RFC3339Nano))
+	}
+	if updatedAt.After(limit) {
+		return fmt.Errorf("updated_at %s is in the future", updatedAt.Format(time.RFC3339Nano))
+	}
+	return nil
+}
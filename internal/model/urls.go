@@ -0,0 +1,44 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// CheckURL returns an error if rawURL is not an absolute URL with a host, or, when httpsOnly is set,
+// if its scheme is anything other than https. An empty URL is valid since the field is optional.
+func CheckURL(rawURL string, httpsOnly bool) error {
+	if rawURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("url %q is not a valid URL: %w", rawURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("url %q must be absolute, e.g. https://catalog.example.com/services/svc-1", rawURL)
+	}
+	if httpsOnly && u.Scheme != "https" {
+		return fmt.Errorf("url %q must use https, got %q", rawURL, u.Scheme)
+	}
+	return nil
+}
+
+// CheckHTTPSURLs returns an error naming the first service whose URL is not an absolute https URL
+func (f *ServicesFile) CheckHTTPSURLs() error {
+	for _, s := range f.Services {
+		if err := s.CheckHTTPSURL(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckHTTPSURL returns an error naming the service if its URL is set and is not an absolute https URL
+func (s *Service) CheckHTTPSURL() error {
+	if err := CheckURL(s.URL, true); err != nil {
+		return fmt.Errorf("service %q %w", s.ID, err)
+	}
+	return nil
+}
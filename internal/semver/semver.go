@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned when a string is not a valid semantic version
+var ErrInvalidVersion = errors.New("invalid semantic version")
+
+// Version represents a parsed semantic version (https://semver.org)
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+	Build      string
+}
+
+// Parse parses a semantic version such as "v1.2.3", "1.2.3-rc.1" or "1.2.3+build.5".
+// The leading "v" is optional.
+func Parse(s string) (Version, error) {
+	var v Version
+
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if raw == "" {
+		return v, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	// split off build metadata, then pre-release
+	if i := strings.Index(raw, "+"); i >= 0 {
+		v.Build = raw[i+1:]
+		raw = raw[:i]
+		if v.Build == "" {
+			return Version{}, fmt.Errorf("%w: %q has empty build metadata", ErrInvalidVersion, s)
+		}
+	}
+	if i := strings.Index(raw, "-"); i >= 0 {
+		v.PreRelease = raw[i+1:]
+		raw = raw[:i]
+		if v.PreRelease == "" {
+			return Version{}, fmt.Errorf("%w: %q has empty pre-release", ErrInvalidVersion, s)
+		}
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w: %q must have major.minor.patch", ErrInvalidVersion, s)
+	}
+
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return Version{}, fmt.Errorf("%w: %q has invalid numeric component %q", ErrInvalidVersion, s, p)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is lower than, equal to or greater than other.
+// Build metadata is ignored and a pre-release sorts before its release, per the semver spec.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// String returns the canonical form of the version, without a leading "v"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// comparePreRelease compares dot-separated pre-release identifiers
+func comparePreRelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInt(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			// numeric identifiers have lower precedence than alphanumeric ones
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// compareInt returns -1, 0 or 1 comparing two ints
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
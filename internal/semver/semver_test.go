@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{"with v prefix", "v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}, false},
+		{"without prefix", "0.1.0", Version{Major: 0, Minor: 1, Patch: 0}, false},
+		{"pre-release", "v2.0.0-rc.1", Version{Major: 2, PreRelease: "rc.1"}, false},
+		{"build metadata", "1.0.0+build.5", Version{Major: 1, Build: "build.5"}, false},
+		{"pre-release and build", "1.0.0-beta+exp.sha.5114f85", Version{Major: 1, PreRelease: "beta", Build: "exp.sha.5114f85"}, false},
+		{"empty", "", Version{}, true},
+		{"missing patch", "v1.2", Version{}, true},
+		{"non-numeric", "v1.x.0", Version{}, true},
+		{"leading zero", "v01.0.0", Version{}, true},
+		{"empty pre-release", "1.0.0-", Version{}, true},
+		{"not a version", "latest", Version{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidVersion)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v2.0.0", -1},
+		{"v1.2.0", "v1.1.9", 1},
+		{"v1.0.1", "v1.0.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"1.0.0+a", "1.0.0+b", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			assert.NoError(t, err)
+			b, err := Parse(tt.b)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, a.Compare(b))
+			assert.Equal(t, -tt.want, b.Compare(a))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+const (
+	// DefaultAuditLogSize is how many audit events are kept in memory before the oldest are dropped
+	DefaultAuditLogSize = 1000
+
+	// auditTokenPrefix marks ListAuditEvents page tokens - format: "audit_<sequence>", resuming before that event
+	auditTokenPrefix = "audit_"
+
+	// Audit actors for changes not made by a caller
+	auditActorSystem    = "system"
+	auditActorAnonymous = "anonymous"
+)
+
+// Audit actions recorded for catalog changes
+const (
+	AuditActionActivateVersion = "version.activate"
+	AuditActionTouchService    = "service.touch"
+	AuditActionPutService      = "service.put"
+	AuditActionCreateService   = "service.create"
+	AuditActionReplaceCatalog  = "catalog.replace"
+)
+
+// auditEvent is one recorded catalog change
+type auditEvent struct {
+	// seq increases with every event and identifies it
+	seq       uint64
+	time      time.Time
+	actor     string
+	action    string
+	serviceID string
+	details   string
+}
+
+// auditFilter selects audit events, zero fields match every event
+type auditFilter struct {
+	actor     string
+	serviceID string
+	action    string
+	// start is inclusive and end exclusive
+	start time.Time
+	end   time.Time
+}
+
+// matches reports whether the event passes the filter
+func (f auditFilter) matches(e *auditEvent) bool {
+	switch {
+	case f.actor != "" && e.actor != f.actor,
+		f.serviceID != "" && e.serviceID != f.serviceID,
+		f.action != "" && e.action != f.action,
+		!f.start.IsZero() && e.time.Before(f.start),
+		!f.end.IsZero() && !e.time.Before(f.end):
+		return false
+	}
+	return true
+}
+
+// auditLog keeps the most recent audit events in a fixed-size ring buffer so they can be queried,
+// in addition to writing each one to the application log
+type auditLog struct {
+	mu sync.Mutex
+	// events is the ring buffer, next is where the next event is written
+	events []auditEvent
+	next   int
+	full   bool
+	seq    uint64
+	now    func() time.Time
+}
+
+// newAuditLog creates an audit log keeping the last size events, nil if size is not positive
+func newAuditLog(size int) *auditLog {
+	if size <= 0 {
+		return nil
+	}
+	return &auditLog{events: make([]auditEvent, size), now: time.Now}
+}
+
+// record appends an event, overwriting the oldest once the log is full
+func (l *auditLog) record(actor, action, serviceID, details string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	l.seq++
+	e := auditEvent{seq: l.seq, time: l.now().UTC(), actor: actor, action: action, serviceID: serviceID, details: details}
+	l.events[l.next] = e
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+	l.mu.Unlock()
+
+	logger.Get().Infow("Audit event",
+		"audit_id", e.seq,
+		"actor", e.actor,
+		"action", e.action,
+		"service_id", e.serviceID,
+		"details", e.details)
+}
+
+// query returns the events matching the filter, newest first
+func (l *auditLog) query(filter auditFilter) []auditEvent {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.events)
+	}
+	var matched []auditEvent
+	for i := 1; i <= count; i++ {
+		e := &l.events[(l.next-i+len(l.events))%len(l.events)]
+		if filter.matches(e) {
+			matched = append(matched, *e)
+		}
+	}
+	return matched
+}
+
+// auditActor names the caller for audit events: the email or user ID from the token, or "anonymous"
+func auditActor(ctx context.Context) string {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return auditActorAnonymous
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.UserID
+}
+
+// parseAuditPageToken returns the sequence a ListAuditEvents page token resumes before, 0 for an empty token
+func parseAuditPageToken(pageToken string) (uint64, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(pageToken, auditTokenPrefix) {
+		return 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token format")
+	}
+	seq, err := strconv.ParseUint(strings.TrimPrefix(pageToken, auditTokenPrefix), 10, 64)
+	if err != nil || seq == 0 {
+		return 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token: %q", pageToken)
+	}
+	return seq, nil
+}
+
+// auditPageToken returns the page token resuming before the event with the given sequence
+func auditPageToken(seq uint64) string {
+	return fmt.Sprintf("%s%d", auditTokenPrefix, seq)
+}
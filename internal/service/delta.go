@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+const (
+	// deltaTokenPrefix marks ListServicesDelta tokens - format: "delta_<epoch>_<sequence>"
+	deltaTokenPrefix = "delta_"
+
+	// deltaPageTokenPrefix marks the page tokens of an initial sync - format: "deltapage_<base64 JSON of
+	// deltaPosition>"
+	deltaPageTokenPrefix = "deltapage_"
+
+	// maxDeltaTombstones is how many deleted services are remembered for delta sync; once exceeded the oldest
+	// half is forgotten and tokens from before them expire
+	maxDeltaTombstones = 10000
+)
+
+// changeEntry is the last change to one service
+type changeEntry struct {
+	id  string
+	seq uint64
+	// service is the service after the change, nil once it was deleted
+	service *model.Service
+	// organizationID is kept for deleted services so their tombstones stay scoped to the organization
+	organizationID string
+	// formerOrganizationIDs are the organizations the service was moved out of, whose clients are sent a
+	// tombstone for it
+	formerOrganizationIDs []string
+	time                  time.Time
+}
+
+// leftScope reports whether the service was moved out of an organization visible returns true for
+func (e *changeEntry) leftScope(visible func(orgID string) bool) bool {
+	for _, orgID := range e.formerOrganizationIDs {
+		if visible(orgID) {
+			return true
+		}
+	}
+	return false
+}
+
+// changeLog numbers catalog changes with an increasing sequence and remembers each service's last change,
+// so clients can fetch what changed after a sequence they have seen. The zero value is ready to use.
+type changeLog struct {
+	mu sync.RWMutex
+	// epoch identifies this process's sequence, tokens from an earlier process or change log expire
+	epoch   int64
+	seq     uint64
+	entries map[string]*changeEntry
+	// tombstones counts the entries of deleted services
+	tombstones int
+	// horizon is the newest sequence whose tombstones were forgotten, tokens before it expire
+	horizon uint64
+}
+
+// init sets up a zero change log, it must be called with mu held
+func (l *changeLog) init() {
+	if l.entries == nil {
+		l.epoch = time.Now().UnixNano()
+		l.entries = make(map[string]*changeEntry)
+	}
+}
+
+// record assigns the events the next sequence number; it is called under the catalog write lock,
+// in the order of the changes
+func (l *changeLog) record(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.init()
+
+	l.seq++
+	for _, e := range events {
+		previous, existed := l.entries[e.ServiceID]
+		entry := &changeEntry{id: e.ServiceID, seq: l.seq, service: e.Service, time: e.Time}
+		if e.Service != nil {
+			entry.organizationID = e.Service.OrganizationID
+		} else if existed {
+			entry.organizationID = previous.organizationID
+		}
+		var formers []string
+		if existed {
+			formers = append(formers, previous.formerOrganizationIDs...)
+			formers = append(formers, previous.organizationID)
+		}
+		formers = append(formers, e.previousOrganizationID)
+		entry.formerOrganizationIDs = formerOrganizations(formers, entry.organizationID)
+
+		if existed && previous.service == nil {
+			l.tombstones--
+		}
+		if entry.service == nil {
+			l.tombstones++
+		}
+		l.entries[e.ServiceID] = entry
+	}
+
+	if l.tombstones > maxDeltaTombstones {
+		l.forgetTombstones(l.tombstones - maxDeltaTombstones/2)
+	}
+}
+
+// formerOrganizations returns the distinct non-empty organizations of orgIDs other than current, in order
+func formerOrganizations(orgIDs []string, current string) []string {
+	var formers []string
+	for _, orgID := range orgIDs {
+		if orgID != "" && orgID != current && !slices.Contains(formers, orgID) {
+			formers = append(formers, orgID)
+		}
+	}
+	return formers
+}
+
+// forgetTombstones drops the n oldest tombstones and moves the horizon past them, it must be called with mu held
+func (l *changeLog) forgetTombstones(n int) {
+	ids := make([]string, 0, l.tombstones)
+	for id, e := range l.entries {
+		if e.service == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return l.entries[ids[i]].seq < l.entries[ids[j]].seq
+	})
+
+	for _, id := range ids[:n] {
+		if seq := l.entries[id].seq; seq > l.horizon {
+			l.horizon = seq
+		}
+		delete(l.entries, id)
+	}
+	l.tombstones -= n
+	logger.Get().Infow("Forgot oldest deleted services for delta sync", "count", n, "horizon", l.horizon)
+}
+
+// token returns the token of the current sequence
+func (l *changeLog) token() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.init()
+	return fmt.Sprintf("%s%d_%d", deltaTokenPrefix, l.epoch, l.seq)
+}
+
+// since returns the last change of every service changed after the token's sequence, and the token of the
+// current sequence. Tokens of another epoch, or from before forgotten tombstones, fail with FailedPrecondition.
+func (l *changeLog) since(token string) ([]*changeEntry, string, error) {
+	epoch, seq, err := parseDeltaToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if epoch != l.epoch || seq > l.seq || seq < l.horizon {
+		return nil, "", newError(codes.FailedPrecondition, ReasonDeltaTokenExpired, ErrInvalidPageToken,
+			"since_token has expired, resync with an empty since_token")
+	}
+
+	var changed []*changeEntry
+	for _, e := range l.entries {
+		if e.seq > seq {
+			changed = append(changed, e)
+		}
+	}
+	return changed, fmt.Sprintf("%s%d_%d", deltaTokenPrefix, l.epoch, l.seq), nil
+}
+
+// parseDeltaToken parses a "delta_<epoch>_<sequence>" token
+func parseDeltaToken(token string) (int64, uint64, error) {
+	epochStr, seqStr, ok := strings.Cut(strings.TrimPrefix(token, deltaTokenPrefix), "_")
+	if !strings.HasPrefix(token, deltaTokenPrefix) || !ok {
+		return 0, 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid since_token format")
+	}
+	epoch, err := strconv.ParseInt(epochStr, 10, 64)
+	if err != nil {
+		return 0, 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid since_token: %q", token)
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil {
+		return 0, 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid since_token: %q", token)
+	}
+	return epoch, seq, nil
+}
+
+// ListServicesDelta returns the services created or updated and the services deleted after since_token,
+// with the token to pass next time. An empty since_token starts an initial sync returning every service in
+// pages of page_size. Services come in the default sort order of listings and deleted services by ID. Authenticated callers only see changes to services of their own
+// organization, unauthenticated ones those of the organizations in WithAnonymousOrganizations, and services
+// moved out of the caller's scope are reported as deleted.
+func (c *CatalogService) ListServicesDelta(ctx context.Context, req *v1.ListServicesDeltaRequest) (*v1.ListServicesDeltaResponse, error) {
+	logger.Get().Infow("ListServicesDelta called",
+		"since_token", req.GetSinceToken(),
+		"page_size", req.GetPageSize(),
+		"page_token", req.GetPageToken())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+	if err := checkRules(req); err != nil {
+		return nil, err
+	}
+	if req.GetSinceToken() != "" && req.GetPageToken() != "" {
+		return nil, newInvalidArgumentError(ReasonInvalidPageToken, "page_token is only used by an initial sync without since_token")
+	}
+
+	orgScope := callerOrganization(ctx)
+	allowed := c.anonymousOrganizations(ctx)
+	visibleOrg := func(orgID string) bool {
+		return (orgScope == "" || orgID == orgScope) && (allowed == nil || allowed[orgID])
+	}
+	localShard := func(id string) bool {
+		return c.shard == nil || c.shard.OwnsService(id)
+	}
+
+	if req.GetSinceToken() == "" {
+		return c.initialDeltaSync(ctx, req, func(s *model.Service) bool {
+			return visibleOrg(s.OrganizationID) && localShard(s.ID)
+		})
+	}
+
+	changed, next, err := c.changes.since(req.GetSinceToken())
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.ListServicesDeltaResponse{NextToken: next}
+
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].id < changed[j].id
+	})
+	var services []*model.Service
+	for _, e := range changed {
+		if !localShard(e.id) {
+			continue
+		}
+		visible := visibleOrg(e.organizationID)
+		switch {
+		case visible && e.service != nil:
+			services = append(services, e.service)
+		case visible || e.leftScope(visibleOrg):
+			// deleted, or moved to an organization the caller does not see
+			resp.DeletedServices = append(resp.DeletedServices, &v1.ServiceTombstone{Id: e.id, DeletedAt: timestamppb.New(e.time)})
+		}
+	}
+	sortBy, sortOrder, _ := c.resolveSort(nil)
+	if err := c.sortServices(ctx, services, sortBy, sortOrder); err != nil {
+		return nil, err
+	}
+	resp.Services = c.toProtoServices(services)
+
+	logger.Get().Infow("ListServicesDelta completed successfully",
+		"services_count", len(resp.Services),
+		"deleted_count", len(resp.DeletedServices))
+	return resp, nil
+}
+
+// deltaPosition is the state of an initial sync kept in its page token: the token every page returns and
+// the sort key of the last service sent
+type deltaPosition struct {
+	NextToken string  `json:"next_token"`
+	After     sortKey `json:"after"`
+}
+
+// initialDeltaSync returns the page of visible services following the request's page token, in the default
+// sort order. Every page carries the token taken before the first page, so services changed during the sync
+// are sent again by the next delta. Pages continue after the sort key of the last service sent rather than
+// at an offset, so services that did not change are never skipped or repeated.
+func (c *CatalogService) initialDeltaSync(ctx context.Context, req *v1.ListServicesDeltaRequest, visible func(*model.Service) bool) (*v1.ListServicesDeltaResponse, error) {
+	var position *deltaPosition
+	if req.GetPageToken() != "" {
+		var err error
+		if position, err = parseDeltaPageToken(req.GetPageToken()); err != nil {
+			return nil, err
+		}
+	} else {
+		position = &deltaPosition{NextToken: c.changes.token()}
+	}
+
+	all := c.getAllServices()
+	services := all[:0]
+	for _, s := range all {
+		if visible(s) {
+			services = append(services, s)
+		}
+	}
+	sortBy, sortOrder, _ := c.resolveSort(nil)
+	if err := c.sortServices(ctx, services, sortBy, sortOrder); err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if req.GetPageToken() != "" {
+		start = sort.Search(len(services), func(i int) bool {
+			return position.After.less(serviceSortKey(services[i]), sortBy, sortOrder)
+		})
+	}
+	end := start + int(c.getPageSize(req.GetPageSize()))
+	if end > len(services) {
+		end = len(services)
+	}
+
+	resp := &v1.ListServicesDeltaResponse{NextToken: position.NextToken, Services: c.toProtoServices(services[start:end])}
+	if end < len(services) {
+		token, err := deltaPageToken(deltaPosition{NextToken: position.NextToken, After: serviceSortKey(services[end-1])})
+		if err != nil {
+			return nil, err
+		}
+		resp.NextPageToken = token
+	}
+
+	logger.Get().Infow("ListServicesDelta completed successfully",
+		"services_count", len(resp.Services),
+		"has_next_page", resp.NextPageToken != "")
+	return resp, nil
+}
+
+// deltaPageToken encodes the position of an initial sync as a page token
+func deltaPageToken(position deltaPosition) (string, error) {
+	b, err := json.Marshal(position)
+	if err != nil {
+		return "", newError(codes.Internal, ReasonInternal, nil, "failed to encode page token: %v", err)
+	}
+	return deltaPageTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// parseDeltaPageToken decodes the page token of an initial sync
+func parseDeltaPageToken(token string) (*deltaPosition, error) {
+	if !strings.HasPrefix(token, deltaPageTokenPrefix) {
+		return nil, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page_token format")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, deltaPageTokenPrefix))
+	if err != nil {
+		return nil, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page_token: %q", token)
+	}
+	var position deltaPosition
+	if err := json.Unmarshal(b, &position); err != nil || position.After.ID == "" {
+		return nil, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page_token: %q", token)
+	}
+	if _, _, err := parseDeltaToken(position.NextToken); err != nil {
+		return nil, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page_token: %q", token)
+	}
+	return &position, nil
+}
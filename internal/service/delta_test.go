@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// serviceIDs returns the IDs of services in response order
+func serviceIDs(services []*v1.Service) []string {
+	ids := make([]string, 0, len(services))
+	for _, s := range services {
+		ids = append(ids, s.Id)
+	}
+	return ids
+}
+
+func TestCatalogService_ListServicesDelta(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithAnonymousWrites(true))
+	ctx := context.Background()
+
+	require.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-1"}))
+
+	// the initial sync returns every service, by name
+	initial, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-4", "svc-3", "svc-2", "svc-5", "svc-1"}, serviceIDs(initial.Services))
+	assert.Empty(t, initial.DeletedServices)
+	require.NotEmpty(t, initial.NextToken)
+
+	// nothing changed yet
+	resp, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: initial.NextToken})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Services)
+	assert.Empty(t, resp.DeletedServices)
+	assert.Equal(t, initial.NextToken, resp.NextToken)
+
+	// touch svc-5, then reload without it and without svc-3
+	_, err = svc.TouchService(ctx, &v1.TouchServiceRequest{Id: "svc-5"})
+	require.NoError(t, err)
+	touched := svc.catalog()["svc-5"]
+	current := svc.catalog()
+	require.NoError(t, svc.ReplaceServices([]*model.Service{current["svc-1"], current["svc-2"], current["svc-4"], touched}))
+
+	resp, err = svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: initial.NextToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-5"}, serviceIDs(resp.Services), "only the changed service is returned")
+	assert.Equal(t, touched.UpdatedAt.Time, resp.Services[0].UpdatedAt.AsTime())
+	require.Len(t, resp.DeletedServices, 1)
+	assert.Equal(t, "svc-3", resp.DeletedServices[0].Id)
+	assert.False(t, resp.DeletedServices[0].DeletedAt.AsTime().IsZero())
+	assert.NotEqual(t, initial.NextToken, resp.NextToken)
+
+	// the new token only returns later changes
+	next, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: resp.NextToken})
+	require.NoError(t, err)
+	assert.Empty(t, next.Services)
+	assert.Empty(t, next.DeletedServices)
+
+	// a recreated service is no longer a tombstone
+	require.NoError(t, svc.PutService(&model.Service{ID: "svc-3", Name: "Notification Service", OrganizationID: "org-2"}))
+	next, err = svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: initial.NextToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-3", "svc-5"}, serviceIDs(next.Services))
+	assert.Empty(t, next.DeletedServices)
+}
+
+func TestCatalogService_ListServicesDelta_OrganizationScope(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1"})
+
+	initial, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+	for _, s := range initial.Services {
+		assert.Equal(t, "org-1", s.OrganizationId)
+	}
+
+	// svc-4 of org-2 is deleted, svc-1 of org-1 changes
+	current := svc.catalog()
+	changed := *current["svc-1"]
+	changed.Name = "Identity Service"
+	require.NoError(t, svc.ReplaceServices([]*model.Service{&changed, current["svc-2"], current["svc-3"]}))
+
+	resp, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: initial.NextToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-1"}, serviceIDs(resp.Services))
+	assert.Empty(t, resp.DeletedServices, "deletions in other organizations are not visible")
+}
+
+func TestCatalogService_ListServicesDelta_MovedOutOfScope(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	org1 := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1"})
+	org2 := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-2"})
+
+	initial1, err := svc.ListServicesDelta(org1, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+	initial2, err := svc.ListServicesDelta(org2, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+
+	// svc-1 moves from org-1 to org-2
+	moved := *svc.catalog()["svc-1"]
+	moved.OrganizationID = "org-2"
+	require.NoError(t, svc.PutService(&moved))
+
+	resp, err := svc.ListServicesDelta(org1, &v1.ListServicesDeltaRequest{SinceToken: initial1.NextToken})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Services)
+	require.Len(t, resp.DeletedServices, 1, "the old organization is told the service left")
+	assert.Equal(t, "svc-1", resp.DeletedServices[0].Id)
+
+	resp, err = svc.ListServicesDelta(org2, &v1.ListServicesDeltaRequest{SinceToken: initial2.NextToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-1"}, serviceIDs(resp.Services))
+	assert.Empty(t, resp.DeletedServices)
+
+	// a reload moving it back tells org-2 it left again
+	back := moved
+	back.OrganizationID = "org-1"
+	current := svc.catalog()
+	require.NoError(t, svc.ReplaceServices([]*model.Service{&back, current["svc-2"], current["svc-3"], current["svc-4"]}))
+	resp, err = svc.ListServicesDelta(org2, &v1.ListServicesDeltaRequest{SinceToken: initial2.NextToken})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Services)
+	assert.Equal(t, "svc-1", resp.DeletedServices[0].Id)
+}
+
+func TestCatalogService_ListServicesDelta_InitialSyncPages(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+
+	// pages follow the default sort, by name: svc-4, svc-3, svc-2, svc-1
+	first, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-4", "svc-3"}, serviceIDs(first.Services))
+	require.NotEmpty(t, first.NextPageToken)
+
+	// a service added before the page boundary shifts nothing, it is returned by the next delta instead
+	require.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Analytics Service", OrganizationID: "org-1"}))
+
+	second, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-2", "svc-1"}, serviceIDs(second.Services))
+	assert.Empty(t, second.NextPageToken)
+	assert.Equal(t, first.NextToken, second.NextToken, "every page returns the token taken before the sync")
+
+	delta, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: second.NextToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-5"}, serviceIDs(delta.Services))
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{PageSize: 101})
+		assert.Equal(t, ReasonInvalidPageSize, ReasonOf(err))
+		_, err = svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{PageToken: "deltapage_garbage"})
+		assert.Equal(t, ReasonInvalidPageToken, ReasonOf(err))
+		_, err = svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: first.NextToken, PageToken: first.NextPageToken})
+		assert.Equal(t, ReasonInvalidPageToken, ReasonOf(err))
+	})
+}
+
+func TestCatalogService_ListServicesDelta_Tokens(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+	initial, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+
+	for _, token := range []string{"garbage", "delta_1", "delta_x_1", "audit_5"} {
+		_, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: token})
+		assert.Equal(t, ReasonInvalidPageToken, ReasonOf(err), token)
+	}
+
+	// tokens of another server or from the future have expired
+	other := newTestCatalogService(mockTestData())
+	otherResp, err := other.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{})
+	require.NoError(t, err)
+	for _, token := range []string{otherResp.NextToken, initial.NextToken + "9"} {
+		_, err = svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{SinceToken: token})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), token)
+		assert.Equal(t, ReasonDeltaTokenExpired, ReasonOf(err))
+	}
+}
+
+func TestChangeLog_ForgetsOldestTombstones(t *testing.T) {
+	var l changeLog
+	first := l.token()
+
+	// one more deletion than remembered, each in its own change
+	for i := 0; i <= maxDeltaTombstones; i++ {
+		l.record(Event{Type: EventServiceDeleted, ServiceID: fmt.Sprintf("svc-%d", i)})
+	}
+	assert.Equal(t, maxDeltaTombstones/2, l.tombstones)
+	assert.Len(t, l.entries, maxDeltaTombstones/2)
+
+	_, _, err := l.since(first)
+	assert.Equal(t, ReasonDeltaTokenExpired, ReasonOf(err))
+
+	// tokens after the horizon still work
+	changed, _, err := l.since(fmt.Sprintf("%s%d_%d", deltaTokenPrefix, l.epoch, l.horizon))
+	require.NoError(t, err)
+	assert.Len(t, changed, maxDeltaTombstones/2)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+// ErrorDomain identifies this service in the google.rpc.ErrorInfo attached to error responses
+const ErrorDomain = "catalog-service"
+
+// Reason is a machine-readable error reason returned to clients alongside the gRPC code.
+// Clients should branch on reasons rather than on message wording, which may change.
+type Reason string
+
+const (
+	// NotFound reasons
+	ReasonServiceNotFound Reason = "SERVICE_NOT_FOUND"
+	ReasonVersionNotFound Reason = "VERSION_NOT_FOUND"
+	ReasonWrongShard      Reason = "WRONG_SHARD"
+
+	// InvalidArgument reasons
+	ReasonMissingRequest      Reason = "MISSING_REQUEST"
+	ReasonMissingID           Reason = "MISSING_ID"
+	ReasonMissingName         Reason = "MISSING_NAME"
+	ReasonInvalidID           Reason = "INVALID_ID"
+	ReasonTooManyIDs          Reason = "TOO_MANY_IDS"
+	ReasonInvalidPageSize     Reason = "INVALID_PAGE_SIZE"
+	ReasonInvalidPageToken    Reason = "INVALID_PAGE_TOKEN"
+	ReasonPageTokenOutOfRange Reason = "PAGE_TOKEN_OUT_OF_RANGE"
+	ReasonSnapshotExpired     Reason = "SNAPSHOT_EXPIRED"
+	ReasonInvalidSearchQuery  Reason = "INVALID_SEARCH_QUERY"
+	ReasonInvalidSort         Reason = "INVALID_SORT"
+	ReasonInvalidVersion      Reason = "INVALID_VERSION"
+	ReasonInvalidTimestamp    Reason = "INVALID_TIMESTAMP"
+	ReasonInvalidURL          Reason = "INVALID_URL"
+	ReasonInvalidField        Reason = "INVALID_FIELD"
+	ReasonTooManyServices     Reason = "TOO_MANY_SERVICES"
+	ReasonUnknownOrganization Reason = "UNKNOWN_ORGANIZATION"
+
+	// PermissionDenied reasons
+	ReasonOrganizationDenied Reason = "ORGANIZATION_DENIED"
+	ReasonAdminRequired      Reason = "ADMIN_REQUIRED"
+
+	// FailedPrecondition reasons
+	ReasonSnapshotsDisabled Reason = "SNAPSHOTS_DISABLED"
+	ReasonDeltaTokenExpired Reason = "DELTA_TOKEN_EXPIRED"
+	ReasonIdempotencyReused Reason = "IDEMPOTENCY_KEY_REUSED"
+
+	// AlreadyExists reasons
+	ReasonServiceExists Reason = "SERVICE_EXISTS"
+
+	// Aborted reasons
+	ReasonBatchAborted Reason = "BATCH_ABORTED"
+
+	// ResourceExhausted reasons
+	ReasonStoreFull        Reason = "STORE_FULL"
+	ReasonTooManySnapshots Reason = "TOO_MANY_SNAPSHOTS"
+
+	// Unavailable reasons
+	ReasonCatalogLoading Reason = "CATALOG_LOADING"
+
+	// Internal reasons
+	ReasonInternal Reason = "INTERNAL"
+)
+
+// Error is a typed service error carrying a gRPC code and a machine-readable reason.
+// It wraps one of the package sentinel errors so errors.Is keeps working.
+type Error struct {
+	Code    codes.Code
+	Reason  Reason
+	Message string
+	// RetryDelay, when set, tells clients how long to wait before retrying
+	RetryDelay time.Duration
+	err        error
+}
+
+// Error returns the message prefixed with the wrapped sentinel, e.g. "invalid request: service ID is required"
+func (e *Error) Error() string {
+	if e.err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%v: %s", e.err, e.Message)
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus converts the error to a gRPC status carrying the reason as google.rpc.ErrorInfo
+// and any retry hint as google.rpc.RetryInfo, which the gateway includes in the JSON error body
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Error())
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: string(e.Reason),
+		Domain: ErrorDomain,
+	}}
+	if e.RetryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryDelay)})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// ReasonOf returns the reason of a service error, or "" if err is not one
+func ReasonOf(err error) Reason {
+	var svcErr *Error
+	if errors.As(err, &svcErr) {
+		return svcErr.Reason
+	}
+	return ""
+}
+
+// newError creates a typed service error wrapping the given sentinel
+func newError(code codes.Code, reason Reason, sentinel error, format string, args ...interface{}) *Error {
+	return &Error{
+		Code:    code,
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+		err:     sentinel,
+	}
+}
+
+// newNotFoundError creates a codes.NotFound error wrapping the given sentinel
+func newNotFoundError(reason Reason, sentinel error, format string, args ...interface{}) *Error {
+	return newError(codes.NotFound, reason, sentinel, format, args...)
+}
+
+// newInvalidArgumentError creates a codes.InvalidArgument error wrapping ErrInvalidRequest
+func newInvalidArgumentError(reason Reason, format string, args ...interface{}) *Error {
+	return newError(codes.InvalidArgument, reason, ErrInvalidRequest, format, args...)
+}
+
+// newAlreadyExistsError creates a codes.AlreadyExists error wrapping ErrServiceExists
+func newAlreadyExistsError(reason Reason, format string, args ...interface{}) *Error {
+	return newError(codes.AlreadyExists, reason, ErrServiceExists, format, args...)
+}
+
+// newUnavailableError creates a codes.Unavailable error wrapping ErrUnavailable with a retry hint
+func newUnavailableError(reason Reason, retryDelay time.Duration, format string, args ...interface{}) *Error {
+	e := newError(codes.Unavailable, reason, ErrUnavailable, format, args...)
+	e.RetryDelay = retryDelay
+	return e
+}
+
+// newStoreFullError creates a codes.ResourceExhausted error wrapping model.ErrStoreFull
+func newStoreFullError(count, limit int) *Error {
+	return newError(codes.ResourceExhausted, ReasonStoreFull, model.ErrStoreFull, "%d services exceed the limit of %d", count, limit)
+}
+
+// newPermissionDeniedError creates a codes.PermissionDenied error wrapping ErrPermissionDenied
+func newPermissionDeniedError(reason Reason, format string, args ...interface{}) *Error {
+	return newError(codes.PermissionDenied, reason, ErrPermissionDenied, format, args...)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+func TestError_CodeAndReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		reason   Reason
+		sentinel error
+		message  string
+	}{
+		{
+			name:     "not found",
+			err:      newNotFoundError(ReasonServiceNotFound, ErrServiceNotFound, "service with ID '%s' not found", "svc-9"),
+			code:     codes.NotFound,
+			reason:   ReasonServiceNotFound,
+			sentinel: ErrServiceNotFound,
+			message:  "service not found: service with ID 'svc-9' not found",
+		},
+		{
+			name:     "invalid argument",
+			err:      newInvalidArgumentError(ReasonInvalidPageSize, "page_size must be between 0 and %d, got %d", MaxPageSize, 101),
+			code:     codes.InvalidArgument,
+			reason:   ReasonInvalidPageSize,
+			sentinel: ErrInvalidRequest,
+			message:  "invalid request: page_size must be between 0 and 100, got 101",
+		},
+		{
+			name:     "permission denied",
+			err:      newPermissionDeniedError(ReasonOrganizationDenied, "organization %s is not accessible", "org-2"),
+			code:     codes.PermissionDenied,
+			reason:   ReasonOrganizationDenied,
+			sentinel: ErrPermissionDenied,
+			message:  "permission denied: organization org-2 is not accessible",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(tt.err))
+			assert.Equal(t, tt.reason, ReasonOf(tt.err))
+			assert.True(t, errors.Is(tt.err, tt.sentinel))
+
+			st, ok := status.FromError(tt.err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.message, st.Message())
+			if assert.Len(t, st.Details(), 1) {
+				info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				assert.True(t, ok)
+				assert.Equal(t, string(tt.reason), info.GetReason())
+				assert.Equal(t, ErrorDomain, info.GetDomain())
+			}
+		})
+	}
+}
+
+func TestReasonOf_NonServiceError(t *testing.T) {
+	assert.Equal(t, Reason(""), ReasonOf(errors.New("boom")))
+	assert.Equal(t, Reason(""), ReasonOf(status.Error(codes.Internal, "boom")))
+	assert.Equal(t, Reason(""), ReasonOf(nil))
+}
+
+func TestCatalogService_ErrorReasons(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithStrictSort(true))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason Reason
+	}{
+		{
+			name: "unknown service",
+			call: func() error {
+				_, err := svc.GetService(ctx, &v1.GetServiceRequest{Id: "svc-9"})
+				return err
+			},
+			code:   codes.NotFound,
+			reason: ReasonServiceNotFound,
+		},
+		{
+			name: "missing service ID",
+			call: func() error {
+				_, err := svc.GetServiceVersions(ctx, &v1.GetServiceVersionsRequest{})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonMissingID,
+		},
+		{
+			name: "malformed service ID",
+			call: func() error {
+				_, err := svc.GetService(ctx, &v1.GetServiceRequest{Id: "svc 1"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonInvalidID,
+		},
+		{
+			name: "page size too large",
+			call: func() error {
+				_, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageSize: 101})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonInvalidPageSize,
+		},
+		{
+			name: "malformed page token",
+			call: func() error {
+				_, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageToken: "abc"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonInvalidPageToken,
+		},
+		{
+			name: "page token out of range",
+			call: func() error {
+				_, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageToken: "page_10"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonPageTokenOutOfRange,
+		},
+		{
+			name: "unknown sort field",
+			call: func() error {
+				_, err := svc.ListServices(ctx, &v1.ListServicesRequest{SortBy: "popularity"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ReasonInvalidSort,
+		},
+		{
+			name: "snapshots disabled",
+			call: func() error {
+				_, err := svc.ListServices(ctx, &v1.ListServicesRequest{Snapshot: true})
+				return err
+			},
+			code:   codes.FailedPrecondition,
+			reason: ReasonSnapshotsDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.reason, ReasonOf(err))
+		})
+	}
+}
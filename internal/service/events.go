@@ -0,0 +1,128 @@
+package service
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// DefaultEventBuffer is how many events a subscriber can fall behind before further events are dropped for it
+const DefaultEventBuffer = 64
+
+// EventType identifies the kind of catalog change an Event describes
+type EventType string
+
+// Catalog change events
+const (
+	EventServiceCreated EventType = "service.created"
+	EventServiceUpdated EventType = "service.updated"
+	EventServiceDeleted EventType = "service.deleted"
+)
+
+// Event describes a change to one service in the catalog
+type Event struct {
+	Type      EventType
+	ServiceID string
+	// Service is the service after the change, nil for EventServiceDeleted. It must not be modified.
+	Service *model.Service
+	Time    time.Time
+
+	// previousOrganizationID is the organization of an updated service before the change, so delta syncs
+	// can tell that organization's clients the service left it
+	previousOrganizationID string
+}
+
+// ServiceProto returns the changed service as served by the API, nil for EventServiceDeleted
+func (e Event) ServiceProto() *v1.Service {
+	if e.Service == nil {
+		return nil
+	}
+	return convertToProtoService(e.Service)
+}
+
+// eventBus fans catalog change events out to subscribers. Publishing never blocks: each subscriber has a
+// buffered channel, and events for a subscriber whose buffer is full are dropped and logged.
+// The zero value is ready to use.
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+}
+
+// subscribe registers a subscriber with room for buffer pending events
+func (b *eventBus) subscribe(buffer int) (int, chan Event) {
+	if buffer <= 0 {
+		buffer = DefaultEventBuffer
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = make(map[int]chan Event)
+	}
+	b.nextID++
+	ch := make(chan Event, buffer)
+	b.subscribers[b.nextID] = ch
+	return b.nextID, ch
+}
+
+// unsubscribe removes a subscriber and closes its channel, it is safe to call more than once
+func (b *eventBus) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(ch)
+	}
+}
+
+// publish delivers events to every subscriber in order, dropping those that do not fit a subscriber's buffer
+func (b *eventBus) publish(events ...Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for id, ch := range b.subscribers {
+		for _, e := range events {
+			select {
+			case ch <- e:
+			default:
+				logger.Get().Warnw("Dropped catalog event for slow subscriber",
+					"subscriber", id,
+					"event", e.Type,
+					"service_id", e.ServiceID)
+			}
+		}
+	}
+}
+
+// Subscribe registers for catalog change events, buffering up to buffer of them (DefaultEventBuffer if not
+// positive). Events a subscriber has no room for are dropped rather than slowing down changes.
+// The returned function unsubscribes and closes the channel.
+func (c *CatalogService) Subscribe(buffer int) (<-chan Event, func()) {
+	id, ch := c.events.subscribe(buffer)
+	return ch, func() { c.events.unsubscribe(id) }
+}
+
+// catalogDiff returns the events turning the previous catalog into the next one, services present in both
+// but not deeply equal count as updated
+func catalogDiff(previous, next map[string]*model.Service, now time.Time) []Event {
+	var events []Event
+	for id, svc := range next {
+		old, existed := previous[id]
+		switch {
+		case !existed:
+			events = append(events, Event{Type: EventServiceCreated, ServiceID: id, Service: svc, Time: now})
+		case old != svc && !reflect.DeepEqual(old, svc):
+			events = append(events, Event{Type: EventServiceUpdated, ServiceID: id, Service: svc, Time: now, previousOrganizationID: old.OrganizationID})
+		}
+	}
+	for id := range previous {
+		if _, exists := next[id]; !exists {
+			events = append(events, Event{Type: EventServiceDeleted, ServiceID: id, Time: now})
+		}
+	}
+	return events
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/model"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// receive returns the next event or fails the test after a second
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func TestCatalogService_Subscribe_PutService(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	events, unsubscribe := svc.Subscribe(0)
+	defer unsubscribe()
+
+	require.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service"}))
+	e := receive(t, events)
+	assert.Equal(t, EventServiceCreated, e.Type)
+	assert.Equal(t, "svc-5", e.ServiceID)
+	assert.Equal(t, "Search Service", e.Service.Name)
+	assert.False(t, e.Time.IsZero())
+
+	require.NoError(t, svc.PutService(&model.Service{ID: "svc-1", Name: "Identity Service"}))
+	e = receive(t, events)
+	assert.Equal(t, EventServiceUpdated, e.Type)
+	assert.Equal(t, "svc-1", e.ServiceID)
+}
+
+func TestCatalogService_Subscribe_ReplaceServices(t *testing.T) {
+	data := mockTestData()
+	svc := newTestCatalogService(data)
+	events, unsubscribe := svc.Subscribe(0)
+	defer unsubscribe()
+
+	// svc-1 changes, svc-2 is unchanged, svc-3 and svc-4 are removed and svc-5 is added
+	changed := *data["svc-1"]
+	changed.Name = "Identity Service"
+	require.NoError(t, svc.ReplaceServices([]*model.Service{&changed, data["svc-2"], {ID: "svc-5", Name: "Search Service"}}))
+
+	got := map[string]EventType{}
+	for i := 0; i < 4; i++ {
+		e := receive(t, events)
+		got[e.ServiceID] = e.Type
+	}
+	assert.Equal(t, map[string]EventType{
+		"svc-1": EventServiceUpdated,
+		"svc-3": EventServiceDeleted,
+		"svc-4": EventServiceDeleted,
+		"svc-5": EventServiceCreated,
+	}, got)
+	assert.Empty(t, events)
+}
+
+func TestCatalogService_Subscribe_ActivateVersion(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	events, unsubscribe := svc.Subscribe(0)
+	defer unsubscribe()
+
+	ctx := context.WithValue(context.Background(), "user", &auth.Claims{UserID: "admin-1", Role: "admin"})
+	resp, err := svc.ActivateVersionAcrossServices(ctx, &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetServiceIds())
+
+	for _, id := range resp.GetServiceIds() {
+		e := receive(t, events)
+		assert.Equal(t, EventServiceUpdated, e.Type)
+		assert.Equal(t, id, e.ServiceID)
+	}
+}
+
+func TestCatalogService_Subscribe_SlowSubscriber(t *testing.T) {
+	svc := newTestCatalogService(map[string]*model.Service{})
+	slow, unsubscribeSlow := svc.Subscribe(1)
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := svc.Subscribe(10)
+	defer unsubscribeFast()
+
+	// Publishing never blocks on the full buffer of the slow subscriber
+	for _, id := range []string{"svc-1", "svc-2", "svc-3"} {
+		require.NoError(t, svc.PutService(&model.Service{ID: id, Name: "Service " + id}))
+	}
+
+	assert.Equal(t, "svc-1", receive(t, slow).ServiceID)
+	assert.Empty(t, slow, "later events were dropped")
+	for _, id := range []string{"svc-1", "svc-2", "svc-3"} {
+		assert.Equal(t, id, receive(t, fast).ServiceID)
+	}
+
+	unsubscribeSlow()
+	_, open := <-slow
+	assert.False(t, open, "unsubscribing closes the channel")
+	unsubscribeSlow()
+}
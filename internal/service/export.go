@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// ExportServices passes every service matching the ListServices filters of req to send, in the requested sort
+// order and without pagination, returning how many were sent. It stops at the first error of send or once
+// the context is done.
+// Authenticated callers only export their own organization, asking for another organization is handled per
+// WithCrossOrgPolicy: nothing is exported, or the export fails with PermissionDenied.
+func (c *CatalogService) ExportServices(ctx context.Context, req *v1.ListServicesRequest, send func(*v1.Service) error) (int, error) {
+	logger.Get().Infow("ExportServices called",
+		"organization_id", logger.Redact("organization_id", req.GetOrganizationId()),
+		"organization_ids", logger.Redact("organization_ids", req.GetOrganizationIds()),
+		"search_query", logger.Redact("search_query", req.GetSearchQuery()),
+		"sort_by", req.GetSortBy(),
+		"sort_order", req.GetSortOrder(),
+		"version", logger.Redact("version", req.GetVersion()))
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return 0, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return 0, err
+	}
+
+	req = c.applyDefaultOrganization(ctx, req)
+	if err := c.validateListServicesRequest(req); err != nil {
+		return 0, err
+	}
+
+	req, visible, err := c.applyCallerOrganization(ctx, req)
+	if err != nil || !visible {
+		return 0, err
+	}
+
+	services, err := c.filterServices(ctx, c.anonymousServices(ctx, c.localServices(c.getAllServices())), req)
+	if err != nil {
+		return 0, err
+	}
+	sortBy, sortOrder, err := c.resolveSort(req)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.sortServices(ctx, services, sortBy, sortOrder); err != nil {
+		return 0, err
+	}
+
+	for i, s := range services {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return i, err
+			}
+		}
+		if err := send(c.toProtoService(s)); err != nil {
+			return i, err
+		}
+	}
+
+	logger.Get().Infow("ExportServices completed successfully", "services_count", len(services))
+	return len(services), nil
+}
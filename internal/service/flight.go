@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+)
+
+// requestFlights coalesces concurrent identical read requests, so a burst of clients asking for the same
+// hot service share one lookup and conversion instead of each repeating it
+type requestFlights struct {
+	group singleflight.Group
+	// onCompute, when set, is called each time a computation actually runs, used by tests to count work
+	onCompute func(key string)
+}
+
+// do runs compute once for all concurrent callers passing the same key and gives each caller its own copy
+// of the response. compute runs detached from the callers' cancellation so one caller giving up does not fail
+// the others, while each caller still stops waiting when its own context ends. Nothing is cached: results,
+// including errors, are only shared with callers that arrived while the computation was running.
+func (f *requestFlights) do(ctx context.Context, method, key string, compute func(ctx context.Context) (proto.Message, error)) (proto.Message, error) {
+	key = method + "/" + key
+	// leader is only set when this caller's compute runs, and is read after the result is received from ch
+	leader := false
+	ch := f.group.DoChan(key, func() (interface{}, error) {
+		leader = true
+		if f.onCompute != nil {
+			f.onCompute(key)
+		}
+		return compute(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		recordFlight(method, leader, res.Shared)
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		resp := res.Val.(proto.Message)
+		if res.Shared {
+			logger.Get().Debugw("Shared response with concurrent identical requests", "key", key)
+			// Callers own their response, e.g. the gateway may add fields while rendering it
+			resp = proto.Clone(resp)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+}
+
+// recordFlight counts a caller that received a result: the caller whose computation ran is a miss, callers
+// that joined it are hits, and a computation shared with at least one other caller counts once as coalesced
+func recordFlight(method string, leader, shared bool) {
+	metrics := logger.NewMetricsLogger()
+	tags := map[string]string{"method": method}
+
+	if leader {
+		logger.CacheMisses().WithLabelValues(method).Add(1)
+		metrics.LogCounter("cache_misses_total", 1, tags)
+		if shared {
+			logger.CoalescedRequests().WithLabelValues(method).Add(1)
+			metrics.LogCounter("coalesced_requests_total", 1, tags)
+		}
+	} else {
+		logger.CacheHits().WithLabelValues(method).Add(1)
+		metrics.LogCounter("cache_hits_total", 1, tags)
+	}
+	metrics.LogGauge("cache_hit_ratio", logger.CacheHitRatio(method), tags)
+}
+
+// listServicesKey identifies a ListServices request by its deterministic wire encoding, so requests with
+// the same parameters share a key regardless of field order on the wire
+func listServicesKey(req proto.Message) (string, bool) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+const (
+	// DefaultIdempotencyTTL is how long a CreateServices response is replayed for retries with the same idempotency key
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// DefaultIdempotencyMaxKeys is how many idempotency keys are kept at once
+	DefaultIdempotencyMaxKeys = 10000
+
+	// maxIdempotencyKeyLength bounds caller-supplied idempotency keys kept in memory
+	maxIdempotencyKeyLength = 128
+)
+
+// idempotentResult is the response of a request made with an idempotency key
+type idempotentResult struct {
+	key string
+	// fingerprint identifies the request, so reusing its key for a different request is detected
+	fingerprint [sha256.Size]byte
+	resp        *v1.CreateServicesResponse
+	expiresAt   time.Time
+}
+
+// idempotencyStore keeps the responses of requests made with an idempotency key in memory until they expire,
+// at most maxCount at once, evicting the oldest one to make room
+type idempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order lists the results from newest to oldest, which is also their expiry order
+	order    *list.List
+	ttl      time.Duration
+	maxCount int
+	now      func() time.Time
+}
+
+// newIdempotencyStore creates an empty idempotency store whose entries expire after ttl, holding at most
+// DefaultIdempotencyMaxKeys keys
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		ttl:      ttl,
+		maxCount: DefaultIdempotencyMaxKeys,
+		now:      time.Now,
+	}
+}
+
+// get returns the unexpired result stored for key
+func (s *idempotencyStore) get(key string) (*idempotentResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	result := elem.Value.(*idempotentResult)
+	if !s.now().Before(result.expiresAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	return result, true
+}
+
+// put stores a copy of the response to the request with the given fingerprint under key. When the store is
+// full the oldest key is evicted, so a retry with it is no longer recognized.
+func (s *idempotencyStore) put(key string, fingerprint [sha256.Size]byte, resp *v1.CreateServicesResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeExpired()
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+	if s.maxCount > 0 && s.order.Len() >= s.maxCount {
+		s.remove(s.order.Back())
+		logger.Get().Debugw("Evicted oldest idempotency key", "max_keys", s.maxCount)
+	}
+	s.entries[key] = s.order.PushFront(&idempotentResult{
+		key:         key,
+		fingerprint: fingerprint,
+		resp:        proto.Clone(resp).(*v1.CreateServicesResponse),
+		expiresAt:   s.now().Add(s.ttl),
+	})
+}
+
+// purgeExpired drops expired results, oldest first, the caller must hold the lock
+func (s *idempotencyStore) purgeExpired() {
+	now := s.now()
+	for elem := s.order.Back(); elem != nil && !now.Before(elem.Value.(*idempotentResult).expiresAt); elem = s.order.Back() {
+		s.remove(elem)
+	}
+}
+
+// remove drops a result, the caller must hold the lock
+func (s *idempotencyStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*idempotentResult).key)
+}
+
+// idempotencyScope returns the key a CreateServices response is stored under, scoped to the caller so one caller
+// never gets another's response, or "" when the request has no idempotency key
+func idempotencyScope(ctx context.Context, req *v1.CreateServicesRequest) string {
+	if req.GetIdempotencyKey() == "" {
+		return ""
+	}
+	return auditActor(ctx) + "\x00" + req.GetIdempotencyKey()
+}
+
+// createServicesFingerprint hashes the request without its idempotency key
+func createServicesFingerprint(req *v1.CreateServicesRequest) ([sha256.Size]byte, error) {
+	unkeyed := proto.Clone(req).(*v1.CreateServicesRequest)
+	unkeyed.IdempotencyKey = ""
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(unkeyed)
+	if err != nil {
+		return [sha256.Size]byte{}, newError(codes.Internal, ReasonInternal, nil, "failed to fingerprint request: %v", err)
+	}
+	return sha256.Sum256(data), nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ruleValidator is a request message with protoc-gen-validate rules declared in catalog.proto
+type ruleValidator interface {
+	proto.Message
+	Validate() error
+}
+
+// ruleViolation is the error protoc-gen-validate generates for a field that breaks its rules
+type ruleViolation interface {
+	error
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// fieldRule is how a broken field rule is reported to clients
+type fieldRule struct {
+	reason  Reason
+	message string
+}
+
+// fieldRules maps the fields with rules in catalog.proto, by generated field name or by message and field
+// name when requests share a field name but not its rules, to the error they report. Rules that depend
+// on configuration (ID formats, field limits, search_query minimum length, strict sorting) stay in the
+// validate methods.
+var fieldRules = map[string]fieldRule{
+	"Id":        {ReasonMissingID, "service ID is required"},
+	"ServiceId": {ReasonMissingID, "service ID is required"},
+	"PageSize":  {ReasonInvalidPageSize, fmt.Sprintf("page_size must be between 0 and %d", MaxPageSize)},
+	"ChunkSize": {ReasonInvalidPageSize, fmt.Sprintf("chunk_size must be between 0 and %d", MaxVersionChunkSize)},
+
+	"ActivateVersionAcrossServicesRequest.Version": {ReasonInvalidVersion, "version is required"},
+	"Service.Name":           {ReasonMissingName, "service name is required"},
+	"Service.OrganizationId": {ReasonInvalidField, "organization_id is required"},
+}
+
+// checkRules checks req against its declared rules, reporting the first broken one as InvalidArgument
+func checkRules(req ruleValidator) error {
+	err := req.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var violation ruleViolation
+	if !errors.As(err, &violation) {
+		return newInvalidArgumentError(ReasonInvalidField, "%v", err)
+	}
+	messageField := string(req.ProtoReflect().Descriptor().Name()) + "." + violation.Field()
+	if rule, ok := fieldRules[messageField]; ok {
+		return newInvalidArgumentError(rule.reason, "%s", rule.message)
+	}
+	if rule, ok := fieldRules[violation.Field()]; ok {
+		return newInvalidArgumentError(rule.reason, "%s", rule.message)
+	}
+
+	// A broken rule of an embedded message is reported with its full path, e.g. "Versions[0].Version"
+	field, reason := violation.Field(), violation.Reason()
+	for {
+		var nested ruleViolation
+		if !errors.As(violation.Cause(), &nested) {
+			break
+		}
+		violation = nested
+		field, reason = field+"."+nested.Field(), nested.Reason()
+	}
+	return newInvalidArgumentError(ReasonInvalidField, "invalid %s: %s", field, reason)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ankittk/catalog-service/internal/auth"
+	v1 "github.com/ankittk/catalog-service/proto/v1"
+)
+
+// goFieldName returns the generated Go name of a proto field, e.g. page_size becomes PageSize
+func goFieldName(field protoreflect.FieldDescriptor) string {
+	var name strings.Builder
+	for _, part := range strings.Split(string(field.Name()), "_") {
+		if part != "" {
+			name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return name.String()
+}
+
+func TestFieldRules_CoverEveryRequestRule(t *testing.T) {
+	messages := v1.File_v1_catalog_proto.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		msg := messages.Get(i)
+		if !strings.HasSuffix(string(msg.Name()), "Request") {
+			continue
+		}
+		for j := 0; j < msg.Fields().Len(); j++ {
+			field := msg.Fields().Get(j)
+			rules, ok := proto.GetExtension(field.Options(), validate.E_Rules).(*validate.FieldRules)
+			if !ok || rules == nil {
+				continue
+			}
+
+			name := goFieldName(field)
+			_, byField := fieldRules[name]
+			_, byMessage := fieldRules[string(msg.Name())+"."+name]
+			// ValidateCatalog is answered by the gRPC server before it reaches this package
+			if msg.Name() != "ValidateCatalogRequest" {
+				assert.True(t, byField || byMessage, "no fieldRules entry for %s.%s", msg.Name(), field.Name())
+			}
+
+			// The bounds in the proto must agree with the constants used for defaults and messages
+			switch name {
+			case "PageSize":
+				assert.Equal(t, int32(MaxPageSize), rules.GetInt32().GetLte(), "%s.page_size", msg.Name())
+			case "ChunkSize":
+				assert.Equal(t, int32(MaxVersionChunkSize), rules.GetInt32().GetLte(), "%s.chunk_size", msg.Name())
+			}
+		}
+	}
+}
+
+// TestCheckRules_RejectsHandWrittenCases checks the declarative rules reject what the validate methods
+// rejected before the rules replaced their checks, with the same reasons and messages
+func TestCheckRules_RejectsHandWrittenCases(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+
+	tests := []struct {
+		name        string
+		call        func() error
+		wantReason  Reason
+		wantMessage string
+	}{
+		{
+			name:        "negative page_size",
+			call:        func() error { return svc.validateListServicesRequest(&v1.ListServicesRequest{PageSize: -1}) },
+			wantReason:  ReasonInvalidPageSize,
+			wantMessage: "page_size must be between 0 and 100",
+		},
+		{
+			name: "page_size above the maximum",
+			call: func() error {
+				return svc.validateListRecentVersionsRequest(&v1.ListRecentVersionsRequest{PageSize: 101})
+			},
+			wantReason:  ReasonInvalidPageSize,
+			wantMessage: "page_size must be between 0 and 100",
+		},
+		{
+			name: "audit page_size above the maximum",
+			call: func() error {
+				_, err := svc.validateListAuditEventsRequest(&v1.ListAuditEventsRequest{PageSize: 101})
+				return err
+			},
+			wantReason:  ReasonInvalidPageSize,
+			wantMessage: "page_size must be between 0 and 100",
+		},
+		{
+			name: "search_query too long",
+			call: func() error {
+				return svc.validateListServicesRequest(&v1.ListServicesRequest{SearchQuery: strings.Repeat("a", 101)})
+			},
+			wantReason:  ReasonInvalidSearchQuery,
+			wantMessage: "search_query too long",
+		},
+		{
+			name: "version filter too long",
+			call: func() error {
+				return svc.validateListServicesRequest(&v1.ListServicesRequest{Version: strings.Repeat("v", MaxVersionFilterLength+1)})
+			},
+			wantReason:  ReasonInvalidVersion,
+			wantMessage: "version too long, max 50 characters",
+		},
+		{
+			name:        "missing service ID",
+			call:        func() error { return svc.validateGetServiceRequest(&v1.GetServiceRequest{}) },
+			wantReason:  ReasonMissingID,
+			wantMessage: "service ID is required",
+		},
+		{
+			name:        "missing history service ID",
+			call:        func() error { return svc.validateGetServiceHistoryRequest(&v1.GetServiceHistoryRequest{}) },
+			wantReason:  ReasonMissingID,
+			wantMessage: "service ID is required",
+		},
+		{
+			name: "chunk_size above the maximum",
+			call: func() error {
+				return svc.validateStreamServiceVersionsRequest(&v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: MaxVersionChunkSize + 1})
+			},
+			wantReason:  ReasonInvalidPageSize,
+			wantMessage: "chunk_size must be between 0 and 1000",
+		},
+		{
+			name: "missing version to activate",
+			call: func() error {
+				_, err := svc.ActivateVersionAcrossServices(adminCtx, &v1.ActivateVersionAcrossServicesRequest{})
+				return err
+			},
+			wantReason:  ReasonInvalidVersion,
+			wantMessage: "version is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, tt.wantReason, ReasonOf(err))
+			assert.Contains(t, status.Convert(err).Message(), tt.wantMessage)
+		})
+	}
+
+	// Limits themselves are still accepted
+	assert.NoError(t, svc.validateListServicesRequest(&v1.ListServicesRequest{PageSize: MaxPageSize, SearchQuery: strings.Repeat("a", 100)}))
+	assert.NoError(t, svc.validateListServicesRequest(&v1.ListServicesRequest{}))
+	assert.NoError(t, svc.validateStreamServiceVersionsRequest(&v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: MaxVersionChunkSize}))
+}
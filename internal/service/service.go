@@ -1,38 +1,100 @@
 package service
 
 import (
+	"container/heap"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
+	"google.golang.org/genproto/googleapis/rpc/code"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/idgen"
 	"github.com/ankittk/catalog-service/internal/logger"
 	"github.com/ankittk/catalog-service/internal/model"
+	"github.com/ankittk/catalog-service/internal/semver"
 	v1 "github.com/ankittk/catalog-service/proto/v1"
 )
 
 var (
 	ErrServiceNotFound     = errors.New("service not found")
+	ErrServiceExists       = errors.New("service already exists")
+	ErrVersionNotFound     = errors.New("version not found")
 	ErrInvalidRequest      = errors.New("invalid request")
 	ErrInvalidPageToken    = errors.New("invalid page token")
 	ErrPageTokenOutOfRange = errors.New("page token out of range")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrUnavailable         = errors.New("service unavailable")
 )
 
 const (
 	MaxPageSize     = 100
 	DefaultPageSize = 10
+
+	// MaxBatchSize is the default for the most distinct service IDs accepted by BatchGetServices
+	MaxBatchSize = 100
+
+	// MaxCreateBatchSize is the default for the most services accepted by one CreateServices request
+	MaxCreateBatchSize = 100
+
+	// MaxOrganizationFilters is the default for the most distinct organization_ids accepted by one ListServices request
+	MaxOrganizationFilters = 50
+
+	// MaxSearchQueryLength is the default for the longest search_query, in characters
+	MaxSearchQueryLength = 100
+
+	// MaxVersionFilterLength is the default for the longest ListServices version filter, in characters
+	MaxVersionFilterLength = 50
+
+	// DefaultVersionChunkSize and MaxVersionChunkSize bound the versions per StreamServiceVersions message
+	DefaultVersionChunkSize = 100
+	MaxVersionChunkSize     = 1000
+
+	// DefaultRetryDelay is the retry hint returned while the catalog is not yet loaded
+	DefaultRetryDelay = time.Second
+
+	// contextCheckInterval is how many loop iterations run between context cancellation checks
+	contextCheckInterval = 1000
+)
+
+// DefaultFieldLimits are the request field limits, keyed by "Method.field": characters of strings and distinct
+// entries of repeated fields. WithFieldLimits replaces them, and the same limits configure the field limit
+// interceptor so oversized requests are rejected before any handler runs.
+var DefaultFieldLimits = map[string]int{
+	"ListServices.search_query":     MaxSearchQueryLength,
+	"ListServices.version":          MaxVersionFilterLength,
+	"ListServices.organization_ids": MaxOrganizationFilters,
+	"SearchVersions.search_query":   MaxSearchQueryLength,
+	"BatchGetServices.ids":          MaxBatchSize,
+	"CreateServices.services":       MaxCreateBatchSize,
+}
+
+const (
+	// DefaultSortBy and DefaultSortOrder order listings whose request leaves sort_by or sort_order empty,
+	// unless WithDefaultSort sets other defaults
+	DefaultSortBy    = "name"
+	DefaultSortOrder = "asc"
 )
 
 var validSortFields = map[string]bool{
-	"name":       true,
-	"created_at": true,
-	"updated_at": true,
+	"name":          true,
+	"created_at":    true,
+	"updated_at":    true,
+	"version_count": true,
 }
 
 var validSortOrders = map[string]bool{
@@ -40,197 +102,2515 @@ var validSortOrders = map[string]bool{
 	"desc": true,
 }
 
+// searchWildcard is the trailing character that turns a search query into a name prefix match
+const searchWildcard = "*"
+
+const (
+	// SearchFieldsName matches search terms against service names only
+	SearchFieldsName = "name"
+	// SearchFieldsNameDescription matches search terms against names and descriptions
+	SearchFieldsNameDescription = "name,description"
+	// SearchFieldsNameDescriptionVersion also matches search terms against version strings
+	SearchFieldsNameDescriptionVersion = "name,description,version"
+
+	// SearchMatchAll requires every search term to match one of the fields
+	SearchMatchAll = "all"
+	// SearchMatchAny requires at least one search term to match one of the fields
+	SearchMatchAny = "any"
+)
+
+// validSearchFields defines the accepted search_fields values
+var validSearchFields = map[string]bool{
+	SearchFieldsName:                   true,
+	SearchFieldsNameDescription:        true,
+	SearchFieldsNameDescriptionVersion: true,
+}
+
+// validSearchMatches defines the accepted search_match values
+var validSearchMatches = map[string]bool{
+	SearchMatchAll: true,
+	SearchMatchAny: true,
+}
+
 type CatalogService struct {
-	data map[string]*model.Service
+	// data holds the services keyed by ID. Reloads swap the whole map atomically, so every
+	// request reads one complete version of the catalog and never a partially replaced one.
+	// The published map is never modified, so reads need no lock.
+	data atomic.Pointer[map[string]*model.Service]
+	// orgNames maps organization IDs to display names, swapped as a whole like data
+	orgNames atomic.Pointer[map[string]string]
+	// orgIDs holds the IDs of the listed organizations, swapped together with orgNames
+	orgIDs atomic.Pointer[map[string]bool]
+	// writeMu serializes mutations so concurrent copy-on-write updates don't lose each other's changes
+	writeMu   sync.Mutex
+	snapshots *snapshotStore
+	// idempotency replays CreateServices responses to retries with the same idempotency key, nil disables it
+	idempotency *idempotencyStore
+	// flights lets concurrent identical GetService and ListServices calls share one computation
+	flights requestFlights
+	// audit records catalog changes for ListAuditEvents, nil disables it
+	audit *auditLog
+	// maxServices caps the catalog size on reloads and additions, 0 means unlimited
+	maxServices int
+	// shard, when set, limits ListServices and GetService to the services owned by the local shard
+	shard *model.Store
+
+	// searchMinLength rejects shorter search queries, 0 disables the check
+	searchMinLength int
+	// searchWildcard enables "pay*" style prefix matching on service names
+	searchWildcard bool
+	// searchFields and searchMatch are the defaults for requests that leave search_fields and search_match empty
+	searchFields string
+	searchMatch  string
+	// strictSort rejects unrecognized sort_by/sort_order values instead of falling back to defaults
+	strictSort bool
+	// defaultSortBy and defaultSortOrder sort listings that leave sort_by and sort_order empty, see resolveSort
+	defaultSortBy    string
+	defaultSortOrder string
+	// maxListResults caps the services in one ListServices response below the page size, 0 disables the cap
+	maxListResults int
+	// requireHTTPSURLs rejects added services whose url is set but is not an absolute https URL
+	requireHTTPSURLs bool
+	// requireKnownOrgs rejects added services of an organization missing from a non-empty organizations list
+	requireKnownOrgs bool
+	// nameNormalization is applied to the name and description of added services, "" trims them
+	nameNormalization model.NameNormalization
+	// futureTimestamps handles added services with timestamps later than now plus timestampSkew like the
+	// data file loader does, "" ignores them
+	futureTimestamps model.FutureTimestampPolicy
+	timestampSkew    time.Duration
+
+	// serviceIDFormat and orgIDFormat validate IDs in requests, the zero value means DefaultIDFormat
+	serviceIDFormat IDFormat
+	orgIDFormat     IDFormat
+	// retryDelay is the retry hint of transient errors, 0 means DefaultRetryDelay
+	retryDelay time.Duration
+	// events notifies subscribers of changes to the catalog
+	events eventBus
+	// changes numbers the changes to the catalog for ListServicesDelta
+	changes changeLog
+	// idGenerator assigns IDs to added services and versions without one, nil means defaultIDGenerator
+	idGenerator idgen.Generator
+	// crossOrgPolicy answers requests for services of another organization, "" means CrossOrgHide
+	crossOrgPolicy CrossOrgPolicy
+	// defaultOrganization filters ListServices of unauthenticated callers that set no organization_id
+	defaultOrganization string
+	// anonymousOrgs are the only organizations whose services unauthenticated callers can read, nil means all
+	anonymousOrgs map[string]bool
+	// fieldLimits are the request field limits keyed like DefaultFieldLimits, nil means DefaultFieldLimits
+	fieldLimits map[string]int
+	// anonymousWrites lets unauthenticated callers use the write RPCs, which otherwise need an admin
+	anonymousWrites bool
+	// written holds the services changed by write RPCs, merged into reloaded catalogs until the data file
+	// catches up with them; guarded by writeMu
+	written map[string]*model.Service
+}
+
+// defaultIDGenerator is shared by every catalog service without an ID generator option,
+// so IDs generated in one process never collide
+var defaultIDGenerator = idgen.NewULID()
+
+// IDFormat describes the accepted shape of an ID in requests
+type IDFormat struct {
+	// Pattern the whole ID must match
+	Pattern *regexp.Regexp
+	// MaxLength is the maximum ID length in bytes
+	MaxLength int
+}
+
+// DefaultIDFormat accepts alphanumerics, hyphens and underscores, up to 50 characters
+var DefaultIDFormat = IDFormat{
+	Pattern:   regexp.MustCompile(`^[A-Za-z0-9_-]+$`),
+	MaxLength: 50,
+}
+
+// Valid reports whether id is non-empty, within MaxLength and matches Pattern
+func (f IDFormat) Valid(id string) bool {
+	if id == "" || len(id) > f.MaxLength {
+		return false
+	}
+	return f.Pattern.MatchString(id)
+}
+
+// orDefault returns DefaultIDFormat for an unset format
+func (f IDFormat) orDefault() IDFormat {
+	if f.Pattern == nil {
+		return DefaultIDFormat
+	}
+	return f
+}
+
+// Option configures optional CatalogService behavior
+type Option func(*CatalogService)
+
+// WithSearchMinLength rejects search queries shorter than n characters
+func WithSearchMinLength(n int) Option {
+	return func(c *CatalogService) {
+		c.searchMinLength = n
+	}
+}
+
+// WithSearchWildcard enables trailing-wildcard prefix matching, e.g. "pay*" matches names starting with "pay"
+func WithSearchWildcard(enabled bool) Option {
+	return func(c *CatalogService) {
+		c.searchWildcard = enabled
+	}
+}
+
+// WithSearchFields sets the fields searched when a request leaves search_fields empty, e.g. SearchFieldsName
+func WithSearchFields(fields string) Option {
+	return func(c *CatalogService) {
+		c.searchFields = fields
+	}
+}
+
+// WithSearchMatch sets how search terms combine when a request leaves search_match empty, SearchMatchAll or SearchMatchAny
+func WithSearchMatch(match string) Option {
+	return func(c *CatalogService) {
+		c.searchMatch = match
+	}
+}
+
+// WithDefaultSort sets the sort field and order of listings that leave sort_by or sort_order empty,
+// DefaultSortBy and DefaultSortOrder otherwise. Empty or unrecognized values keep those defaults.
+func WithDefaultSort(sortBy, sortOrder string) Option {
+	return func(c *CatalogService) {
+		if validSortFields[sortBy] {
+			c.defaultSortBy = sortBy
+		}
+		if validSortOrders[sortOrder] {
+			c.defaultSortOrder = sortOrder
+		}
+	}
+}
+
+// WithStrictSort rejects unrecognized sort_by and sort_order values with codes.InvalidArgument
+// instead of silently falling back to "name" and "asc"
+func WithStrictSort(enabled bool) Option {
+	return func(c *CatalogService) {
+		c.strictSort = enabled
+	}
+}
+
+// WithMaxListResults returns at most n services per ListServices response whatever the page size,
+// later results follow through next_page_token; 0 leaves page_size as the only limit
+func WithMaxListResults(n int) Option {
+	return func(c *CatalogService) {
+		c.maxListResults = n
+	}
+}
+
+// WithAuditLogSize keeps the last n catalog changes for ListAuditEvents, 0 disables the audit log
+func WithAuditLogSize(n int) Option {
+	return func(c *CatalogService) {
+		c.audit = newAuditLog(n)
+	}
+}
+
+// WithRequireHTTPSURLs rejects services added with a url that is not an absolute https URL
+func WithRequireHTTPSURLs(enabled bool) Option {
+	return func(c *CatalogService) {
+		c.requireHTTPSURLs = enabled
+	}
+}
+
+// WithRequireKnownOrganizations rejects services added with an organization_id that is not listed among the
+// organizations, when any are listed, so free-form organizations keep working without a list
+func WithRequireKnownOrganizations(enabled bool) Option {
+	return func(c *CatalogService) {
+		c.requireKnownOrgs = enabled
+	}
+}
+
+// WithFutureTimestamps sets how added services with a created_at or updated_at later than now plus skew
+// are handled, matching the FUTURE_TIMESTAMPS policy of the data file
+func WithFutureTimestamps(policy model.FutureTimestampPolicy, skew time.Duration) Option {
+	return func(c *CatalogService) {
+		c.futureTimestamps = policy
+		c.timestampSkew = skew
+	}
+}
+
+// WithNameNormalization sets how whitespace in the names and descriptions of added services is normalized
+func WithNameNormalization(n model.NameNormalization) Option {
+	return func(c *CatalogService) {
+		c.nameNormalization = n
+	}
+}
+
+// WithServiceIDFormat validates service IDs in requests against the given pattern and maximum length
+func WithServiceIDFormat(pattern *regexp.Regexp, maxLength int) Option {
+	return func(c *CatalogService) {
+		c.serviceIDFormat = IDFormat{Pattern: pattern, MaxLength: maxLength}
+	}
+}
+
+// WithOrganizationIDFormat validates organization IDs in requests against the given pattern and maximum length
+func WithOrganizationIDFormat(pattern *regexp.Regexp, maxLength int) Option {
+	return func(c *CatalogService) {
+		c.orgIDFormat = IDFormat{Pattern: pattern, MaxLength: maxLength}
+	}
+}
+
+// WithRetryDelay sets how long clients are told to wait before retrying after a transient error
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *CatalogService) {
+		c.retryDelay = d
+	}
+}
+
+// WithMaxSnapshots caps the ListServices snapshots kept at once, 0 removes the cap
+func WithMaxSnapshots(n int) Option {
+	return func(c *CatalogService) {
+		if c.snapshots != nil {
+			c.snapshots.maxCount = n
+		}
+	}
+}
+
+// WithAnonymousWrites lets unauthenticated callers, which only reach the service when auth is disabled,
+// use the write RPCs
+func WithAnonymousWrites(allow bool) Option {
+	return func(c *CatalogService) {
+		c.anonymousWrites = allow
+	}
+}
+
+// WithIdempotencyTTL sets how long CreateServices responses are replayed to retries with the same
+// idempotency key, 0 ignores idempotency keys
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(c *CatalogService) {
+		maxCount := DefaultIdempotencyMaxKeys
+		if c.idempotency != nil {
+			maxCount = c.idempotency.maxCount
+		}
+		c.idempotency = nil
+		if ttl > 0 {
+			c.idempotency = newIdempotencyStore(ttl)
+			c.idempotency.maxCount = maxCount
+		}
+	}
+}
+
+// WithIdempotencyMaxKeys caps the idempotency keys kept at once, the oldest is evicted to make room;
+// 0 removes the cap
+func WithIdempotencyMaxKeys(n int) Option {
+	return func(c *CatalogService) {
+		if c.idempotency != nil {
+			c.idempotency.maxCount = n
+		}
+	}
+}
+
+// WithIDGenerator sets how IDs are generated for added services and versions that have none
+func WithIDGenerator(g idgen.Generator) Option {
+	return func(c *CatalogService) {
+		c.idGenerator = g
+	}
+}
+
+// CrossOrgPolicy is how a request for a service of another organization than the caller's is answered
+type CrossOrgPolicy string
+
+const (
+	// CrossOrgHide answers NotFound, exactly like a missing service, so other organizations' IDs stay secret
+	CrossOrgHide CrossOrgPolicy = "hide"
+	// CrossOrgDeny answers PermissionDenied, telling the caller the service exists but is not theirs
+	CrossOrgDeny CrossOrgPolicy = "deny"
+)
+
+// WithCrossOrgPolicy sets how GetService, GetServiceVersions and TouchService answer authenticated callers
+// asking for another organization's service, "" means CrossOrgHide
+func WithCrossOrgPolicy(policy CrossOrgPolicy) Option {
+	return func(c *CatalogService) {
+		c.crossOrgPolicy = policy
+	}
+}
+
+// WithDefaultOrganization scopes ListServices requests without JWT claims and without an organization_id
+// to the given organization, empty leaves them unrestricted
+func WithDefaultOrganization(org string) Option {
+	return func(c *CatalogService) {
+		c.defaultOrganization = org
+	}
+}
+
+// WithAnonymousOrganizations lets requests without JWT claims read only the services of the given organizations:
+// listings, searches, statistics and delta syncs leave the others out, and lookups by ID report them as not
+// found. Empty leaves anonymous reads unrestricted.
+func WithAnonymousOrganizations(orgs []string) Option {
+	return func(c *CatalogService) {
+		if len(orgs) == 0 {
+			c.anonymousOrgs = nil
+			return
+		}
+		c.anonymousOrgs = make(map[string]bool, len(orgs))
+		for _, orgID := range orgs {
+			c.anonymousOrgs[orgID] = true
+		}
+	}
+}
+
+// WithFieldLimits sets the request field limits keyed like DefaultFieldLimits, replacing the defaults;
+// a missing or non-positive limit disables the check, nil keeps the defaults
+func WithFieldLimits(limits map[string]int) Option {
+	return func(c *CatalogService) {
+		c.fieldLimits = limits
+	}
+}
+
+// NewCatalogService initializes a new CatalogService with the local store, adopting its size limit and,
+// when the store is sharded, serving only the local shard's services from ListServices and GetService
+func NewCatalogService(store *model.Store, opts ...Option) *CatalogService {
+	c := &CatalogService{
+		snapshots:        newSnapshotStore(DefaultSnapshotTTL),
+		idempotency:      newIdempotencyStore(DefaultIdempotencyTTL),
+		maxServices:      store.MaxServices(),
+		audit:            newAuditLog(DefaultAuditLogSize),
+		defaultSortBy:    DefaultSortBy,
+		defaultSortOrder: DefaultSortOrder,
+	}
+	if store.ShardCount() > 1 {
+		c.shard = store
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.ReplaceOrganizations(store.ListOrganizations())
+	// The store already enforced its own limit
+	_ = c.ReplaceServices(store.ListServices())
+	return c
+}
+
+// ReplaceOrganizations atomically swaps the organization display names for those of orgs.
+// Organizations without a display name are shown by ID.
+func (c *CatalogService) ReplaceOrganizations(orgs []*model.Organization) {
+	names := model.OrganizationNames(orgs)
+	ids := model.OrganizationIDs(orgs)
+	c.orgNames.Store(&names)
+	c.orgIDs.Store(&ids)
+}
+
+// checkKnownOrganization rejects a service of an unlisted organization when known organizations are required
+func (c *CatalogService) checkKnownOrganization(service *model.Service) error {
+	if !c.requireKnownOrgs {
+		return nil
+	}
+	var known map[string]bool
+	if ids := c.orgIDs.Load(); ids != nil {
+		known = *ids
+	}
+	if err := service.CheckKnownOrganization(known); err != nil {
+		return newInvalidArgumentError(ReasonUnknownOrganization, "%v", err)
+	}
+	return nil
+}
+
+// organizationName returns the display name of an organization, its ID when it has none
+func (c *CatalogService) organizationName(orgID string) string {
+	if names := c.orgNames.Load(); names != nil {
+		if name, ok := (*names)[orgID]; ok {
+			return name
+		}
+	}
+	return orgID
 }
 
-// NewCatalogService initializes a new CatalogService with the local store
-func NewCatalogService(store *model.Store) *CatalogService {
-	data := make(map[string]*model.Service)
-	for _, s := range store.ListServices() {
+// ReplaceServices atomically swaps the served catalog for the given services.
+// Requests already running keep reading the previous catalog; later requests see the new one.
+// Services changed by write RPCs are kept over the given ones until a given service is updated at least as
+// recently, so reloading a data file that does not carry the writes yet does not discard them.
+// More services than the size limit fail with ResourceExhausted and leave the catalog and the kept writes unchanged.
+// Every service created, updated or deleted by the swap is published to subscribers and ListServicesDelta.
+func (c *CatalogService) ReplaceServices(services []*model.Service) error {
+	data := make(map[string]*model.Service, len(services))
+	for _, s := range services {
 		data[s.ID] = s
+		warnUnparseableVersions(s)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	superseded := c.mergeWritten(data)
+	if c.maxServices > 0 && len(data) > c.maxServices {
+		return newStoreFullError(len(data), c.maxServices)
+	}
+	for _, id := range superseded {
+		delete(c.written, id)
+	}
+	kept := len(c.written)
+	previous := c.catalog()
+	c.data.Store(&data)
+	// Still holding the write lock so events are delivered in the order of the changes
+	c.publishChanges(catalogDiff(previous, data, time.Now().UTC())...)
+
+	logger.Get().Infow("Catalog data replaced", "services_count", len(data), "kept_written_services", kept)
+	c.audit.record(auditActorSystem, AuditActionReplaceCatalog, "", fmt.Sprintf("loaded %d services", len(data)))
+	return nil
+}
+
+// mergeWritten puts the services changed by write RPCs into data unless data holds a version of the service
+// updated at the same time or later, which replaces the write for good once data is stored. It returns the
+// IDs of those superseded writes, leaving them to the caller to drop. The caller must hold writeMu.
+func (c *CatalogService) mergeWritten(data map[string]*model.Service) []string {
+	var superseded []string
+	for id, svc := range c.written {
+		if loaded, ok := data[id]; ok && !loaded.UpdatedAt.Before(svc.UpdatedAt.Time) {
+			superseded = append(superseded, id)
+			continue
+		}
+		data[id] = svc
+	}
+	return superseded
+}
+
+// keepWritten records services changed by a write RPC so reloads keep them, the caller must hold writeMu
+func (c *CatalogService) keepWritten(services ...*model.Service) {
+	if c.written == nil {
+		c.written = make(map[string]*model.Service, len(services))
+	}
+	for _, svc := range services {
+		c.written[svc.ID] = svc
+	}
+}
+
+// PutService adds the service to the catalog, replacing any service with the same ID.
+// It copies the current catalog and publishes the copy, so concurrent readers are never blocked
+// and never observe a map being written. Adding a new service past the size limit fails with ResourceExhausted,
+// and with https-only URLs required, a service with any other url fails with InvalidArgument, as does one of an
+// unlisted organization with known organizations required.
+// A service or version without an ID is assigned a generated one. The name and description are normalized
+// (see WithNameNormalization) before a service left without a name fails with InvalidArgument.
+// Subscribers are notified of the change.
+func (c *CatalogService) PutService(service *model.Service) error {
+	if err := c.assignIDs(service); err != nil {
+		return err
+	}
+	service.Normalize(c.nameNormalization)
+	if err := service.CheckName(); err != nil {
+		return newInvalidArgumentError(ReasonMissingName, "%v", err)
+	}
+	if c.requireHTTPSURLs {
+		if err := service.CheckHTTPSURL(); err != nil {
+			return newInvalidArgumentError(ReasonInvalidURL, "%v", err)
+		}
+	}
+	if err := c.checkKnownOrganization(service); err != nil {
+		return err
+	}
+	warnUnparseableVersions(service)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	current := c.catalog()
+	previous, exists := current[service.ID]
+	if !exists && c.maxServices > 0 && len(current) >= c.maxServices {
+		return newStoreFullError(len(current)+1, c.maxServices)
 	}
-	return &CatalogService{data: data}
+
+	data := make(map[string]*model.Service, len(current)+1)
+	for id, s := range current {
+		data[id] = s
+	}
+	data[service.ID] = service
+	c.data.Store(&data)
+
+	c.audit.record(auditActorSystem, AuditActionPutService, service.ID, "")
+	event := Event{Type: EventServiceCreated, ServiceID: service.ID, Service: service, Time: time.Now().UTC()}
+	if exists {
+		event.Type = EventServiceUpdated
+		event.previousOrganizationID = previous.OrganizationID
+	}
+	c.publishChanges(event)
+	return nil
+}
+
+// publishChanges records published catalog changes for ListServicesDelta and notifies subscribers of them.
+// It is called under the write lock after the changed catalog was stored.
+func (c *CatalogService) publishChanges(events ...Event) {
+	c.changes.record(events...)
+	c.events.publish(events...)
+}
+
+// assignIDs generates the IDs missing from a service and its versions, and links versions to the service.
+// A generated service ID the configured service ID format rejects fails with InvalidArgument.
+func (c *CatalogService) assignIDs(service *model.Service) error {
+	gen := c.idGenerator
+	if gen == nil {
+		gen = defaultIDGenerator
+	}
+
+	if service.ID == "" {
+		service.ID = gen.NewID()
+		if !c.isValidID(service.ID) {
+			return newInvalidArgumentError(ReasonInvalidID, "generated service ID %q does not match the configured service ID format", service.ID)
+		}
+	}
+	for _, v := range service.Versions {
+		if v.ID == "" {
+			v.ID = gen.NewID()
+		}
+		if v.ServiceID == "" {
+			v.ServiceID = service.ID
+		}
+	}
+	return nil
 }
 
-// ListServices returns a paginated list of services based on the request parameters
+// Services returns the services served by this shard ordered by ID. They must not be modified.
+func (c *CatalogService) Services() []*model.Service {
+	return c.localServices(c.getAllServices())
+}
+
+// checkAvailable returns codes.Unavailable with a retry hint until the catalog has been loaded
+func (c *CatalogService) checkAvailable() error {
+	if c.data.Load() == nil {
+		retryDelay := c.retryDelay
+		if retryDelay <= 0 {
+			retryDelay = DefaultRetryDelay
+		}
+		return newUnavailableError(ReasonCatalogLoading, retryDelay, "catalog is loading, retry shortly")
+	}
+	return nil
+}
+
+// ListServices returns a paginated list of services based on the request parameters.
+// Authenticated callers only list their own organization, asking for another organization is handled per
+// WithCrossOrgPolicy: nothing is listed, or the request fails with PermissionDenied.
 func (c *CatalogService) ListServices(ctx context.Context, req *v1.ListServicesRequest) (*v1.ListServicesResponse, error) {
 	logger.Get().Infow("ListServices called",
 		"page_size", req.GetPageSize(),
 		"page_token", req.GetPageToken(),
-		"organization_id", req.GetOrganizationId(),
-		"search_query", req.GetSearchQuery(),
+		"organization_id", logger.Redact("organization_id", req.GetOrganizationId()),
+		"organization_ids", logger.Redact("organization_ids", req.GetOrganizationIds()),
+		"search_query", logger.Redact("search_query", req.GetSearchQuery()),
 		"sort_by", req.GetSortBy(),
-		"sort_order", req.GetSortOrder())
+		"sort_order", req.GetSortOrder(),
+		"version", logger.Redact("version", req.GetVersion()),
+		"snapshot", req.GetSnapshot(),
+		"ids_only", req.GetIdsOnly())
 
 	// Check context cancellation
-	if ctx.Err() != nil {
-		return nil, status.Error(codes.Canceled, "request cancelled")
+	if err := contextError(ctx); err != nil {
+		return nil, err
 	}
 
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// Anonymous callers are scoped to the default organization unless they filter by one themselves
+	req = c.applyDefaultOrganization(ctx, req)
+
 	// validate request parameters
 	if err := c.validateListServicesRequest(req); err != nil {
 		return nil, err
 	}
 
-	// fetch all services from the store
-	services := c.getAllServices()
+	// Authenticated callers only list their own organization, like GetService and ExportServices
+	req, visible, err := c.applyCallerOrganization(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		resp := &v1.ListServicesResponse{}
+		if req.GetSkipTotalCount() {
+			resp.TotalCount = -1
+		}
+		return resp, nil
+	}
+
+	// Snapshots are per client, so only plain listings are shared between concurrent identical requests
+	if key, ok := listServicesKey(req); ok && !req.GetSnapshot() {
+		// anonymous callers may see fewer services, so they only share listings among themselves
+		if c.anonymousOrganizations(ctx) != nil {
+			key = "anonymous/" + key
+		}
+		resp, err := c.flights.do(ctx, "ListServices", key, func(ctx context.Context) (proto.Message, error) {
+			return c.listServices(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*v1.ListServicesResponse), nil
+	}
+	return c.listServices(ctx, req)
+}
+
+// listServices lists the services matching a validated request
+func (c *CatalogService) listServices(ctx context.Context, req *v1.ListServicesRequest) (*v1.ListServicesResponse, error) {
+	// later pages of a snapshot read the frozen results, ignoring the live catalog
+	if isSnapshotToken(req.GetPageToken()) {
+		id, services, startIndex, err := c.getSnapshotPage(req.GetPageToken())
+		if err != nil {
+			return nil, err
+		}
+		return c.paginateSnapshot(id, services, startIndex, c.listPageSize(req.GetPageSize()), req.GetIdsOnly())
+	}
+
+	// fetch all services owned by this shard and visible to the caller from the store
+	services := c.anonymousServices(ctx, c.localServices(c.getAllServices()))
 	logger.Get().Debugw("Initial services count", "count", len(services))
 
+	if req.GetSkipTotalCount() && !req.GetSnapshot() {
+		return c.listServicesWithoutCount(ctx, services, req)
+	}
+
 	// filter services based on request parameters
-	services = c.filterServices(services, req)
+	services, err := c.filterServices(ctx, services, req)
+	if err != nil {
+		return nil, err
+	}
 	logger.Get().Debugw("Services after filtering", "count", len(services))
 
 	// sort results to ensure consistent ordering
-	c.sortServices(services, req.GetSortBy(), req.GetSortOrder())
+	sortBy, sortOrder, err := c.resolveSort(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.sortServices(ctx, services, sortBy, sortOrder); err != nil {
+		return nil, err
+	}
 
 	// paginate results to handle large datasets
-	pageSize := c.getPageSize(req.GetPageSize())
+	pageSize := c.listPageSize(req.GetPageSize())
+
+	// freeze the results so later pages are unaffected by catalog changes
+	if req.GetSnapshot() && req.GetPageToken() == "" {
+		if c.snapshots == nil {
+			return nil, newError(codes.FailedPrecondition, ReasonSnapshotsDisabled, ErrInvalidRequest, "snapshots are not enabled")
+		}
+		id, err := c.snapshots.put(services)
+		if err != nil {
+			return nil, err
+		}
+		return c.paginateSnapshot(id, services, 0, pageSize, req.GetIdsOnly())
+	}
+
 	startIndex, err := c.getStartIndex(req.GetPageToken(), pageSize, len(services))
 	if err != nil {
 		return nil, err
 	}
 
-	return c.paginateServices(services, startIndex, pageSize)
+	return c.paginateServices(services, startIndex, pageSize, req.GetIdsOnly())
 }
 
-// GetService returns a specific service by ID
-func (c *CatalogService) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1.GetServiceResponse, error) {
-	logger.Get().Infow("GetService called", "service_id", req.GetId())
+// listServicesWithoutCount filters before sorting and only orders the requested page and one lookahead match,
+// returning total_count -1 instead of counting every match
+func (c *CatalogService) listServicesWithoutCount(ctx context.Context, services []*model.Service, req *v1.ListServicesRequest) (*v1.ListServicesResponse, error) {
+	sortBy, sortOrder, err := c.resolveSort(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := c.listPageSize(req.GetPageSize())
+	startIndex, err := parsePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	services, err = c.filterServices(ctx, services, req)
+	if err != nil {
+		return nil, err
+	}
+	if startIndex > 0 && startIndex >= len(services) {
+		return nil, newInvalidArgumentError(ReasonPageTokenOutOfRange, "page token out of range")
+	}
+
+	// the lookahead match tells whether a next page exists
+	services, err = c.firstServices(ctx, services, startIndex+int(pageSize)+1, sortBy, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.paginateServices(services, int32(startIndex), pageSize, req.GetIdsOnly())
+	if err != nil {
+		return nil, err
+	}
+	resp.TotalCount = -1
+	return resp, nil
+}
+
+// CountServices returns the number of services ListServices would report as total_count for the same filters,
+// with the same organization scoping, without sorting, paginating or converting them
+func (c *CatalogService) CountServices(ctx context.Context, req *v1.CountServicesRequest) (*v1.CountServicesResponse, error) {
+	logger.Get().Infow("CountServices called",
+		"organization_id", logger.Redact("organization_id", req.GetOrganizationId()),
+		"search_query", logger.Redact("search_query", req.GetSearchQuery()),
+		"version", logger.Redact("version", req.GetVersion()))
 
 	// Check context cancellation
-	if ctx.Err() != nil {
-		return nil, status.Error(codes.Canceled, "request cancelled")
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// The filters are those of ListServices, so they are validated and applied as a list request
+	listReq := c.applyDefaultOrganization(ctx, &v1.ListServicesRequest{
+		OrganizationId: req.GetOrganizationId(),
+		SearchQuery:    req.GetSearchQuery(),
+		Version:        req.GetVersion(),
+		SearchFields:   req.GetSearchFields(),
+		SearchMatch:    req.GetSearchMatch(),
+	})
+	if err := c.validateListServicesRequest(listReq); err != nil {
+		return nil, err
+	}
+	listReq, visible, err := c.applyCallerOrganization(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return &v1.CountServicesResponse{}, nil
+	}
+
+	services, err := c.filterServices(ctx, c.anonymousServices(ctx, c.localServices(c.getAllServices())), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Get().Infow("CountServices completed successfully", "count", len(services))
+	return &v1.CountServicesResponse{Count: int32(len(services))}, nil
+}
+
+// GetService returns a specific service by ID. Authenticated callers asking for a service of another
+// organization get NotFound or PermissionDenied, see WithCrossOrgPolicy, and anonymous callers get NotFound
+// for services outside WithAnonymousOrganizations.
+func (c *CatalogService) GetService(ctx context.Context, req *v1.GetServiceRequest) (*v1.GetServiceResponse, error) {
+	logger.Get().Infow("GetService called", "service_id", req.GetId())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateGetServiceRequest(req); err != nil {
+		return nil, err
+	}
+
+	// services on other shards are served by their own nodes
+	if err := c.checkLocalShard(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	// concurrent requests for the same service share one lookup and conversion
+	resp, err := c.flights.do(ctx, "GetService", req.GetId(), func(ctx context.Context) (proto.Message, error) {
+		svc, err := c.getServiceByID(req.GetId())
+		if err != nil {
+			return nil, err
+		}
+		return &v1.GetServiceResponse{Service: c.toProtoService(svc)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the shared lookup may serve callers of any organization, so each is checked on its own
+	service := resp.(*v1.GetServiceResponse).GetService()
+	if err := c.checkOrganizationAccess(ctx, service.GetId(), service.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+
+	logger.Get().Infow("GetService completed successfully", "service_id", req.GetId())
+	return resp.(*v1.GetServiceResponse), nil
+}
+
+// BatchGetServices returns the requested services that exist, in request order, and the IDs that don't.
+// Services on other shards are reported as missing. Services of another organization are checked like in
+// GetService: hidden ones are reported as missing and denied ones as per-item errors.
+func (c *CatalogService) BatchGetServices(ctx context.Context, req *v1.BatchGetServicesRequest) (*v1.BatchGetServicesResponse, error) {
+	logger.Get().Infow("BatchGetServices called", "ids", req.GetIds())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	ids, err := c.validateBatchGetServicesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := c.catalog()
+	resp := &v1.BatchGetServicesResponse{}
+	for _, id := range ids {
+		svc, ok := data[id]
+		if !ok || c.checkLocalShard(id) != nil {
+			resp.MissingIds = append(resp.MissingIds, id)
+			continue
+		}
+		if err := c.checkOrganizationAccess(ctx, id, svc.OrganizationID); err != nil {
+			if status.Code(err) == codes.NotFound {
+				resp.MissingIds = append(resp.MissingIds, id)
+			} else {
+				resp.Errors = append(resp.Errors, batchGetServiceError(id, err))
+			}
+			continue
+		}
+		resp.Services = append(resp.Services, c.toProtoService(svc))
+	}
+
+	logger.Get().Infow("BatchGetServices completed successfully",
+		"found_count", len(resp.Services),
+		"missing_count", len(resp.MissingIds),
+		"error_count", len(resp.Errors))
+
+	return resp, nil
+}
+
+// batchGetServiceError reports err as the failure to return the service with the given ID
+func batchGetServiceError(id string, err error) *v1.BatchGetServiceError {
+	st, _ := status.FromError(err)
+	return &v1.BatchGetServiceError{
+		Id:           id,
+		ErrorCode:    code.Code(st.Code()).String(),
+		ErrorReason:  string(ReasonOf(err)),
+		ErrorMessage: st.Message(),
+	}
+}
+
+// GetServiceVersions returns all versions of a specific service, scoped to the caller's organization and the
+// local shard like GetService
+func (c *CatalogService) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersionsRequest) (*v1.GetServiceVersionsResponse, error) {
+	logger.Get().Infow("GetServiceVersions called", "service_id", req.GetServiceId())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateGetServiceVersionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	// services on other shards are served by their own nodes
+	if err := c.checkLocalShard(req.GetServiceId()); err != nil {
+		return nil, err
+	}
+
+	// get service by ID
+	svc, err := c.getServiceByID(req.GetServiceId())
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkOrganizationAccess(ctx, svc.ID, svc.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	versions := convertVersionsToProto(svc.Versions)
+
+	logger.Get().Infow("GetServiceVersions completed successfully",
+		"service_id", req.GetServiceId(),
+		"versions_count", len(versions))
+
+	return &v1.GetServiceVersionsResponse{Versions: versions}, nil
+}
+
+// StreamServiceVersions sends the versions of a service matching the filters in chunks of chunk_size,
+// in data file order. It stops with the context's error once the client cancels or the deadline passes.
+// Services of another organization or shard are answered like in GetService.
+func (c *CatalogService) StreamServiceVersions(req *v1.StreamServiceVersionsRequest, stream v1.CatalogService_StreamServiceVersionsServer) error {
+	logger.Get().Infow("StreamServiceVersions called",
+		"service_id", req.GetServiceId(),
+		"updated_after", req.GetUpdatedAfter().AsTime(),
+		"is_active", req.GetIsActive(),
+		"chunk_size", req.GetChunkSize())
+	ctx := stream.Context()
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return err
+	}
+
+	// validate request parameters
+	if err := c.validateStreamServiceVersionsRequest(req); err != nil {
+		return err
+	}
+
+	// services on other shards are served by their own nodes
+	if err := c.checkLocalShard(req.GetServiceId()); err != nil {
+		return err
+	}
+
+	svc, err := c.getServiceByID(req.GetServiceId())
+	if err != nil {
+		return err
+	}
+	if err := c.checkOrganizationAccess(ctx, svc.ID, svc.OrganizationID); err != nil {
+		return err
+	}
+
+	chunkSize := int(req.GetChunkSize())
+	if chunkSize == 0 {
+		chunkSize = DefaultVersionChunkSize
+	}
+
+	// send delivers the collected chunk, checking first that the client is still listening
+	var chunk []*model.ServiceVersion
+	sent, chunks := 0, 0
+	send := func() error {
+		if err := contextError(ctx); err != nil {
+			return err
+		}
+		if err := stream.Send(&v1.StreamServiceVersionsResponse{Versions: convertVersionsToProto(chunk)}); err != nil {
+			return err
+		}
+		sent += len(chunk)
+		chunks++
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for _, v := range svc.Versions {
+		if !versionMatches(v, req.GetUpdatedAfter(), req.IsActive) {
+			continue
+		}
+		chunk = append(chunk, v)
+		if len(chunk) == chunkSize {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		if err := send(); err != nil {
+			return err
+		}
+	}
+
+	logger.Get().Infow("StreamServiceVersions completed successfully",
+		"service_id", req.GetServiceId(),
+		"versions_count", sent,
+		"chunks_count", chunks)
+	return nil
+}
+
+// DiffServices compares two services, or two versions of one service, and returns the fields whose values
+// differ. IDs are not compared, so a service or version compared with itself has no differences.
+// Services are compared on their own fields and the version strings they list; use the version form to
+// compare the fields of two versions. Both services are checked like in GetService, so a service of another
+// organization cannot be read through a diff.
+func (c *CatalogService) DiffServices(ctx context.Context, req *v1.DiffServicesRequest) (*v1.DiffServicesResponse, error) {
+	logger.Get().Infow("DiffServices called",
+		"service_id", req.GetServiceId(),
+		"other_service_id", req.GetOtherServiceId(),
+		"base_version_id", req.GetBaseVersionId(),
+		"target_version_id", req.GetTargetVersionId())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateDiffServicesRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Both services are read from the same catalog version
+	catalog := c.catalog()
+	lookup := func(id string) (*model.Service, error) {
+		if err := c.checkLocalShard(id); err != nil {
+			return nil, err
+		}
+		svc, ok := catalog[id]
+		if !ok {
+			return nil, newNotFoundError(ReasonServiceNotFound, ErrServiceNotFound, "service with ID '%s' not found", id)
+		}
+		if err := c.checkOrganizationAccess(ctx, svc.ID, svc.OrganizationID); err != nil {
+			return nil, err
+		}
+		return svc, nil
+	}
+
+	base, err := lookup(req.GetServiceId())
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []*v1.FieldDiff
+	if req.GetOtherServiceId() != "" {
+		target, err := lookup(req.GetOtherServiceId())
+		if err != nil {
+			return nil, err
+		}
+		differences = diffServices(base, target)
+	} else {
+		baseVersion, err := findVersion(base, req.GetBaseVersionId())
+		if err != nil {
+			return nil, err
+		}
+		targetVersion, err := findVersion(base, req.GetTargetVersionId())
+		if err != nil {
+			return nil, err
+		}
+		differences = diffVersions(baseVersion, targetVersion)
+	}
+
+	logger.Get().Infow("DiffServices completed successfully",
+		"service_id", req.GetServiceId(),
+		"differences_count", len(differences))
+
+	return &v1.DiffServicesResponse{Differences: differences}, nil
+}
+
+// findVersion returns the version of svc with the given version ID
+func findVersion(svc *model.Service, versionID string) (*model.ServiceVersion, error) {
+	for _, v := range svc.Versions {
+		if v.ID == versionID {
+			return v, nil
+		}
+	}
+	return nil, newNotFoundError(ReasonVersionNotFound, ErrVersionNotFound, "version with ID '%s' not found in service '%s'", versionID, svc.ID)
+}
+
+// fieldDiffer collects the fields whose formatted values differ, in the order they are compared
+type fieldDiffer []*v1.FieldDiff
+
+func (d *fieldDiffer) compare(field, base, target string) {
+	if base != target {
+		*d = append(*d, &v1.FieldDiff{Field: field, Base: base, Target: target})
+	}
+}
+
+// diffServices compares the fields of two services, in Service message order. Versions are compared as the
+// list of their version strings.
+func diffServices(base, target *model.Service) []*v1.FieldDiff {
+	var d fieldDiffer
+	d.compare("name", base.Name, target.Name)
+	d.compare("description", base.Description, target.Description)
+	d.compare("organization_id", base.OrganizationID, target.OrganizationID)
+	d.compare("url", base.URL, target.URL)
+	d.compare("created_at", formatDiffTime(base.CreatedAt.Time), formatDiffTime(target.CreatedAt.Time))
+	d.compare("updated_at", formatDiffTime(base.UpdatedAt.Time), formatDiffTime(target.UpdatedAt.Time))
+	d.compare("versions", versionList(base), versionList(target))
+	return d
+}
+
+// diffVersions compares the fields of two versions, in ServiceVersion message order
+func diffVersions(base, target *model.ServiceVersion) []*v1.FieldDiff {
+	var d fieldDiffer
+	d.compare("version", base.Version, target.Version)
+	d.compare("description", base.Description, target.Description)
+	d.compare("is_active", strconv.FormatBool(base.IsActive), strconv.FormatBool(target.IsActive))
+	d.compare("created_at", formatDiffTime(base.CreatedAt.Time), formatDiffTime(target.CreatedAt.Time))
+	d.compare("updated_at", formatDiffTime(base.UpdatedAt.Time), formatDiffTime(target.UpdatedAt.Time))
+	return d
+}
+
+// formatDiffTime formats a timestamp for a field diff, "" when unset
+func formatDiffTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// versionList lists the version strings of a service in data file order, comma-separated
+func versionList(svc *model.Service) string {
+	versions := make([]string, 0, len(svc.Versions))
+	for _, v := range svc.Versions {
+		versions = append(versions, v.Version)
+	}
+	return strings.Join(versions, ", ")
+}
+
+// GetServiceHistory returns the versions of a service as a timeline sorted by created_at, oldest first,
+// with the gap between consecutive releases. Versions created at the same instant keep their order
+// in the data file, so the timeline is stable across calls. Services of another organization or shard are
+// answered like in GetService.
+func (c *CatalogService) GetServiceHistory(ctx context.Context, req *v1.GetServiceHistoryRequest) (*v1.GetServiceHistoryResponse, error) {
+	logger.Get().Infow("GetServiceHistory called", "service_id", req.GetServiceId())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateGetServiceHistoryRequest(req); err != nil {
+		return nil, err
+	}
+
+	// services on other shards are served by their own nodes
+	if err := c.checkLocalShard(req.GetServiceId()); err != nil {
+		return nil, err
+	}
+
+	svc, err := c.getServiceByID(req.GetServiceId())
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkOrganizationAccess(ctx, svc.ID, svc.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	// Sort a copy, the published versions slice is shared with concurrent readers
+	versions := make([]*model.ServiceVersion, len(svc.Versions))
+	copy(versions, svc.Versions)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.Before(versions[j].CreatedAt.Time)
+	})
+
+	entries := make([]*v1.ServiceHistoryEntry, 0, len(versions))
+	for i, protoVersion := range convertVersionsToProto(versions) {
+		entry := &v1.ServiceHistoryEntry{Version: protoVersion}
+		if i > 0 {
+			entry.SincePreviousRelease = durationpb.New(versions[i].CreatedAt.Sub(versions[i-1].CreatedAt.Time))
+		}
+		entries = append(entries, entry)
+	}
+
+	logger.Get().Infow("GetServiceHistory completed successfully",
+		"service_id", req.GetServiceId(),
+		"versions_count", len(entries))
+
+	return &v1.GetServiceHistoryResponse{ServiceId: svc.ID, Entries: entries}, nil
+}
+
+// ListRecentVersions returns a paginated list of versions across all services, most recently updated first.
+// Like SearchVersions it covers the services the caller can see: those of its organization when authenticated,
+// of the organizations in WithAnonymousOrganizations otherwise, and only those of the local shard.
+func (c *CatalogService) ListRecentVersions(ctx context.Context, req *v1.ListRecentVersionsRequest) (*v1.ListRecentVersionsResponse, error) {
+	logger.Get().Infow("ListRecentVersions called",
+		"page_size", req.GetPageSize(),
+		"page_token", req.GetPageToken(),
+		"updated_after", req.GetUpdatedAfter().AsTime(),
+		"is_active", req.GetIsActive())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateListRecentVersionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	// collect versions across the visible services that match the filters
+	versions, err := c.filterVersions(ctx, c.visibleServices(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	logger.Get().Debugw("Versions after filtering", "count", len(versions))
+
+	// most recently updated first, tie-break on service and version ID for stable pages
+	sort.Slice(versions, func(i, j int) bool {
+		if !versions[i].UpdatedAt.Equal(versions[j].UpdatedAt.Time) {
+			return versions[i].UpdatedAt.After(versions[j].UpdatedAt.Time)
+		}
+		if versions[i].ServiceID != versions[j].ServiceID {
+			return versions[i].ServiceID < versions[j].ServiceID
+		}
+		return versions[i].ID < versions[j].ID
+	})
+
+	// paginate results
+	totalCount := len(versions)
+	pageSize := c.getPageSize(req.GetPageSize())
+	startIndex, err := c.getStartIndex(req.GetPageToken(), pageSize, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	endIndex := startIndex + pageSize
+	if endIndex > int32(totalCount) {
+		endIndex = int32(totalCount)
+	}
+
+	var nextPageToken string
+	if endIndex < int32(totalCount) {
+		nextPageToken = fmt.Sprintf("page_%d", endIndex)
+	}
+
+	logger.Get().Infow("ListRecentVersions completed successfully",
+		"returned_count", endIndex-startIndex,
+		"total_count", totalCount,
+		"has_next_page", nextPageToken != "")
+
+	return &v1.ListRecentVersionsResponse{
+		Versions:      convertVersionsToProto(versions[startIndex:endIndex]),
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(totalCount),
+	}, nil
+}
+
+// SearchVersions returns a paginated list of the versions across all services whose version string or
+// description contains every term of the search query, ordered by service ID and then by version in data file
+// order. Each matching version is a result of its own, so one service can appear several times.
+// Only services of this shard that the caller may read are searched, see visibleServices.
+func (c *CatalogService) SearchVersions(ctx context.Context, req *v1.SearchVersionsRequest) (*v1.SearchVersionsResponse, error) {
+	logger.Get().Infow("SearchVersions called",
+		"page_size", req.GetPageSize(),
+		"page_token", req.GetPageToken(),
+		"search_query", logger.Redact("search_query", req.GetSearchQuery()),
+		"is_active", req.GetIsActive())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateSearchVersionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	// services come in ID order, so results are ordered without sorting
+	results, err := c.searchVersions(ctx, c.visibleServices(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	logger.Get().Debugw("Versions matching the search", "count", len(results))
+
+	// paginate results
+	totalCount := len(results)
+	pageSize := c.getPageSize(req.GetPageSize())
+	startIndex, err := c.getStartIndex(req.GetPageToken(), pageSize, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	endIndex := startIndex + pageSize
+	if endIndex > int32(totalCount) {
+		endIndex = int32(totalCount)
+	}
+
+	var nextPageToken string
+	if endIndex < int32(totalCount) {
+		nextPageToken = fmt.Sprintf("page_%d", endIndex)
+	}
+
+	logger.Get().Infow("SearchVersions completed successfully",
+		"returned_count", endIndex-startIndex,
+		"total_count", totalCount,
+		"has_next_page", nextPageToken != "")
+
+	return &v1.SearchVersionsResponse{
+		Results:       results[startIndex:endIndex],
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(totalCount),
+	}, nil
+}
+
+// DescribeCatalog returns aggregate statistics computed in a single pass over the store.
+// When the request carries JWT claims the statistics only cover the caller's organization, and without them
+// only the organizations in WithAnonymousOrganizations. A sharded node only covers its own shard.
+func (c *CatalogService) DescribeCatalog(ctx context.Context, req *v1.DescribeCatalogRequest) (*v1.DescribeCatalogResponse, error) {
+	orgScope := callerOrganization(ctx)
+	logger.Get().Infow("DescribeCatalog called", "organization_scope", orgScope)
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	var (
+		totalServices, totalVersions, activeVersions int32
+		newest, oldest                               *model.Service
+	)
+	perOrg := make(map[string]int32)
+
+	for i, s := range c.visibleServices(ctx) {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		totalServices++
+		perOrg[s.OrganizationID]++
+		totalVersions += int32(len(s.Versions))
+		for _, v := range s.Versions {
+			if v.IsActive {
+				activeVersions++
+			}
+		}
+
+		// tie-break on ID so the result does not depend on map iteration order
+		if newest == nil || s.CreatedAt.After(newest.CreatedAt.Time) || (s.CreatedAt.Equal(newest.CreatedAt.Time) && s.ID < newest.ID) {
+			newest = s
+		}
+		if oldest == nil || s.CreatedAt.Before(oldest.CreatedAt.Time) || (s.CreatedAt.Equal(oldest.CreatedAt.Time) && s.ID < oldest.ID) {
+			oldest = s
+		}
+	}
+
+	orgCounts := make([]*v1.OrganizationServiceCount, 0, len(perOrg))
+	for orgID, count := range perOrg {
+		orgCounts = append(orgCounts, &v1.OrganizationServiceCount{OrganizationId: orgID, ServiceCount: count})
+	}
+	sort.Slice(orgCounts, func(i, j int) bool {
+		return orgCounts[i].OrganizationId < orgCounts[j].OrganizationId
+	})
+
+	resp := &v1.DescribeCatalogResponse{
+		TotalServices:           totalServices,
+		TotalVersions:           totalVersions,
+		ActiveVersions:          activeVersions,
+		ServicesPerOrganization: orgCounts,
+	}
+	if newest != nil {
+		resp.NewestService = c.toProtoService(newest)
+		resp.OldestService = c.toProtoService(oldest)
+	}
+
+	logger.Get().Infow("DescribeCatalog completed successfully",
+		"total_services", totalServices,
+		"total_versions", totalVersions,
+		"organizations", len(orgCounts))
+
+	return resp, nil
+}
+
+// ListOrganizations returns every organization that owns a service or is given a display name, sorted by ID.
+// When the request carries JWT claims only the caller's organization is listed, and without them only the
+// organizations in WithAnonymousOrganizations. A sharded node only counts the services of its own shard.
+func (c *CatalogService) ListOrganizations(ctx context.Context, req *v1.ListOrganizationsRequest) (*v1.ListOrganizationsResponse, error) {
+	orgScope := callerOrganization(ctx)
+	logger.Get().Infow("ListOrganizations called", "organization_scope", orgScope)
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	counts := make(map[string]int32)
+	if names := c.orgNames.Load(); names != nil {
+		for orgID := range *names {
+			counts[orgID] = 0
+		}
+	}
+	for i, s := range c.localServices(c.getAllServices()) {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if s.OrganizationID != "" {
+			counts[s.OrganizationID]++
+		}
+	}
+
+	allowed := c.anonymousOrganizations(ctx)
+	orgs := make([]*v1.Organization, 0, len(counts))
+	for orgID, count := range counts {
+		if orgScope != "" && orgID != orgScope {
+			continue
+		}
+		if allowed != nil && !allowed[orgID] {
+			continue
+		}
+		orgs = append(orgs, &v1.Organization{Id: orgID, DisplayName: c.organizationName(orgID), ServiceCount: count})
+	}
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Id < orgs[j].Id
+	})
+
+	logger.Get().Infow("ListOrganizations completed successfully", "organizations", len(orgs))
+	return &v1.ListOrganizationsResponse{Organizations: orgs}, nil
+}
+
+// applyDefaultOrganization returns req filtered by the default organization when the caller is unauthenticated
+// and filters by no organization, req itself otherwise
+func (c *CatalogService) applyDefaultOrganization(ctx context.Context, req *v1.ListServicesRequest) *v1.ListServicesRequest {
+	if c.defaultOrganization == "" || req.GetOrganizationId() != "" || len(req.GetOrganizationIds()) > 0 {
+		return req
+	}
+	if _, ok := auth.ClaimsFromContext(ctx); ok {
+		return req
+	}
+	scoped := proto.Clone(req).(*v1.ListServicesRequest)
+	scoped.OrganizationId = c.defaultOrganization
+	return scoped
+}
+
+// applyCallerOrganization returns req restricted to the organization of an authenticated caller, or req itself
+// for unauthenticated callers. Asking for other organizations is answered per the cross-organization policy:
+// PermissionDenied, or visible false when none of the requested organizations is the caller's.
+func (c *CatalogService) applyCallerOrganization(ctx context.Context, req *v1.ListServicesRequest) (*v1.ListServicesRequest, bool, error) {
+	orgScope := callerOrganization(ctx)
+	if orgScope == "" {
+		return req, true, nil
+	}
+
+	for _, orgID := range append([]string{req.GetOrganizationId()}, req.GetOrganizationIds()...) {
+		if orgID == "" || orgID == orgScope {
+			continue
+		}
+		logger.Get().Warnw("Cross-organization listing", "organization_id", orgID, "policy", c.crossOrgPolicy)
+		if c.crossOrgPolicy == CrossOrgDeny {
+			return nil, false, newPermissionDeniedError(ReasonOrganizationDenied, "organization '%s' is not the caller's organization", orgID)
+		}
+	}
+	if orgs := requestedOrganizations(req); orgs != nil && !orgs[orgScope] {
+		return req, false, nil
+	}
+	scoped := proto.Clone(req).(*v1.ListServicesRequest)
+	scoped.OrganizationId = orgScope
+	scoped.OrganizationIds = nil
+	return scoped, true, nil
+}
+
+// anonymousOrganizations returns the organizations an unauthenticated caller may read, or nil when the caller
+// is authenticated or anonymous reads are unrestricted
+func (c *CatalogService) anonymousOrganizations(ctx context.Context) map[string]bool {
+	if c.anonymousOrgs == nil {
+		return nil
+	}
+	if _, ok := auth.ClaimsFromContext(ctx); ok {
+		return nil
+	}
+	return c.anonymousOrgs
+}
+
+// visibleServices returns the services of this shard the caller may read, in ID order: an authenticated
+// caller's own organization, or the anonymous allowlist for unauthenticated callers
+func (c *CatalogService) visibleServices(ctx context.Context) []*model.Service {
+	services := c.anonymousServices(ctx, c.localServices(c.getAllServices()))
+	orgScope := callerOrganization(ctx)
+	if orgScope == "" {
+		return services
+	}
+	visible := services[:0]
+	for _, s := range services {
+		if s.OrganizationID == orgScope {
+			visible = append(visible, s)
+		}
+	}
+	return visible
+}
+
+// anonymousServices drops the services an unauthenticated caller may not read, reusing the services slice
+func (c *CatalogService) anonymousServices(ctx context.Context, services []*model.Service) []*model.Service {
+	allowed := c.anonymousOrganizations(ctx)
+	if allowed == nil {
+		return services
+	}
+	visible := services[:0]
+	for _, s := range services {
+		if allowed[s.OrganizationID] {
+			visible = append(visible, s)
+		}
+	}
+	return visible
+}
+
+// callerOrganization returns the organization from the request's JWT claims, or "" when the request is unauthenticated
+func callerOrganization(ctx context.Context) string {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return claims.Organization
+}
+
+// requireAdmin rejects authenticated callers without the admin role; unauthenticated requests, which only reach
+// the service when auth is disabled, are allowed
+func requireAdmin(ctx context.Context) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if ok && claims.Role != "admin" {
+		return newPermissionDeniedError(ReasonAdminRequired, "admin role required")
+	}
+	return nil
+}
+
+// checkWriteAccess rejects unauthenticated callers of write RPCs unless anonymous writes are allowed,
+// so disabling auth does not let anyone change the catalog
+func (c *CatalogService) checkWriteAccess(ctx context.Context) error {
+	if _, ok := auth.ClaimsFromContext(ctx); ok || c.anonymousWrites {
+		return nil
+	}
+	return newPermissionDeniedError(ReasonAdminRequired, "writes require an authenticated admin")
+}
+
+// checkOrganizationAccess rejects authenticated callers asking for a service of another organization,
+// as not found or as denied depending on the cross-organization policy, and anonymous callers asking for
+// a service outside the anonymous allowlist as not found
+func (c *CatalogService) checkOrganizationAccess(ctx context.Context, id, orgID string) error {
+	if allowed := c.anonymousOrganizations(ctx); allowed != nil && !allowed[orgID] {
+		logger.Get().Debugw("Anonymous access to service outside the allowlist", "service_id", id)
+		return newNotFoundError(ReasonServiceNotFound, ErrServiceNotFound, "service with ID '%s' not found", id)
+	}
+
+	orgScope := callerOrganization(ctx)
+	if orgScope == "" || orgID == orgScope {
+		return nil
+	}
+
+	logger.Get().Warnw("Cross-organization service access", "service_id", id, "policy", c.crossOrgPolicy)
+	if c.crossOrgPolicy == CrossOrgDeny {
+		return newPermissionDeniedError(ReasonOrganizationDenied, "service with ID '%s' belongs to another organization", id)
+	}
+	return newNotFoundError(ReasonServiceNotFound, ErrServiceNotFound, "service with ID '%s' not found", id)
+}
+
+// ActivateVersionAcrossServices makes the requested version the only active version of every service that has it,
+// skipping services without it. Authenticated callers only affect services of their own organization, and a
+// sharded node only those of its own shard.
+// All services are updated together under the write lock and published as one new catalog, so readers see
+// either none or all of the changes.
+func (c *CatalogService) ActivateVersionAcrossServices(ctx context.Context, req *v1.ActivateVersionAcrossServicesRequest) (*v1.ActivateVersionAcrossServicesResponse, error) {
+	logger.Get().Infow("ActivateVersionAcrossServices called", "version", req.GetVersion())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+	if err := checkRules(req); err != nil {
+		return nil, err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	orgScope := callerOrganization(ctx)
+	now := time.Now().UTC()
+	current := c.catalog()
+	updated := make(map[string]*model.Service)
+	for id, svc := range current {
+		if orgScope != "" && svc.OrganizationID != orgScope {
+			continue
+		}
+		if c.checkLocalShard(id) != nil {
+			continue
+		}
+		if activated := activateVersion(svc, req.GetVersion(), now); activated != nil {
+			updated[id] = activated
+		}
+	}
+
+	serviceIDs := make([]string, 0, len(updated))
+	if len(updated) > 0 {
+		data := make(map[string]*model.Service, len(current))
+		for id, svc := range current {
+			data[id] = svc
+		}
+		for id, svc := range updated {
+			data[id] = svc
+			serviceIDs = append(serviceIDs, id)
+			c.keepWritten(svc)
+		}
+		c.data.Store(&data)
+	}
+	sort.Strings(serviceIDs)
+
+	actor := auditActor(ctx)
+	events := make([]Event, 0, len(serviceIDs))
+	for _, id := range serviceIDs {
+		c.audit.record(actor, AuditActionActivateVersion, id, "activated "+req.GetVersion())
+		events = append(events, Event{Type: EventServiceUpdated, ServiceID: id, Service: updated[id], Time: now})
+	}
+	c.publishChanges(events...)
+
+	logger.Get().Infow("ActivateVersionAcrossServices completed successfully",
+		"version", req.GetVersion(),
+		"affected_services", len(serviceIDs))
+
+	return &v1.ActivateVersionAcrossServicesResponse{ServiceIds: serviceIDs}, nil
+}
+
+// CreateServices adds new services (admin only) and reports the outcome of each in request order.
+// Each service gets missing IDs generated and is normalized like PutService, then checked against the Service
+// field rules; an ID already in the catalog or repeated in the batch fails with AlreadyExists. The services
+// that pass are created and the others reported, or in transactional mode a single failure creates none.
+// Authenticated callers can only create services of their own organization, which is also the default.
+// The created services are published together as one new catalog. A retry repeating the idempotency key
+// of an earlier request by the same caller gets the earlier response and creates nothing, while reusing
+// the key for a different request fails with FailedPrecondition.
+func (c *CatalogService) CreateServices(ctx context.Context, req *v1.CreateServicesRequest) (*v1.CreateServicesResponse, error) {
+	logger.Get().Infow("CreateServices called",
+		"services_count", len(req.GetServices()),
+		"transactional", req.GetTransactional(),
+		"has_idempotency_key", req.GetIdempotencyKey() != "")
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters; the services themselves are checked one by one
+	if err := c.validateCreateServicesRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Holding the write lock, so a retry racing the original request sees its response
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	idempotencyKey := ""
+	var fingerprint [sha256.Size]byte
+	if c.idempotency != nil {
+		idempotencyKey = idempotencyScope(ctx, req)
+	}
+	if idempotencyKey != "" {
+		var err error
+		if fingerprint, err = createServicesFingerprint(req); err != nil {
+			return nil, err
+		}
+		if prior, ok := c.idempotency.get(idempotencyKey); ok {
+			if prior.fingerprint != fingerprint {
+				return nil, newError(codes.FailedPrecondition, ReasonIdempotencyReused, ErrInvalidRequest, "idempotency_key was already used for a different request")
+			}
+			logger.Get().Infow("CreateServices replayed for a repeated idempotency key",
+				"created_count", prior.resp.GetCreatedCount(),
+				"failed_count", prior.resp.GetFailedCount())
+			return proto.Clone(prior.resp).(*v1.CreateServicesResponse), nil
+		}
+	}
+
+	orgScope := callerOrganization(ctx)
+	now := time.Now().UTC()
+	current := c.catalog()
+	results := make([]*v1.CreateServiceResult, len(req.GetServices()))
+	created := make([]*model.Service, 0, len(req.GetServices()))
+	batchIDs := make(map[string]bool, len(req.GetServices()))
+	for i, item := range req.GetServices() {
+		results[i] = &v1.CreateServiceResult{Index: int32(i)}
+
+		svc, err := c.newService(item, orgScope, now)
+		if err == nil {
+			if _, exists := current[svc.ID]; exists || batchIDs[svc.ID] {
+				err = newAlreadyExistsError(ReasonServiceExists, "service with ID '%s' already exists", svc.ID)
+			}
+		}
+		if err == nil && c.maxServices > 0 && len(current)+len(created) >= c.maxServices {
+			err = newStoreFullError(len(current)+len(created)+1, c.maxServices)
+		}
+		if err != nil {
+			setCreateServiceError(results[i], err)
+			continue
+		}
+
+		batchIDs[svc.ID] = true
+		created = append(created, svc)
+		results[i].Id = svc.ID
+	}
+
+	failed := len(results) - len(created)
+	if req.GetTransactional() && failed > 0 {
+		aborted := newError(codes.Aborted, ReasonBatchAborted, nil, "not created, another service of the transactional batch failed")
+		for _, r := range results {
+			if r.Id != "" {
+				r.Id = ""
+				setCreateServiceError(r, aborted)
+			}
+		}
+		created = created[:0]
+		failed = len(results)
+	}
+
+	if len(created) > 0 {
+		data := make(map[string]*model.Service, len(current)+len(created))
+		for id, svc := range current {
+			data[id] = svc
+		}
+		for _, svc := range created {
+			data[svc.ID] = svc
+		}
+		c.data.Store(&data)
+		c.keepWritten(created...)
+	}
+
+	actor := auditActor(ctx)
+	events := make([]Event, 0, len(created))
+	for _, svc := range created {
+		c.audit.record(actor, AuditActionCreateService, svc.ID, "")
+		events = append(events, Event{Type: EventServiceCreated, ServiceID: svc.ID, Service: svc, Time: now})
+	}
+	c.publishChanges(events...)
+
+	logger.Get().Infow("CreateServices completed successfully",
+		"created_count", len(created),
+		"failed_count", failed)
+
+	resp := &v1.CreateServicesResponse{
+		Results:      results,
+		CreatedCount: int32(len(created)),
+		FailedCount:  int32(failed),
+	}
+	if idempotencyKey != "" {
+		c.idempotency.put(idempotencyKey, fingerprint, resp)
+	}
+	return resp, nil
+}
+
+// newService converts a service to create into a model service, applying the defaults for missing IDs,
+// organization and timestamps, and checks it the way PutService does plus the Service field rules
+func (c *CatalogService) newService(item *v1.Service, orgScope string, now time.Time) (*model.Service, error) {
+	if item == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "service cannot be nil")
+	}
+	if item.GetId() != "" && !c.isValidID(item.GetId()) {
+		return nil, newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
+	}
+
+	svc := serviceFromProto(item)
+	if svc.OrganizationID == "" {
+		svc.OrganizationID = orgScope
+	}
+	if orgScope != "" && svc.OrganizationID != orgScope {
+		return nil, newPermissionDeniedError(ReasonOrganizationDenied, "cannot create services of organization %q", svc.OrganizationID)
+	}
+	if svc.OrganizationID != "" && !c.isValidOrganizationID(svc.OrganizationID) {
+		return nil, newInvalidArgumentError(ReasonInvalidID, "invalid organization_id format")
+	}
+
+	if err := c.assignIDs(svc); err != nil {
+		return nil, err
+	}
+	svc.Normalize(c.nameNormalization)
+	for _, v := range svc.Versions {
+		if v.ServiceID != svc.ID {
+			return nil, newInvalidArgumentError(ReasonInvalidField, "version %q belongs to service %q, not %q", v.Version, v.ServiceID, svc.ID)
+		}
+	}
+	if err := svc.CheckVersionIDs(); err != nil {
+		return nil, newInvalidArgumentError(ReasonInvalidField, "%v", err)
+	}
+	if err := checkRules(convertToProtoService(svc)); err != nil {
+		return nil, err
+	}
+	if c.requireHTTPSURLs {
+		if err := svc.CheckHTTPSURL(); err != nil {
+			return nil, newInvalidArgumentError(ReasonInvalidURL, "%v", err)
+		}
+	}
+	if err := c.checkKnownOrganization(svc); err != nil {
+		return nil, err
+	}
+
+	// services on other shards are created by their own nodes
+	if err := c.checkLocalShard(svc.ID); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkFutureTimestamps(svc, now); err != nil {
+		return nil, err
+	}
+
+	if svc.CreatedAt.IsZero() {
+		svc.CreatedAt.Time = now
+	}
+	if svc.UpdatedAt.IsZero() {
+		svc.UpdatedAt = svc.CreatedAt
+	}
+	for _, v := range svc.Versions {
+		if v.CreatedAt.IsZero() {
+			v.CreatedAt.Time = now
+		}
+		if v.UpdatedAt.IsZero() {
+			v.UpdatedAt = v.CreatedAt
+		}
+	}
+	warnUnparseableVersions(svc)
+	return svc, nil
+}
+
+// checkFutureTimestamps applies the future timestamp policy to a service being added: a timestamp later than
+// now plus the skew fails it under FutureTimestampsReject and is logged under FutureTimestampsWarn
+func (c *CatalogService) checkFutureTimestamps(svc *model.Service, now time.Time) error {
+	if c.futureTimestamps == "" || c.futureTimestamps == model.FutureTimestampsIgnore {
+		return nil
+	}
+
+	err := svc.CheckFutureTimestamps(now.Add(c.timestampSkew))
+	if err == nil {
+		return nil
+	}
+	if c.futureTimestamps == model.FutureTimestampsReject {
+		return newInvalidArgumentError(ReasonInvalidTimestamp, "%v", err)
+	}
+	logger.Get().Warnw("Future timestamp in created service", "service_id", svc.ID, "error", err)
+	return nil
+}
+
+// setCreateServiceError reports err as the failure of a CreateServices result
+func setCreateServiceError(result *v1.CreateServiceResult, err error) {
+	st, _ := status.FromError(err)
+	result.ErrorCode = code.Code(st.Code()).String()
+	result.ErrorReason = string(ReasonOf(err))
+	result.ErrorMessage = st.Message()
+}
+
+// TouchService sets the service's UpdatedAt to now, leaving every other field and its versions unchanged,
+// and returns the updated service (admin only). Authenticated callers can only touch services of their own
+// organization, others are answered according to the cross-organization policy. The caller is recorded in
+// the audit log.
+func (c *CatalogService) TouchService(ctx context.Context, req *v1.TouchServiceRequest) (*v1.TouchServiceResponse, error) {
+	logger.Get().Infow("TouchService called", "service_id", req.GetId())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reject requests until the catalog has been loaded
+	if err := c.checkAvailable(); err != nil {
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	if err := c.validateTouchServiceRequest(req); err != nil {
+		return nil, err
+	}
+
+	// services on other shards are changed by their own nodes
+	if err := c.checkLocalShard(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	current := c.catalog()
+	svc, err := c.getServiceByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkOrganizationAccess(ctx, svc.ID, svc.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	// The published service is shared with readers, so the copy keeps its versions and replaces only UpdatedAt
+	now := time.Now().UTC()
+	touched := *svc
+	touched.UpdatedAt.Time = now
+
+	data := make(map[string]*model.Service, len(current))
+	for id, s := range current {
+		data[id] = s
+	}
+	data[touched.ID] = &touched
+	c.data.Store(&data)
+	c.keepWritten(&touched)
+
+	c.audit.record(auditActor(ctx), AuditActionTouchService, touched.ID, "")
+	c.publishChanges(Event{Type: EventServiceUpdated, ServiceID: touched.ID, Service: &touched, Time: now})
+
+	logger.Get().Infow("TouchService completed successfully", "service_id", touched.ID)
+	return &v1.TouchServiceResponse{Service: c.toProtoService(&touched)}, nil
+}
+
+// ListAuditEvents returns recorded catalog changes matching the request filters, newest first (admin only).
+// Only the most recent events are retained, see WithAuditLogSize.
+func (c *CatalogService) ListAuditEvents(ctx context.Context, req *v1.ListAuditEventsRequest) (*v1.ListAuditEventsResponse, error) {
+	logger.Get().Infow("ListAuditEvents called",
+		"page_size", req.GetPageSize(),
+		"page_token", req.GetPageToken(),
+		"actor", req.GetActor(),
+		"service_id", req.GetServiceId(),
+		"action", req.GetAction())
+
+	// Check context cancellation
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// validate request parameters
+	beforeSeq, err := c.validateListAuditEventsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := auditFilter{
+		actor:     req.GetActor(),
+		serviceID: req.GetServiceId(),
+		action:    req.GetAction(),
+	}
+	if req.GetStartTime() != nil {
+		filter.start = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		filter.end = req.GetEndTime().AsTime()
+	}
+	events := c.audit.query(filter)
+
+	// skip events up to the previous page's last one, which may have been dropped from the log since
+	start := 0
+	if beforeSeq != 0 {
+		start = sort.Search(len(events), func(i int) bool { return events[i].seq < beforeSeq })
+	}
+	end := start + int(c.getPageSize(req.GetPageSize()))
+	if end > len(events) {
+		end = len(events)
+	}
+
+	resp := &v1.ListAuditEventsResponse{
+		Events:     make([]*v1.AuditEvent, 0, end-start),
+		TotalCount: int32(len(events)),
+	}
+	for _, e := range events[start:end] {
+		resp.Events = append(resp.Events, &v1.AuditEvent{
+			Id:        strconv.FormatUint(e.seq, 10),
+			Time:      timestamppb.New(e.time),
+			Actor:     e.actor,
+			Action:    e.action,
+			ServiceId: e.serviceID,
+			Details:   e.details,
+		})
+	}
+	if end < len(events) {
+		resp.NextPageToken = auditPageToken(events[end-1].seq)
+	}
+
+	logger.Get().Infow("ListAuditEvents completed successfully",
+		"returned_count", len(resp.Events),
+		"total_count", len(events),
+		"has_next_page", resp.NextPageToken != "")
+
+	return resp, nil
+}
+
+// activateVersion returns a copy of the service with only the given version active, or nil if the service
+// has no such version. The published service is left untouched; versions whose state changes get
+// updatedAt, as does the service when any version changed.
+func activateVersion(svc *model.Service, version string, updatedAt time.Time) *model.Service {
+	found := false
+	for _, v := range svc.Versions {
+		if v.Version == version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	activated := *svc
+	activated.Versions = make([]*model.ServiceVersion, len(svc.Versions))
+	for i, v := range svc.Versions {
+		versionCopy := *v
+		if active := v.Version == version; versionCopy.IsActive != active {
+			versionCopy.IsActive = active
+			versionCopy.UpdatedAt.Time = updatedAt
+			activated.UpdatedAt.Time = updatedAt
+		}
+		activated.Versions[i] = &versionCopy
+	}
+	return &activated
+}
+
+// validateListServicesRequest checks the validity of the ListServicesRequest parameters
+func (c *CatalogService) validateListServicesRequest(req *v1.ListServicesRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return err
+	}
+
+	if limit, over := c.overFieldLimit("ListServices.search_query", utf8.RuneCountInString(req.GetSearchQuery())); over {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "search_query too long, max %d characters", limit)
+	}
+	if limit, over := c.overFieldLimit("ListServices.version", utf8.RuneCountInString(req.GetVersion())); over {
+		return newInvalidArgumentError(ReasonInvalidVersion, "version too long, max %d characters", limit)
+	}
+
+	if req.GetSearchQuery() != "" && c.searchMinLength > 0 {
+		query, _ := c.parseSearchQuery(req.GetSearchQuery())
+		if utf8.RuneCountInString(query) < c.searchMinLength {
+			return newInvalidArgumentError(ReasonInvalidSearchQuery, "search_query too short, min %d characters", c.searchMinLength)
+		}
+	}
+
+	if req.GetSearchFields() != "" && !validSearchFields[req.GetSearchFields()] {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "invalid search_fields %q, allowed values: %s", req.GetSearchFields(), allowedValues(validSearchFields))
+	}
+	if req.GetSearchMatch() != "" && !validSearchMatches[req.GetSearchMatch()] {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "invalid search_match %q, allowed values: %s", req.GetSearchMatch(), allowedValues(validSearchMatches))
+	}
+
+	// Validate organization ID format if provided
+	if req.GetOrganizationId() != "" && !c.isValidOrganizationID(req.GetOrganizationId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid organization_id format")
+	}
+	if limit, over := c.overFieldLimit("ListServices.organization_ids", distinctCount(req.GetOrganizationIds())); over {
+		return newInvalidArgumentError(ReasonTooManyIDs, "at most %d organization_ids can be given, got %d", limit, distinctCount(req.GetOrganizationIds()))
+	}
+	for _, orgID := range req.GetOrganizationIds() {
+		if !c.isValidOrganizationID(orgID) {
+			return newInvalidArgumentError(ReasonInvalidID, "invalid organization_ids entry %q", orgID)
+		}
+	}
+
+	// In strict mode unrecognized sort values are rejected, otherwise they fall back to the defaults
+	if _, _, err := c.resolveSort(req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sortRequest is a listing request carrying sort_by and sort_order
+type sortRequest interface {
+	GetSortBy() string
+	GetSortOrder() string
+}
+
+// resolveSort returns the field and order a listing is sorted by, the single place sort defaults are applied:
+// empty values take the configured defaults, and unrecognized ones fail with InvalidArgument in strict mode
+// and take the defaults otherwise. A nil request, for listings without sort fields, resolves to the defaults.
+func (c *CatalogService) resolveSort(req sortRequest) (string, string, error) {
+	sortBy, sortOrder := c.defaultSortBy, c.defaultSortOrder
+	if req == nil {
+		return sortBy, sortOrder, nil
+	}
+
+	switch by := req.GetSortBy(); {
+	case validSortFields[by]:
+		sortBy = by
+	case by != "" && c.strictSort:
+		return "", "", newInvalidArgumentError(ReasonInvalidSort, "invalid sort_by %q, allowed values: %s", by, allowedValues(validSortFields))
+	}
+	switch order := req.GetSortOrder(); {
+	case validSortOrders[order]:
+		sortOrder = order
+	case order != "" && c.strictSort:
+		return "", "", newInvalidArgumentError(ReasonInvalidSort, "invalid sort_order %q, allowed values: %s", order, allowedValues(validSortOrders))
+	}
+	return sortBy, sortOrder, nil
+}
+
+// allowedValues returns the sorted keys of a set as a comma-separated list for error messages
+func allowedValues(set map[string]bool) string {
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+// validateListRecentVersionsRequest checks the validity of the ListRecentVersionsRequest parameters
+func (c *CatalogService) validateListRecentVersionsRequest(req *v1.ListRecentVersionsRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return err
+	}
+
+	if req.UpdatedAfter != nil {
+		if err := req.GetUpdatedAfter().CheckValid(); err != nil {
+			return newInvalidArgumentError(ReasonInvalidTimestamp, "invalid updated_after: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateSearchVersionsRequest checks the validity of the SearchVersionsRequest parameters
+func (c *CatalogService) validateSearchVersionsRequest(req *v1.SearchVersionsRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return err
+	}
+
+	if limit, over := c.overFieldLimit("SearchVersions.search_query", utf8.RuneCountInString(req.GetSearchQuery())); over {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "search_query too long, max %d characters", limit)
+	}
+
+	query := strings.TrimSpace(req.GetSearchQuery())
+	if query == "" {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "search_query is required")
+	}
+	if c.searchMinLength > 0 && utf8.RuneCountInString(query) < c.searchMinLength {
+		return newInvalidArgumentError(ReasonInvalidSearchQuery, "search_query too short, min %d characters", c.searchMinLength)
+	}
+
+	return nil
+}
+
+// validateGetServiceRequest checks the validity of the GetServiceRequest parameters
+func (c *CatalogService) validateGetServiceRequest(req *v1.GetServiceRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return err
+	}
+
+	if !c.isValidID(req.GetId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
+	}
+
+	return nil
+}
+
+// validateTouchServiceRequest checks the validity of the TouchServiceRequest parameters
+func (c *CatalogService) validateTouchServiceRequest(req *v1.TouchServiceRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return err
+	}
+
+	if !c.isValidID(req.GetId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
+	}
+
+	return nil
+}
+
+// validateCreateServicesRequest validates the size of a CreateServices batch
+func (c *CatalogService) validateCreateServicesRequest(req *v1.CreateServicesRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if len(req.GetServices()) == 0 {
+		return newInvalidArgumentError(ReasonInvalidField, "at least one service is required")
+	}
+
+	if limit, over := c.overFieldLimit("CreateServices.services", len(req.GetServices())); over {
+		return newInvalidArgumentError(ReasonTooManyServices, "too many services, max %d per request", limit)
+	}
+
+	if utf8.RuneCountInString(req.GetIdempotencyKey()) > maxIdempotencyKeyLength {
+		return newInvalidArgumentError(ReasonInvalidField, "idempotency_key too long, max %d characters", maxIdempotencyKeyLength)
+	}
+
+	return nil
+}
+
+// validateListAuditEventsRequest validates the request and returns the sequence its page token resumes before
+func (c *CatalogService) validateListAuditEventsRequest(req *v1.ListAuditEventsRequest) (uint64, error) {
+	if req == nil {
+		return 0, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	if err := checkRules(req); err != nil {
+		return 0, err
+	}
+
+	if req.StartTime != nil {
+		if err := req.GetStartTime().CheckValid(); err != nil {
+			return 0, newInvalidArgumentError(ReasonInvalidTimestamp, "invalid start_time: %v", err)
+		}
+	}
+	if req.EndTime != nil {
+		if err := req.GetEndTime().CheckValid(); err != nil {
+			return 0, newInvalidArgumentError(ReasonInvalidTimestamp, "invalid end_time: %v", err)
+		}
+	}
+	if req.StartTime != nil && req.EndTime != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		return 0, newInvalidArgumentError(ReasonInvalidTimestamp, "start_time must be before end_time")
+	}
+
+	return parseAuditPageToken(req.GetPageToken())
+}
+
+// validateBatchGetServicesRequest validates the request and returns its IDs, split on commas, trimmed and
+// deduplicated in request order
+func (c *CatalogService) validateBatchGetServicesRequest(req *v1.BatchGetServicesRequest) ([]string, error) {
+	if req == nil {
+		return nil, newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, entry := range req.GetIds() {
+		for _, id := range strings.Split(entry, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			if !c.isValidID(id) {
+				return nil, newInvalidArgumentError(ReasonInvalidID, "invalid service ID format: %q", id)
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
 	}
 
-	// validate request parameters
-	if err := c.validateGetServiceRequest(req); err != nil {
-		return nil, err
+	if len(ids) == 0 {
+		return nil, newInvalidArgumentError(ReasonMissingID, "at least one service ID is required")
 	}
-
-	// fetch service by ID
-	svc, err := c.getServiceByID(req.GetId())
-	if err != nil {
-		return nil, err
+	if limit, over := c.overFieldLimit("BatchGetServices.ids", len(ids)); over {
+		return nil, newInvalidArgumentError(ReasonTooManyIDs, "at most %d service IDs can be requested at once, got %d", limit, len(ids))
 	}
 
-	logger.Get().Infow("GetService completed successfully", "service_id", req.GetId())
-	return &v1.GetServiceResponse{Service: convertToProtoService(svc)}, nil
+	return ids, nil
 }
 
-// GetServiceVersions returns all versions of a specific service
-func (c *CatalogService) GetServiceVersions(ctx context.Context, req *v1.GetServiceVersionsRequest) (*v1.GetServiceVersionsResponse, error) {
-	logger.Get().Infow("GetServiceVersions called", "service_id", req.GetServiceId())
-
-	// Check context cancellation
-	if ctx.Err() != nil {
-		return nil, status.Error(codes.Canceled, "request cancelled")
+// overFieldLimit returns the limit of a field keyed like DefaultFieldLimits and whether size is over it
+func (c *CatalogService) overFieldLimit(key string, size int) (int, bool) {
+	limits := c.fieldLimits
+	if limits == nil {
+		limits = DefaultFieldLimits
 	}
+	limit := limits[key]
+	return limit, limit > 0 && size > limit
+}
 
-	// validate request parameters
-	if err := c.validateGetServiceVersionsRequest(req); err != nil {
-		return nil, err
+// distinctCount returns the number of distinct values
+func distinctCount(values []string) int {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		seen[v] = true
 	}
+	return len(seen)
+}
 
-	// get service by ID
-	svc, err := c.getServiceByID(req.GetServiceId())
-	if err != nil {
-		return nil, err
+// validateGetServiceVersionsRequest checks the validity of the GetServiceVersionsRequest parameters
+func (c *CatalogService) validateGetServiceVersionsRequest(req *v1.GetServiceVersionsRequest) error {
+	if req == nil {
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
 	}
 
-	versions := convertVersionsToProto(svc.Versions)
+	if err := checkRules(req); err != nil {
+		return err
+	}
 
-	logger.Get().Infow("GetServiceVersions completed successfully",
-		"service_id", req.GetServiceId(),
-		"versions_count", len(versions))
+	if !c.isValidID(req.GetServiceId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
+	}
 
-	return &v1.GetServiceVersionsResponse{Versions: versions}, nil
+	return nil
 }
 
-// validateListServicesRequest checks the validity of the ListServicesRequest parameters
-func (c *CatalogService) validateListServicesRequest(req *v1.ListServicesRequest) error {
+// validateStreamServiceVersionsRequest checks the validity of the StreamServiceVersionsRequest parameters
+func (c *CatalogService) validateStreamServiceVersionsRequest(req *v1.StreamServiceVersionsRequest) error {
 	if req == nil {
-		return status.Errorf(codes.InvalidArgument, "%v: request cannot be nil", ErrInvalidRequest)
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
 	}
 
-	if req.GetPageSize() < 0 || req.GetPageSize() > MaxPageSize {
-		return status.Errorf(codes.InvalidArgument, "%v: page_size must be between 0 and %d, got %d", ErrInvalidRequest, MaxPageSize, req.GetPageSize())
+	if err := checkRules(req); err != nil {
+		return err
 	}
 
-	// Validate search query length
-	if req.GetSearchQuery() != "" && len(req.GetSearchQuery()) > 100 {
-		return status.Errorf(codes.InvalidArgument, "%v: search_query too long, max 100 characters", ErrInvalidRequest)
+	if !c.isValidID(req.GetServiceId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
 	}
 
-	// Validate organization ID format if provided
-	if req.GetOrganizationId() != "" && !c.isValidID(req.GetOrganizationId()) {
-		return status.Errorf(codes.InvalidArgument, "%v: invalid organization_id format", ErrInvalidRequest)
+	if req.UpdatedAfter != nil {
+		if err := req.GetUpdatedAfter().CheckValid(); err != nil {
+			return newInvalidArgumentError(ReasonInvalidTimestamp, "invalid updated_after: %v", err)
+		}
 	}
 
 	return nil
 }
 
-// validateGetServiceRequest checks the validity of the GetServiceRequest parameters
-func (c *CatalogService) validateGetServiceRequest(req *v1.GetServiceRequest) error {
+// validateDiffServicesRequest checks that the request names either a second service or two versions
+func (c *CatalogService) validateDiffServicesRequest(req *v1.DiffServicesRequest) error {
 	if req == nil {
-		return status.Errorf(codes.InvalidArgument, "%v: request cannot be nil", ErrInvalidRequest)
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
 	}
 
-	if req.GetId() == "" {
-		return status.Errorf(codes.InvalidArgument, "%v: service ID is required", ErrInvalidRequest)
+	if err := checkRules(req); err != nil {
+		return err
 	}
 
-	if !c.isValidID(req.GetId()) {
-		return status.Errorf(codes.InvalidArgument, "%v: invalid service ID format", ErrInvalidRequest)
+	if !c.isValidID(req.GetServiceId()) {
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
+	}
+
+	versionsSet := req.GetBaseVersionId() != "" || req.GetTargetVersionId() != ""
+	switch {
+	case req.GetOtherServiceId() != "" && versionsSet:
+		return newInvalidArgumentError(ReasonInvalidField, "set either other_service_id or base_version_id and target_version_id, not both")
+	case req.GetOtherServiceId() != "":
+		if !c.isValidID(req.GetOtherServiceId()) {
+			return newInvalidArgumentError(ReasonInvalidID, "invalid other_service_id format")
+		}
+	case req.GetBaseVersionId() == "" || req.GetTargetVersionId() == "":
+		return newInvalidArgumentError(ReasonMissingID, "other_service_id, or both base_version_id and target_version_id, are required")
 	}
 
 	return nil
 }
 
-// validateGetServiceVersionsRequest checks the validity of the GetServiceVersionsRequest parameters
-func (c *CatalogService) validateGetServiceVersionsRequest(req *v1.GetServiceVersionsRequest) error {
+// validateGetServiceHistoryRequest checks the validity of the GetServiceHistoryRequest parameters
+func (c *CatalogService) validateGetServiceHistoryRequest(req *v1.GetServiceHistoryRequest) error {
 	if req == nil {
-		return status.Errorf(codes.InvalidArgument, "%v: request cannot be nil", ErrInvalidRequest)
+		return newInvalidArgumentError(ReasonMissingRequest, "request cannot be nil")
 	}
 
-	if req.GetServiceId() == "" {
-		return status.Errorf(codes.InvalidArgument, "%v: service ID is required", ErrInvalidRequest)
+	if err := checkRules(req); err != nil {
+		return err
 	}
 
 	if !c.isValidID(req.GetServiceId()) {
-		return status.Errorf(codes.InvalidArgument, "%v: invalid service ID format", ErrInvalidRequest)
+		return newInvalidArgumentError(ReasonInvalidID, "invalid service ID format")
 	}
 
 	return nil
 }
 
-// isValidID validates ID format (basic validation)
+// isValidID validates a service ID against the configured service ID format
 func (c *CatalogService) isValidID(id string) bool {
-	if id == "" {
-		return false
+	return c.serviceIDFormat.orDefault().Valid(id)
+}
+
+// isValidOrganizationID validates an organization ID against the configured organization ID format
+func (c *CatalogService) isValidOrganizationID(id string) bool {
+	return c.orgIDFormat.orDefault().Valid(id)
+}
+
+// getAllServices retrieves all services from the local data store, ordered by ID
+func (c *CatalogService) getAllServices() []*model.Service {
+	data := c.catalog()
+	services := make([]*model.Service, 0, len(data))
+	for _, s := range data {
+		services = append(services, s)
 	}
-	// Basic validation: alphanumeric, hyphens, underscores, max 50 chars
-	if len(id) > 50 {
-		return false
+	// Map iteration order is random, start from ID order so equal sort keys keep the same page boundaries
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	return services
+}
+
+// localServices returns the services owned by the local shard, or all of them when the catalog is not sharded
+func (c *CatalogService) localServices(services []*model.Service) []*model.Service {
+	if c.shard == nil {
+		return services
 	}
-	for _, char := range id {
-		if !((char >= 'a' && char <= 'z') ||
-			(char >= 'A' && char <= 'Z') ||
-			(char >= '0' && char <= '9') ||
-			char == '-' || char == '_') {
-			return false
+	local := services[:0]
+	for _, s := range services {
+		if c.shard.OwnsService(s.ID) {
+			local = append(local, s)
 		}
 	}
-	return true
+	return local
 }
 
-// getAllServices retrieves all services from the local data store
-func (c *CatalogService) getAllServices() []*model.Service {
-	services := make([]*model.Service, 0, len(c.data))
-	for _, s := range c.data {
-		services = append(services, s)
+// checkLocalShard returns codes.NotFound with ReasonWrongShard if id belongs to another shard
+func (c *CatalogService) checkLocalShard(id string) error {
+	if c.shard == nil || c.shard.OwnsService(id) {
+		return nil
 	}
-	return services
+	shard := c.shard.ShardOf(id)
+	logger.Get().Debugw("Service belongs to another shard", "service_id", id, "shard", shard, "local_shard", c.shard.LocalShard())
+	return newNotFoundError(ReasonWrongShard, ErrServiceNotFound, "service with ID '%s' belongs to shard %d, this server serves shard %d",
+		id, shard, c.shard.LocalShard())
+}
+
+// catalog returns the current services map, or nil before the catalog has been loaded
+func (c *CatalogService) catalog() map[string]*model.Service {
+	data := c.data.Load()
+	if data == nil {
+		return nil
+	}
+	return *data
 }
 
 // getPageSize returns the requested page size, defaulting to DefaultPageSize if not specified
@@ -241,33 +2621,59 @@ func (c *CatalogService) getPageSize(requestedPageSize int32) int32 {
 	return requestedPageSize
 }
 
+// listPageSize returns the ListServices page size, the requested one limited to maxListResults
+func (c *CatalogService) listPageSize(requestedPageSize int32) int32 {
+	pageSize := c.getPageSize(requestedPageSize)
+	if c.maxListResults > 0 && pageSize > int32(c.maxListResults) {
+		return int32(c.maxListResults)
+	}
+	return pageSize
+}
+
 // getStartIndex calculates the starting index for pagination based on the page token and page size
 func (c *CatalogService) getStartIndex(pageToken string, pageSize int32, totalCount int) (int32, error) {
 	if pageToken == "" {
 		return 0, nil
 	}
 
+	offset, err := parsePageToken(pageToken)
+	if err != nil {
+		return 0, err
+	}
+
+	// validate offset is within bounds
+	if offset >= totalCount {
+		return 0, newInvalidArgumentError(ReasonPageTokenOutOfRange, "page token out of range")
+	}
+
+	return int32(offset), nil
+}
+
+// parsePageToken returns the offset encoded in a page token, 0 for an empty token
+func parsePageToken(pageToken string) (int, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+
 	// parse page token - format: "page_<offset>"
 	if !strings.HasPrefix(pageToken, "page_") {
-		return 0, status.Errorf(codes.InvalidArgument, "%v: invalid page token format", ErrInvalidRequest)
+		return 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token format")
 	}
 
 	offsetStr := strings.TrimPrefix(pageToken, "page_")
 	offset, err := strconv.Atoi(offsetStr)
 	if err != nil {
-		return 0, status.Errorf(codes.InvalidArgument, "%v: invalid page token: %v", ErrInvalidRequest, err)
+		return 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token: %v", err)
 	}
-
-	// validate offset is within bounds
-	if offset < 0 || offset >= totalCount {
-		return 0, status.Errorf(codes.InvalidArgument, "%v: page token out of range", ErrInvalidRequest)
+	if offset < 0 {
+		return 0, newInvalidArgumentError(ReasonPageTokenOutOfRange, "page token out of range")
 	}
 
-	return int32(offset), nil
+	return offset, nil
 }
 
 // paginateServices slices the services based on the start index and page size
-func (c *CatalogService) paginateServices(services []*model.Service, startIndex, pageSize int32) (*v1.ListServicesResponse, error) {
+func (c *CatalogService) paginateServices(services []*model.Service, startIndex, pageSize int32, idsOnly bool) (*v1.ListServicesResponse, error) {
 	totalCount := len(services)
 
 	if startIndex >= int32(totalCount) {
@@ -286,10 +2692,14 @@ func (c *CatalogService) paginateServices(services []*model.Service, startIndex,
 		endIndex = int32(totalCount)
 	}
 
-	// convert to proto and return
+	// convert to proto and return, index entries skipping the full conversion
 	protoServices := make([]*v1.Service, 0, endIndex-startIndex)
 	for _, s := range services[startIndex:endIndex] {
-		protoServices = append(protoServices, convertToProtoService(s))
+		if idsOnly {
+			protoServices = append(protoServices, convertToServiceIndexEntry(s))
+			continue
+		}
+		protoServices = append(protoServices, c.toProtoService(s))
 	}
 
 	// generate next page token
@@ -312,81 +2722,397 @@ func (c *CatalogService) paginateServices(services []*model.Service, startIndex,
 	}, nil
 }
 
-// filterServices filters the services based on organization ID and search query
-func (c *CatalogService) filterServices(services []*model.Service, req *v1.ListServicesRequest) []*model.Service {
+// getSnapshotPage resolves a snapshot page token to the snapshot ID, its frozen services and the start index
+func (c *CatalogService) getSnapshotPage(pageToken string) (string, []*model.Service, int32, error) {
+	if c.snapshots == nil {
+		return "", nil, 0, newError(codes.FailedPrecondition, ReasonSnapshotsDisabled, ErrInvalidRequest, "snapshots are not enabled")
+	}
+
+	id, offset, err := parseSnapshotToken(pageToken)
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	services, ok := c.snapshots.get(id)
+	if !ok {
+		return "", nil, 0, newInvalidArgumentError(ReasonSnapshotExpired, "snapshot expired or unknown, restart pagination")
+	}
+
+	// validate offset is within bounds
+	if offset < 0 || offset >= len(services) {
+		return "", nil, 0, newInvalidArgumentError(ReasonPageTokenOutOfRange, "page token out of range")
+	}
+
+	return id, services, int32(offset), nil
+}
+
+// paginateSnapshot paginates frozen snapshot results, issuing next page tokens that point back into the snapshot
+func (c *CatalogService) paginateSnapshot(id string, services []*model.Service, startIndex, pageSize int32, idsOnly bool) (*v1.ListServicesResponse, error) {
+	resp, err := c.paginateServices(services, startIndex, pageSize, idsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.NextPageToken != "" {
+		resp.NextPageToken = snapshotPageToken(id, startIndex+int32(len(resp.Services)))
+	}
+	return resp, nil
+}
+
+// filterServices filters the services based on organization ID and search query, stopping early if the context is done
+func (c *CatalogService) filterServices(ctx context.Context, services []*model.Service, req *v1.ListServicesRequest) ([]*model.Service, error) {
 	var filtered []*model.Service
+	orgs := requestedOrganizations(req)
+	query, prefix := c.parseSearchQuery(req.GetSearchQuery())
+	terms := strings.Fields(query)
+	fields, matchAll := c.searchOptions(req)
+
+	for i, s := range services {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
 
-	for _, s := range services {
-		// filter by organization ID if specified
-		if req.GetOrganizationId() != "" && s.OrganizationID != req.GetOrganizationId() {
+		// filter by organization IDs if specified, matching any of them
+		if len(orgs) > 0 && !orgs[s.OrganizationID] {
 			continue
 		}
 
 		// filter by search query if specified
 		if req.GetSearchQuery() != "" {
-			query := strings.ToLower(strings.TrimSpace(req.GetSearchQuery()))
-			name := strings.ToLower(s.Name)
-			description := strings.ToLower(s.Description)
-
-			if !strings.Contains(name, query) && !strings.Contains(description, query) {
+			if prefix {
+				if !strings.HasPrefix(strings.ToLower(s.Name), query) {
+					continue
+				}
+			} else if !matchesSearchTerms(s, terms, fields, matchAll) {
 				continue
 			}
 		}
 
+		// filter by version string if specified
+		if req.GetVersion() != "" && !c.hasMatchingVersion(s, req.GetVersion()) {
+			continue
+		}
+
 		filtered = append(filtered, s)
 	}
 
-	return filtered
+	return filtered, nil
 }
 
-// sortServices sorts the services based on the specified field and order
-func (c *CatalogService) sortServices(services []*model.Service, sortBy, sortOrder string) {
-	// Set defaults
-	if sortBy == "" {
-		sortBy = "name"
+// requestedOrganizations returns the organizations a list request filters by, organization_id together with
+// organization_ids, or nil when it does not filter by organization
+func requestedOrganizations(req *v1.ListServicesRequest) map[string]bool {
+	if req.GetOrganizationId() == "" && len(req.GetOrganizationIds()) == 0 {
+		return nil
+	}
+	orgs := make(map[string]bool, len(req.GetOrganizationIds())+1)
+	if req.GetOrganizationId() != "" {
+		orgs[req.GetOrganizationId()] = true
 	}
-	if sortOrder == "" {
-		sortOrder = "asc"
+	for _, orgID := range req.GetOrganizationIds() {
+		orgs[orgID] = true
+	}
+	return orgs
+}
+
+// hasMatchingVersion reports whether any version of the service matches the version filter exactly,
+// or by prefix when wildcard search is enabled and the filter ends with "*"
+func (c *CatalogService) hasMatchingVersion(s *model.Service, version string) bool {
+	prefix := c.searchWildcard && strings.HasSuffix(version, searchWildcard)
+	if prefix {
+		version = strings.TrimSuffix(version, searchWildcard)
 	}
 
-	// validate sort fields
-	if !validSortFields[sortBy] {
-		sortBy = "name"
+	for _, v := range s.Versions {
+		if prefix && strings.HasPrefix(v.Version, version) {
+			return true
+		}
+		if !prefix && v.Version == version {
+			return true
+		}
 	}
+	return false
+}
 
-	// validate sort order
-	if !validSortOrders[sortOrder] {
-		sortOrder = "asc"
+// searchOptions returns the fields to search and whether all terms must match, falling back to the configured defaults
+func (c *CatalogService) searchOptions(req *v1.ListServicesRequest) (string, bool) {
+	fields := req.GetSearchFields()
+	if fields == "" {
+		fields = c.searchFields
+	}
+	if fields == "" {
+		fields = SearchFieldsNameDescription
 	}
 
-	sort.Slice(services, func(i, j int) bool {
-		var result bool
+	match := req.GetSearchMatch()
+	if match == "" {
+		match = c.searchMatch
+	}
+	return fields, match != SearchMatchAny
+}
 
-		switch sortBy {
-		case "name":
-			result = services[i].Name < services[j].Name
-		case "created_at":
-			result = services[i].CreatedAt.Before(services[j].CreatedAt)
-		case "updated_at":
-			result = services[i].UpdatedAt.Before(services[j].UpdatedAt)
-		default:
-			result = services[i].Name < services[j].Name
+// matchesSearchTerms reports whether all (or any) of the lowercased terms appear in one of the searched fields
+func matchesSearchTerms(s *model.Service, terms []string, fields string, matchAll bool) bool {
+	if len(terms) == 0 {
+		return true
+	}
+
+	values := []string{strings.ToLower(s.Name)}
+	if fields != SearchFieldsName {
+		values = append(values, strings.ToLower(s.Description))
+	}
+	if fields == SearchFieldsNameDescriptionVersion {
+		for _, v := range s.Versions {
+			values = append(values, strings.ToLower(v.Version))
+		}
+	}
+
+	for _, term := range terms {
+		found := false
+		for _, value := range values {
+			if strings.Contains(value, term) {
+				found = true
+				break
+			}
+		}
+		if found && !matchAll {
+			return true
+		}
+		if !found && matchAll {
+			return false
+		}
+	}
+	return matchAll
+}
+
+// parseSearchQuery normalizes a search query and reports whether it is a trailing-wildcard prefix match
+func (c *CatalogService) parseSearchQuery(searchQuery string) (string, bool) {
+	query := strings.ToLower(strings.TrimSpace(searchQuery))
+	if c.searchWildcard && strings.HasSuffix(query, searchWildcard) {
+		return strings.TrimSuffix(query, searchWildcard), true
+	}
+	return query, false
+}
+
+// filterVersions flattens the versions of the given services, keeping those matching the updated_after and is_active filters
+func (c *CatalogService) filterVersions(ctx context.Context, services []*model.Service, req *v1.ListRecentVersionsRequest) ([]*model.ServiceVersion, error) {
+	var filtered []*model.ServiceVersion
+
+	for i, s := range services {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, v := range s.Versions {
+			if versionMatches(v, req.GetUpdatedAfter(), req.IsActive) {
+				filtered = append(filtered, v)
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// searchVersions returns a result for every version of the given services whose version string or description
+// contains each search term and that passes the is_active filter, ordered by service ID and then data file order
+func (c *CatalogService) searchVersions(ctx context.Context, services []*model.Service, req *v1.SearchVersionsRequest) ([]*v1.VersionSearchResult, error) {
+	terms := strings.Fields(strings.ToLower(req.GetSearchQuery()))
+	var results []*v1.VersionSearchResult
+	for i, s := range services {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, v := range s.Versions {
+			if !versionMatches(v, nil, req.IsActive) || !versionMatchesTerms(v, terms) {
+				continue
+			}
+			results = append(results, &v1.VersionSearchResult{
+				ServiceId: s.ID,
+				Version:   convertVersionToProto(v),
+			})
 		}
+	}
+
+	return results, nil
+}
 
-		if sortOrder == "desc" {
-			result = !result
+// versionMatchesTerms reports whether every lowercase term appears in the version string or description
+func versionMatchesTerms(v *model.ServiceVersion, terms []string) bool {
+	version, description := strings.ToLower(v.Version), strings.ToLower(v.Description)
+	for _, term := range terms {
+		if !strings.Contains(version, term) && !strings.Contains(description, term) {
+			return false
 		}
+	}
+	return true
+}
+
+// versionMatches reports whether a version passes the optional update time and active flag filters
+func versionMatches(v *model.ServiceVersion, updatedAfter *timestamppb.Timestamp, isActive *bool) bool {
+	// filter by update time if specified
+	if updatedAfter != nil && !v.UpdatedAt.After(updatedAfter.AsTime()) {
+		return false
+	}
+
+	// filter by active flag if specified
+	if isActive != nil && v.IsActive != *isActive {
+		return false
+	}
+
+	return true
+}
 
-		return result
+// sortServices sorts the services based on the specified field and order, as resolved by resolveSort;
+// anything else sorts by name ascending.
+// The order is total, so repeated calls always agree and page boundaries never move: services equal on the
+// sort field are ordered by ID ascending in either direction (by name first for version_count).
+// Once the context is done the remaining comparisons short-circuit and the context error is returned.
+func (c *CatalogService) sortServices(ctx context.Context, services []*model.Service, sortBy, sortOrder string) error {
+	comparisons := 0
+	cancelled := false
+	sort.SliceStable(services, func(i, j int) bool {
+		if cancelled {
+			return false
+		}
+		comparisons++
+		if comparisons%contextCheckInterval == 0 && ctx.Err() != nil {
+			cancelled = true
+			return false
+		}
+		return serviceSortKey(services[i]).less(serviceSortKey(services[j]), sortBy, sortOrder)
 	})
+
+	if cancelled {
+		return contextError(ctx)
+	}
+	return nil
+}
+
+// firstServices returns the first n services in the order sortServices would give, keeping only n services
+// in a heap instead of sorting all of them
+func (c *CatalogService) firstServices(ctx context.Context, services []*model.Service, n int, sortBy, sortOrder string) ([]*model.Service, error) {
+	if len(services) <= n {
+		return services, c.sortServices(ctx, services, sortBy, sortOrder)
+	}
+
+	// the heap top is the kept service listed last, the one a service listed before it replaces
+	h := &lastServiceHeap{sortBy: sortBy, sortOrder: sortOrder}
+	for i, s := range services {
+		if i%contextCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+		}
+		key := serviceSortKey(s)
+		if h.Len() < n {
+			heap.Push(h, keyedService{key: key, service: s})
+			continue
+		}
+		if key.less(h.services[0].key, sortBy, sortOrder) {
+			h.services[0] = keyedService{key: key, service: s}
+			heap.Fix(h, 0)
+		}
+	}
+
+	first := make([]*model.Service, 0, n)
+	for _, ks := range h.services {
+		first = append(first, ks.service)
+	}
+	return first, c.sortServices(ctx, first, sortBy, sortOrder)
+}
+
+type keyedService struct {
+	key     sortKey
+	service *model.Service
+}
+
+// lastServiceHeap is a heap.Interface with the service listed last on top
+type lastServiceHeap struct {
+	services  []keyedService
+	sortBy    string
+	sortOrder string
+}
+
+func (h *lastServiceHeap) Len() int { return len(h.services) }
+func (h *lastServiceHeap) Less(i, j int) bool {
+	return h.services[j].key.less(h.services[i].key, h.sortBy, h.sortOrder)
+}
+func (h *lastServiceHeap) Swap(i, j int) { h.services[i], h.services[j] = h.services[j], h.services[i] }
+func (h *lastServiceHeap) Push(x any)    { h.services = append(h.services, x.(keyedService)) }
+func (h *lastServiceHeap) Pop() any {
+	last := h.services[len(h.services)-1]
+	h.services = h.services[:len(h.services)-1]
+	return last
+}
+
+// sortKey holds the fields listings are sorted by, so a position in a listing can be kept in a page token
+type sortKey struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	VersionCount int       `json:"version_count"`
+}
+
+func serviceSortKey(s *model.Service) sortKey {
+	return sortKey{ID: s.ID, Name: s.Name, CreatedAt: s.CreatedAt.Time, UpdatedAt: s.UpdatedAt.Time, VersionCount: len(s.Versions)}
+}
+
+// less reports whether a service with key a is listed before one with key b
+func (a sortKey) less(b sortKey, sortBy, sortOrder string) bool {
+	var result, tie bool
+
+	switch sortBy {
+	case "created_at":
+		result, tie = a.CreatedAt.Before(b.CreatedAt), a.CreatedAt.Equal(b.CreatedAt)
+	case "updated_at":
+		result, tie = a.UpdatedAt.Before(b.UpdatedAt), a.UpdatedAt.Equal(b.UpdatedAt)
+	case "version_count":
+		result, tie = a.VersionCount < b.VersionCount, a.VersionCount == b.VersionCount
+		// Ties are ordered by name ascending in either direction so pages are deterministic
+		if tie && a.Name != b.Name {
+			return a.Name < b.Name
+		}
+	default:
+		result, tie = a.Name < b.Name, a.Name == b.Name
+	}
+
+	// The ID breaks remaining ties ascending in either direction, IDs are unique so the order is total
+	if tie {
+		return a.ID < b.ID
+	}
+	if sortOrder == "desc" {
+		result = !result
+	}
+
+	return result
+}
+
+// contextError maps a done context to the matching gRPC status, or returns nil if the context is still active
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	default:
+		return status.Error(codes.Canceled, "request cancelled")
+	}
 }
 
 // getServiceByID retrieves a service by its ID, returning an error if not found
 func (c *CatalogService) getServiceByID(id string) (*model.Service, error) {
-	svc, ok := c.data[id]
+	svc, ok := c.catalog()[id]
 	if !ok {
 		logger.Get().Warnw("Service not found", "service_id", id)
-		return nil, status.Errorf(codes.NotFound, "%v: service with ID '%s' not found", ErrServiceNotFound, id)
+		return nil, newNotFoundError(ReasonServiceNotFound, ErrServiceNotFound, "service with ID '%s' not found", id)
 	}
 	return svc, nil
 }
@@ -395,29 +3121,137 @@ func (c *CatalogService) getServiceByID(id string) (*model.Service, error) {
 func convertVersionsToProto(versions []*model.ServiceVersion) []*v1.ServiceVersion {
 	protoVersions := make([]*v1.ServiceVersion, 0, len(versions))
 	for _, v := range versions {
-		protoVersions = append(protoVersions, &v1.ServiceVersion{
-			Id:          v.ID,
-			Version:     v.Version,
-			ServiceId:   v.ServiceID,
-			Description: v.Description,
-			IsActive:    v.IsActive,
-			CreatedAt:   timestamppb.New(v.CreatedAt),
-			UpdatedAt:   timestamppb.New(v.UpdatedAt),
-		})
+		protoVersions = append(protoVersions, convertVersionToProto(v))
 	}
 	return protoVersions
 }
 
+// convertVersionToProto converts a ServiceVersion model to a ServiceVersion protobuf message
+func convertVersionToProto(v *model.ServiceVersion) *v1.ServiceVersion {
+	return &v1.ServiceVersion{
+		Id:          v.ID,
+		Version:     v.Version,
+		ServiceId:   v.ServiceID,
+		Description: v.Description,
+		IsActive:    v.IsActive,
+		CreatedAt:   timestamppb.New(v.CreatedAt.Time),
+		UpdatedAt:   timestamppb.New(v.UpdatedAt.Time),
+	}
+}
+
+// toProtoService converts a Service model to a Service protobuf message carrying its organization's display name
+func (c *CatalogService) toProtoService(s *model.Service) *v1.Service {
+	svc := convertToProtoService(s)
+	if s.OrganizationID != "" {
+		svc.OrganizationName = c.organizationName(s.OrganizationID)
+	}
+	return svc
+}
+
+// toProtoServices converts services in order with toProtoService
+func (c *CatalogService) toProtoServices(services []*model.Service) []*v1.Service {
+	protoServices := make([]*v1.Service, 0, len(services))
+	for _, s := range services {
+		protoServices = append(protoServices, c.toProtoService(s))
+	}
+	return protoServices
+}
+
 // convertToProtoService converts a Service model to a Service protobuf message
 func convertToProtoService(s *model.Service) *v1.Service {
 	return &v1.Service{
-		Id:             s.ID,
-		Name:           s.Name,
-		Description:    s.Description,
-		OrganizationId: s.OrganizationID,
-		Url:            s.URL,
-		CreatedAt:      timestamppb.New(s.CreatedAt),
-		UpdatedAt:      timestamppb.New(s.UpdatedAt),
-		Versions:       convertVersionsToProto(s.Versions),
+		Id:                s.ID,
+		Name:              s.Name,
+		Description:       s.Description,
+		OrganizationId:    s.OrganizationID,
+		Url:               s.URL,
+		CreatedAt:         timestamppb.New(s.CreatedAt.Time),
+		UpdatedAt:         timestamppb.New(s.UpdatedAt.Time),
+		Versions:          convertVersionsToProto(s.Versions),
+		HasBreakingChange: hasBreakingChange(s),
+	}
+}
+
+// convertToServiceIndexEntry converts a Service model to a Service protobuf message carrying only its ID and
+// update time, as listed for ids_only requests
+func convertToServiceIndexEntry(s *model.Service) *v1.Service {
+	return &v1.Service{
+		Id:        s.ID,
+		UpdatedAt: timestamppb.New(s.UpdatedAt.Time),
+	}
+}
+
+// serviceFromProto converts a Service protobuf message to a Service model. Computed fields are ignored and
+// unset timestamps are left zero.
+func serviceFromProto(s *v1.Service) *model.Service {
+	svc := &model.Service{
+		ID:             s.GetId(),
+		Name:           s.GetName(),
+		Description:    s.GetDescription(),
+		OrganizationID: s.GetOrganizationId(),
+		URL:            s.GetUrl(),
+		CreatedAt:      protoTime(s.GetCreatedAt()),
+		UpdatedAt:      protoTime(s.GetUpdatedAt()),
+	}
+	for _, v := range s.GetVersions() {
+		if v == nil {
+			continue
+		}
+		svc.Versions = append(svc.Versions, &model.ServiceVersion{
+			ID:          v.GetId(),
+			Version:     v.GetVersion(),
+			ServiceID:   v.GetServiceId(),
+			Description: v.GetDescription(),
+			IsActive:    v.GetIsActive(),
+			CreatedAt:   protoTime(v.GetCreatedAt()),
+			UpdatedAt:   protoTime(v.GetUpdatedAt()),
+		})
+	}
+	return svc
+}
+
+// protoTime converts a protobuf timestamp to a UTC model timestamp, the zero time when it is unset
+func protoTime(ts *timestamppb.Timestamp) model.Timestamp {
+	if ts == nil {
+		return model.Timestamp{}
+	}
+	return model.Timestamp{Time: ts.AsTime()}
+}
+
+// hasBreakingChange reports whether a service's versions span more than one semver major version.
+// Services with fewer than two versions, or any version that is not valid semver, report false.
+// It runs on every conversion, so it does not log; warnUnparseableVersions warns when the service is added.
+func hasBreakingChange(s *model.Service) bool {
+	if len(s.Versions) < 2 {
+		return false
+	}
+
+	majors := make(map[int]bool)
+	for _, v := range s.Versions {
+		parsed, err := semver.Parse(v.Version)
+		if err != nil {
+			return false
+		}
+		majors[parsed.Major] = true
+	}
+
+	return len(majors) > 1
+}
+
+// warnUnparseableVersions warns about the first version of a service with several versions that is not valid
+// semver, as it keeps has_breaking_change false. It is called when the service is loaded or written.
+func warnUnparseableVersions(s *model.Service) {
+	if len(s.Versions) < 2 {
+		return
+	}
+	for _, v := range s.Versions {
+		if _, err := semver.Parse(v.Version); err != nil {
+			logger.Get().Warnw("Cannot determine breaking change for service with unparseable version",
+				"service_id", s.ID,
+				"version_id", v.ID,
+				"version", v.Version,
+				"error", err)
+			return
+		}
 	}
 }
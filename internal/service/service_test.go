@@ -2,12 +2,30 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ankittk/catalog-service/internal/auth"
+	"github.com/ankittk/catalog-service/internal/idgen"
+	"github.com/ankittk/catalog-service/internal/logger"
 	"github.com/ankittk/catalog-service/internal/model"
 	v1 "github.com/ankittk/catalog-service/proto/v1"
 )
@@ -29,8 +47,8 @@ func mockTestData() map[string]*model.Service {
 		Description:    "Handles user authentication and profile management",
 		OrganizationID: "org-1",
 		URL:            "https://services.example.com/user",
-		CreatedAt:      createdAt1,
-		UpdatedAt:      updatedAt1,
+		CreatedAt:      model.Timestamp{Time: createdAt1},
+		UpdatedAt:      model.Timestamp{Time: updatedAt1},
 		Versions: []*model.ServiceVersion{
 			{
 				ID:          "v1",
@@ -38,8 +56,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-1",
 				Description: "Initial stable release",
 				IsActive:    false,
-				CreatedAt:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
-				UpdatedAt:   time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+				CreatedAt:   model.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+				UpdatedAt:   model.Timestamp{Time: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)},
 			},
 			{
 				ID:          "v2",
@@ -47,8 +65,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-1",
 				Description: "Added OAuth support",
 				IsActive:    true,
-				CreatedAt:   time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC),
-				UpdatedAt:   updatedAt1,
+				CreatedAt:   model.Timestamp{Time: time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)},
+				UpdatedAt:   model.Timestamp{Time: updatedAt1},
 			},
 		},
 	}
@@ -59,8 +77,8 @@ func mockTestData() map[string]*model.Service {
 		Description:    "Facilitates payments and transaction management",
 		OrganizationID: "org-2",
 		URL:            "https://services.example.com/payment",
-		CreatedAt:      createdAt2,
-		UpdatedAt:      updatedAt2,
+		CreatedAt:      model.Timestamp{Time: createdAt2},
+		UpdatedAt:      model.Timestamp{Time: updatedAt2},
 		Versions: []*model.ServiceVersion{
 			{
 				ID:          "v1",
@@ -68,8 +86,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-2",
 				Description: "Supports Stripe and Razorpay",
 				IsActive:    true,
-				CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-				UpdatedAt:   time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+				CreatedAt:   model.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				UpdatedAt:   model.Timestamp{Time: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
 			},
 		},
 	}
@@ -80,8 +98,8 @@ func mockTestData() map[string]*model.Service {
 		Description:    "Tracks product availability and stock levels",
 		OrganizationID: "org-1",
 		URL:            "https://services.example.com/inventory",
-		CreatedAt:      createdAt3,
-		UpdatedAt:      updatedAt3,
+		CreatedAt:      model.Timestamp{Time: createdAt3},
+		UpdatedAt:      model.Timestamp{Time: updatedAt3},
 		Versions: []*model.ServiceVersion{
 			{
 				ID:          "v1",
@@ -89,8 +107,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-3",
 				Description: "Initial version",
 				IsActive:    false,
-				CreatedAt:   createdAt3,
-				UpdatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				CreatedAt:   model.Timestamp{Time: createdAt3},
+				UpdatedAt:   model.Timestamp{Time: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
 			},
 			{
 				ID:          "v2",
@@ -98,8 +116,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-3",
 				Description: "Optimized warehouse sync",
 				IsActive:    true,
-				CreatedAt:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
-				UpdatedAt:   updatedAt3,
+				CreatedAt:   model.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+				UpdatedAt:   model.Timestamp{Time: updatedAt3},
 			},
 		},
 	}
@@ -110,8 +128,8 @@ func mockTestData() map[string]*model.Service {
 		Description:    "Generates usage and engagement reports",
 		OrganizationID: "org-3",
 		URL:            "https://services.example.com/analytics",
-		CreatedAt:      createdAt4,
-		UpdatedAt:      updatedAt4,
+		CreatedAt:      model.Timestamp{Time: createdAt4},
+		UpdatedAt:      model.Timestamp{Time: updatedAt4},
 		Versions: []*model.ServiceVersion{
 			{
 				ID:          "v1",
@@ -119,8 +137,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-4",
 				Description: "Beta release",
 				IsActive:    false,
-				CreatedAt:   createdAt4,
-				UpdatedAt:   time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC),
+				CreatedAt:   model.Timestamp{Time: createdAt4},
+				UpdatedAt:   model.Timestamp{Time: time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)},
 			},
 			{
 				ID:          "v2",
@@ -128,8 +146,8 @@ func mockTestData() map[string]*model.Service {
 				ServiceID:   "svc-4",
 				Description: "First stable release",
 				IsActive:    true,
-				CreatedAt:   time.Date(2024, 6, 1, 14, 0, 0, 0, time.UTC),
-				UpdatedAt:   updatedAt4,
+				CreatedAt:   model.Timestamp{Time: time.Date(2024, 6, 1, 14, 0, 0, 0, time.UTC)},
+				UpdatedAt:   model.Timestamp{Time: updatedAt4},
 			},
 		},
 	}
@@ -142,9 +160,28 @@ func mockTestData() map[string]*model.Service {
 	}
 }
 
+// newTestCatalogService returns a CatalogService serving data, configured by opts
+func newTestCatalogService(data map[string]*model.Service, opts ...Option) *CatalogService {
+	svc := &CatalogService{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	svc.data.Store(&data)
+	return svc
+}
+
+// servicesOf returns the services in data as a slice
+func servicesOf(data map[string]*model.Service) []*model.Service {
+	services := make([]*model.Service, 0, len(data))
+	for _, s := range data {
+		services = append(services, s)
+	}
+	return services
+}
+
 func TestCatalogService_ListServices(t *testing.T) {
 	testData := mockTestData()
-	svc := &CatalogService{data: testData}
+	svc := newTestCatalogService(testData)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -238,7 +275,7 @@ func TestCatalogService_ListServices(t *testing.T) {
 
 func TestCatalogService_GetService(t *testing.T) {
 	testData := mockTestData()
-	svc := &CatalogService{data: testData}
+	svc := newTestCatalogService(testData)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -318,7 +355,7 @@ func TestCatalogService_GetService(t *testing.T) {
 
 func TestCatalogService_GetServiceVersions(t *testing.T) {
 	testData := mockTestData()
-	svc := &CatalogService{data: testData}
+	svc := newTestCatalogService(testData)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -709,7 +746,7 @@ func TestCatalogService_paginateServices(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := svc.paginateServices(tt.services, tt.startIndex, tt.pageSize)
+			got, err := svc.paginateServices(tt.services, tt.startIndex, tt.pageSize, false)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -787,7 +824,8 @@ func TestCatalogService_filterServices(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := svc.filterServices(tt.services, tt.req)
+			got, err := svc.filterServices(context.Background(), tt.services, tt.req)
+			assert.NoError(t, err)
 			assert.Len(t, got, len(tt.want))
 
 			// Create maps for easier comparison regardless of order
@@ -870,7 +908,8 @@ func TestCatalogService_sortServices(t *testing.T) {
 			servicesCopy := make([]*model.Service, len(tt.services))
 			copy(servicesCopy, tt.services)
 
-			svc.sortServices(servicesCopy, tt.sortBy, tt.sortOrder)
+			err := svc.sortServices(context.Background(), servicesCopy, tt.sortBy, tt.sortOrder)
+			assert.NoError(t, err)
 
 			assert.Len(t, servicesCopy, len(tt.services))
 
@@ -886,3 +925,3123 @@ func TestCatalogService_sortServices(t *testing.T) {
 		})
 	}
 }
+
+func TestCatalogService_ListRecentVersions(t *testing.T) {
+	testData := mockTestData()
+	svc := newTestCatalogService(testData)
+	ctx := context.Background()
+
+	active := true
+	inactive := false
+
+	tests := []struct {
+		name          string
+		req           *v1.ListRecentVersionsRequest
+		wantServices  []string
+		wantVersions  []string
+		wantTotal     int32
+		wantNextToken string
+		wantErr       bool
+	}{
+		{
+			name:         "all versions most recently updated first",
+			req:          &v1.ListRecentVersionsRequest{},
+			wantServices: []string{"svc-1", "svc-3", "svc-4", "svc-2", "svc-1", "svc-4", "svc-3"},
+			wantVersions: []string{"v1.1.0", "v2.0.0", "v1.0.0", "v2.0.0", "v1.0.0", "v0.1.0", "v1.0.0"},
+			wantTotal:    7,
+		},
+		{
+			name: "filter by updated_after",
+			req: &v1.ListRecentVersionsRequest{
+				UpdatedAfter: timestamppb.New(time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)),
+			},
+			wantServices: []string{"svc-1", "svc-3"},
+			wantVersions: []string{"v1.1.0", "v2.0.0"},
+			wantTotal:    2,
+		},
+		{
+			name:         "filter active versions",
+			req:          &v1.ListRecentVersionsRequest{IsActive: &active},
+			wantServices: []string{"svc-1", "svc-3", "svc-4", "svc-2"},
+			wantVersions: []string{"v1.1.0", "v2.0.0", "v1.0.0", "v2.0.0"},
+			wantTotal:    4,
+		},
+		{
+			name:         "filter inactive versions",
+			req:          &v1.ListRecentVersionsRequest{IsActive: &inactive},
+			wantServices: []string{"svc-1", "svc-4", "svc-3"},
+			wantVersions: []string{"v1.0.0", "v0.1.0", "v1.0.0"},
+			wantTotal:    3,
+		},
+		{
+			name:          "first page",
+			req:           &v1.ListRecentVersionsRequest{PageSize: 3},
+			wantServices:  []string{"svc-1", "svc-3", "svc-4"},
+			wantVersions:  []string{"v1.1.0", "v2.0.0", "v1.0.0"},
+			wantTotal:     7,
+			wantNextToken: "page_3",
+		},
+		{
+			name:         "last page",
+			req:          &v1.ListRecentVersionsRequest{PageSize: 3, PageToken: "page_6"},
+			wantServices: []string{"svc-3"},
+			wantVersions: []string{"v1.0.0"},
+			wantTotal:    7,
+		},
+		{
+			name:    "invalid page size",
+			req:     &v1.ListRecentVersionsRequest{PageSize: 150},
+			wantErr: true,
+		},
+		{
+			name:    "invalid page token",
+			req:     &v1.ListRecentVersionsRequest{PageToken: "invalid_token"},
+			wantErr: true,
+		},
+		{
+			name:    "nil request",
+			req:     nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ListRecentVersions(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.req == nil {
+					assert.Contains(t, err.Error(), "request cannot be nil")
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, got.TotalCount)
+			assert.Equal(t, tt.wantNextToken, got.NextPageToken)
+
+			gotServices := make([]string, 0, len(got.Versions))
+			gotVersions := make([]string, 0, len(got.Versions))
+			for _, v := range got.Versions {
+				gotServices = append(gotServices, v.ServiceId)
+				gotVersions = append(gotVersions, v.Version)
+			}
+			assert.Equal(t, tt.wantServices, gotServices)
+			assert.Equal(t, tt.wantVersions, gotVersions)
+		})
+	}
+}
+
+func TestCatalogService_ListRecentVersions_Scope(t *testing.T) {
+	versions := func(resp *v1.ListRecentVersionsResponse) []string {
+		ids := []string{}
+		for _, v := range resp.GetVersions() {
+			ids = append(ids, v.GetServiceId()+"/"+v.GetId())
+		}
+		return ids
+	}
+
+	t.Run("caller's organization only", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+		resp, err := svc.ListRecentVersions(ctx, &v1.ListRecentVersionsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1/v2", "svc-3/v2", "svc-1/v1", "svc-3/v1"}, versions(resp))
+		assert.Equal(t, int32(4), resp.GetTotalCount())
+	})
+
+	t.Run("local shard only", func(t *testing.T) {
+		// With 4 shards svc-1 and svc-3 hash to shard 1
+		store := model.NewStore(0)
+		assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+		store.SetSharding(model.NewHashRing(4), 1)
+		resp, err := NewCatalogService(store).ListRecentVersions(context.Background(), &v1.ListRecentVersionsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1/v2", "svc-3/v2", "svc-1/v1", "svc-3/v1"}, versions(resp))
+	})
+}
+
+func TestCatalogService_SearchVersions(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+	active := true
+
+	tests := []struct {
+		name          string
+		req           *v1.SearchVersionsRequest
+		wantResults   []string
+		wantTotal     int32
+		wantNextToken string
+		wantReason    Reason
+	}{
+		{name: "description match", req: &v1.SearchVersionsRequest{SearchQuery: "OAuth"}, wantResults: []string{"svc-1/v2"}, wantTotal: 1},
+		{name: "case-insensitive", req: &v1.SearchVersionsRequest{SearchQuery: "oauth"}, wantResults: []string{"svc-1/v2"}, wantTotal: 1},
+		{name: "version string match", req: &v1.SearchVersionsRequest{SearchQuery: "v2.0"}, wantResults: []string{"svc-2/v1", "svc-3/v2"}, wantTotal: 2},
+		{name: "one result per matching version", req: &v1.SearchVersionsRequest{SearchQuery: "release"}, wantResults: []string{"svc-1/v1", "svc-4/v1", "svc-4/v2"}, wantTotal: 3},
+		{name: "every term must match", req: &v1.SearchVersionsRequest{SearchQuery: "stable release"}, wantResults: []string{"svc-1/v1", "svc-4/v2"}, wantTotal: 2},
+		{name: "active versions only", req: &v1.SearchVersionsRequest{SearchQuery: "release", IsActive: &active}, wantResults: []string{"svc-4/v2"}, wantTotal: 1},
+		{name: "first page", req: &v1.SearchVersionsRequest{SearchQuery: "release", PageSize: 2}, wantResults: []string{"svc-1/v1", "svc-4/v1"}, wantTotal: 3, wantNextToken: "page_2"},
+		{name: "last page", req: &v1.SearchVersionsRequest{SearchQuery: "release", PageSize: 2, PageToken: "page_2"}, wantResults: []string{"svc-4/v2"}, wantTotal: 3},
+		{name: "no match", req: &v1.SearchVersionsRequest{SearchQuery: "graphql"}, wantResults: []string{}, wantTotal: 0},
+		{name: "missing query", req: &v1.SearchVersionsRequest{SearchQuery: "  "}, wantReason: ReasonInvalidSearchQuery},
+		{name: "query too long", req: &v1.SearchVersionsRequest{SearchQuery: strings.Repeat("a", 101)}, wantReason: ReasonInvalidSearchQuery},
+		{name: "invalid page size", req: &v1.SearchVersionsRequest{SearchQuery: "release", PageSize: 150}, wantReason: ReasonInvalidPageSize},
+		{name: "nil request", wantReason: ReasonMissingRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.SearchVersions(ctx, tt.req)
+			if tt.wantReason != "" {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, tt.wantReason, ReasonOf(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, got.GetTotalCount())
+			assert.Equal(t, tt.wantNextToken, got.GetNextPageToken())
+
+			results := make([]string, 0, len(got.GetResults()))
+			for _, r := range got.GetResults() {
+				results = append(results, r.GetServiceId()+"/"+r.GetVersion().GetId())
+			}
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestCatalogService_SearchVersions_Scope(t *testing.T) {
+	results := func(resp *v1.SearchVersionsResponse) []string {
+		ids := []string{}
+		for _, r := range resp.GetResults() {
+			ids = append(ids, r.GetServiceId()+"/"+r.GetVersion().GetId())
+		}
+		return ids
+	}
+	req := &v1.SearchVersionsRequest{SearchQuery: "release"}
+
+	t.Run("caller's organization only", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-3", Role: "user"})
+		resp, err := svc.SearchVersions(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-4/v1", "svc-4/v2"}, results(resp))
+		assert.Equal(t, int32(2), resp.GetTotalCount())
+	})
+
+	t.Run("local shard only", func(t *testing.T) {
+		// With 4 shards svc-1 and svc-3 hash to shard 1
+		store := model.NewStore(0)
+		assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+		store.SetSharding(model.NewHashRing(4), 1)
+		resp, err := NewCatalogService(store).SearchVersions(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1/v1"}, results(resp))
+	})
+}
+
+func TestHasBreakingChange(t *testing.T) {
+	testData := mockTestData()
+
+	tests := []struct {
+		name    string
+		service *model.Service
+		want    bool
+	}{
+		{"minor bump only", testData["svc-1"], false},
+		{"single version", testData["svc-2"], false},
+		{"v1.x then v2.0.0", testData["svc-3"], true},
+		{"v0.x then v1.0.0", testData["svc-4"], true},
+		{
+			name: "unparseable version",
+			service: &model.Service{
+				ID: "svc-5",
+				Versions: []*model.ServiceVersion{
+					{ID: "v1", Version: "v1.0.0"},
+					{ID: "v2", Version: "latest"},
+					{ID: "v3", Version: "v2.0.0"},
+				},
+			},
+			want: false,
+		},
+		{"no versions", &model.Service{ID: "svc-6"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasBreakingChange(tt.service))
+		})
+	}
+}
+
+func TestCatalogService_WarnsUnparseableVersionsOnce(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	t.Cleanup(func() { logger.SetLogger(previous) })
+	warnings := func() int {
+		return logs.FilterMessage("Cannot determine breaking change for service with unparseable version").Len()
+	}
+
+	data := mockTestData()
+	data["svc-1"].Versions[1].Version = "latest"
+	svc := newTestCatalogService(nil)
+	require.NoError(t, svc.ReplaceServices(servicesOf(data)))
+	assert.Equal(t, 1, warnings(), "warned when loaded")
+
+	// Reads convert the service without warning again
+	for i := 0; i < 3; i++ {
+		resp, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+		require.NoError(t, err)
+		assert.False(t, resp.GetService().GetHasBreakingChange())
+	}
+	_, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, warnings())
+
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+	_, err = svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: []*v1.Service{{
+		Id:   "svc-5",
+		Name: "Search Service",
+		Versions: []*v1.ServiceVersion{
+			{Id: "v1", Version: "v1.0.0"},
+			{Id: "v2", Version: "next"},
+		},
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, warnings(), "warned when created")
+}
+
+func TestCatalogService_GetService_HasBreakingChange(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	got, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-3"})
+	assert.NoError(t, err)
+	assert.True(t, got.Service.HasBreakingChange)
+
+	got, err = svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	assert.NoError(t, err)
+	assert.False(t, got.Service.HasBreakingChange)
+}
+
+func TestCatalogService_filterServices_Cancelled(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	services := make([]*model.Service, 100000)
+	for i := range services {
+		services[i] = &model.Service{ID: "svc", Name: "Service", OrganizationID: "org-1"}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := svc.filterServices(ctx, services, &v1.ListServicesRequest{SearchQuery: "service"})
+	assert.Nil(t, got)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestCatalogService_ListServices_DeadlineExceeded(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	got, err := svc.ListServices(ctx, &v1.ListServicesRequest{})
+	assert.Nil(t, got)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestCatalogService_ListServices_Snapshot(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	svc.snapshots = newSnapshotStore(DefaultSnapshotTTL)
+
+	first, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 2, Snapshot: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Analytics Service", "Inventory Service"}, serviceNames(first.Services))
+	assert.True(t, strings.HasPrefix(first.NextPageToken, snapshotTokenPrefix))
+
+	// mutate the catalog mid-pagination: one service is removed and one sorting first is added
+	mutated := mockTestData()
+	delete(mutated, "svc-2")
+	mutated["svc-5"] = &model.Service{ID: "svc-5", Name: "Auth Service", OrganizationID: "org-1"}
+	svc.ReplaceServices(servicesOf(mutated))
+
+	second, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 2, PageToken: first.NextPageToken})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Payment Gateway", "User Service"}, serviceNames(second.Services))
+	assert.Equal(t, int32(4), second.TotalCount)
+	assert.Empty(t, second.NextPageToken)
+
+	// a fresh listing sees the live catalog
+	live, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Analytics Service", "Auth Service"}, serviceNames(live.Services))
+}
+
+func TestCatalogService_ListServices_SnapshotTokenErrors(t *testing.T) {
+	now := time.Now()
+	snapshots := newSnapshotStore(time.Minute)
+	snapshots.now = func() time.Time { return now }
+	svc := newTestCatalogService(mockTestData())
+	svc.snapshots = snapshots
+
+	first, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 1, Snapshot: true})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		pageToken string
+		advance   time.Duration
+	}{
+		{name: "malformed token", pageToken: "snap_abc"},
+		{name: "unknown snapshot", pageToken: "snap_deadbeef_1"},
+		{name: "offset out of range", pageToken: strings.TrimSuffix(first.NextPageToken, "_1") + "_10"},
+		{name: "expired snapshot", pageToken: first.NextPageToken, advance: 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 1, PageToken: tt.pageToken})
+			assert.Nil(t, got)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestCatalogService_ListServices_MaxSnapshots(t *testing.T) {
+	now := time.Now()
+	snapshots := newSnapshotStore(time.Hour)
+	snapshots.now = func() time.Time { return now }
+	svc := newTestCatalogService(mockTestData())
+	svc.snapshots = snapshots
+	WithMaxSnapshots(2)(svc)
+	ctx := context.Background()
+
+	open := func() (*v1.ListServicesResponse, error) {
+		return svc.ListServices(ctx, &v1.ListServicesRequest{PageSize: 1, Snapshot: true})
+	}
+	next := func(resp *v1.ListServicesResponse) error {
+		_, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageSize: 1, PageToken: resp.NextPageToken})
+		return err
+	}
+
+	first, err := open()
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	second, err := open()
+	require.NoError(t, err)
+
+	// every snapshot was read recently, so none is evicted
+	_, err = open()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, ReasonTooManySnapshots, ReasonOf(err))
+	assert.Equal(t, snapshotMinIdle-time.Second, err.(*Error).RetryDelay)
+
+	// reading the first snapshot makes the second the least recently used, which is evicted once idle
+	now = now.Add(snapshotMinIdle)
+	assert.NoError(t, next(first))
+	third, err := open()
+	require.NoError(t, err)
+
+	assert.NoError(t, next(first))
+	assert.NoError(t, next(third))
+	assert.Equal(t, ReasonSnapshotExpired, ReasonOf(next(second)))
+	assert.Len(t, snapshots.entries, 2)
+}
+
+func serviceNames(services []*v1.Service) []string {
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestCatalogService_validateListServicesRequest_SearchMinLength(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithSearchMinLength(3), WithSearchWildcard(true))
+
+	tests := []struct {
+		name        string
+		searchQuery string
+		wantErr     bool
+	}{
+		{name: "empty query skips the check", searchQuery: ""},
+		{name: "query at minimum length", searchQuery: "pay"},
+		{name: "single character rejected", searchQuery: "a", wantErr: true},
+		{name: "surrounding whitespace ignored", searchQuery: "  a  ", wantErr: true},
+		{name: "wildcard does not count", searchQuery: "pa*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.validateListServicesRequest(&v1.ListServicesRequest{SearchQuery: tt.searchQuery})
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Contains(t, err.Error(), "too short")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCatalogService_filterServices_Wildcard(t *testing.T) {
+	services := newTestCatalogService(mockTestData()).getAllServices()
+
+	tests := []struct {
+		name        string
+		wildcard    bool
+		searchQuery string
+		want        []string
+	}{
+		{name: "prefix wildcard matches name start", wildcard: true, searchQuery: "pay*", want: []string{"Payment Gateway"}},
+		{name: "prefix wildcard does not match mid-name", wildcard: true, searchQuery: "service*", want: nil},
+		{name: "query without wildcard keeps substring match", wildcard: true, searchQuery: "gateway", want: []string{"Payment Gateway"}},
+		{name: "wildcard disabled treats star literally", wildcard: false, searchQuery: "pay*", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), WithSearchWildcard(tt.wildcard))
+			got, err := svc.filterServices(context.Background(), services, &v1.ListServicesRequest{SearchQuery: tt.searchQuery})
+			assert.NoError(t, err)
+
+			var names []string
+			for _, s := range got {
+				names = append(names, s.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestCatalogService_ListServices_SortValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		strictSort bool
+		sortBy     string
+		sortOrder  string
+		wantErr    string
+		wantNames  []string
+	}{
+		{
+			name:      "lenient mode falls back to name asc",
+			sortBy:    "popularity",
+			sortOrder: "sideways",
+			wantNames: []string{"Analytics Service", "Inventory Service", "Payment Gateway", "User Service"},
+		},
+		{
+			name:       "strict mode rejects unknown sort_by",
+			strictSort: true,
+			sortBy:     "popularity",
+			wantErr:    "allowed values: created_at, name, updated_at",
+		},
+		{
+			name:       "strict mode rejects unknown sort_order",
+			strictSort: true,
+			sortOrder:  "sideways",
+			wantErr:    "allowed values: asc, desc",
+		},
+		{
+			name:       "strict mode accepts valid values",
+			strictSort: true,
+			sortBy:     "name",
+			sortOrder:  "desc",
+			wantNames:  []string{"User Service", "Payment Gateway", "Inventory Service", "Analytics Service"},
+		},
+		{
+			name:       "strict mode keeps empty values defaulted",
+			strictSort: true,
+			wantNames:  []string{"Analytics Service", "Inventory Service", "Payment Gateway", "User Service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), WithStrictSort(tt.strictSort))
+			got, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{SortBy: tt.sortBy, SortOrder: tt.sortOrder})
+
+			if tt.wantErr != "" {
+				assert.Nil(t, got)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNames, serviceNames(got.Services))
+		})
+	}
+}
+
+func TestCatalogService_DefaultSort(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantIDs []string
+	}{
+		{name: "built-in default", wantIDs: []string{"svc-4", "svc-3", "svc-2", "svc-1"}},
+		{name: "configured default", opts: []Option{WithDefaultSort("version_count", "desc")}, wantIDs: []string{"svc-4", "svc-3", "svc-1", "svc-2"}},
+		{name: "configured order only", opts: []Option{WithDefaultSort("", "desc")}, wantIDs: []string{"svc-1", "svc-2", "svc-3", "svc-4"}},
+		{name: "unrecognized defaults are ignored", opts: []Option{WithDefaultSort("popularity", "sideways")}, wantIDs: []string{"svc-4", "svc-3", "svc-2", "svc-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), tt.opts...)
+
+			list, err := svc.ListServices(ctx, &v1.ListServicesRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, serviceIDs(list.GetServices()), "ListServices")
+
+			list, err = svc.ListServices(ctx, &v1.ListServicesRequest{SkipTotalCount: true})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, serviceIDs(list.GetServices()), "ListServices without total count")
+
+			var exported []*v1.Service
+			_, err = svc.ExportServices(ctx, &v1.ListServicesRequest{}, func(s *v1.Service) error {
+				exported = append(exported, s)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, serviceIDs(exported), "ExportServices")
+
+			delta, err := svc.ListServicesDelta(ctx, &v1.ListServicesDeltaRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, serviceIDs(delta.GetServices()), "ListServicesDelta")
+		})
+	}
+}
+
+func TestCatalogService_DescribeCatalog(t *testing.T) {
+	orgCounts := func(pairs ...interface{}) []*v1.OrganizationServiceCount {
+		var counts []*v1.OrganizationServiceCount
+		for i := 0; i < len(pairs); i += 2 {
+			counts = append(counts, &v1.OrganizationServiceCount{OrganizationId: pairs[i].(string), ServiceCount: int32(pairs[i+1].(int))})
+		}
+		return counts
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		totalServices  int32
+		totalVersions  int32
+		activeVersions int32
+		perOrg         []*v1.OrganizationServiceCount
+		newestID       string
+		oldestID       string
+	}{
+		{
+			name:           "whole catalog without auth",
+			ctx:            context.Background(),
+			totalServices:  4,
+			totalVersions:  7,
+			activeVersions: 4,
+			perOrg:         orgCounts("org-1", 2, "org-2", 1, "org-3", 1),
+			newestID:       "svc-1",
+			oldestID:       "svc-3",
+		},
+		{
+			name:           "scoped to caller organization",
+			ctx:            context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1"}),
+			totalServices:  2,
+			totalVersions:  4,
+			activeVersions: 2,
+			perOrg:         orgCounts("org-1", 2),
+			newestID:       "svc-1",
+			oldestID:       "svc-3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData())
+			got, err := svc.DescribeCatalog(tt.ctx, &v1.DescribeCatalogRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.totalServices, got.TotalServices)
+			assert.Equal(t, tt.totalVersions, got.TotalVersions)
+			assert.Equal(t, tt.activeVersions, got.ActiveVersions)
+			assert.Equal(t, len(tt.perOrg), len(got.ServicesPerOrganization))
+			for i, want := range tt.perOrg {
+				assert.Equal(t, want.OrganizationId, got.ServicesPerOrganization[i].OrganizationId)
+				assert.Equal(t, want.ServiceCount, got.ServicesPerOrganization[i].ServiceCount)
+			}
+			assert.Equal(t, tt.newestID, got.NewestService.GetId())
+			assert.Equal(t, tt.oldestID, got.OldestService.GetId())
+		})
+	}
+
+	t.Run("unknown organization yields empty statistics", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-9"})
+		got, err := svc.DescribeCatalog(ctx, &v1.DescribeCatalogRequest{})
+		assert.NoError(t, err)
+		assert.Zero(t, got.TotalServices)
+		assert.Empty(t, got.ServicesPerOrganization)
+		assert.Nil(t, got.NewestService)
+		assert.Nil(t, got.OldestService)
+	})
+}
+
+func TestCatalogService_ListOrganizations(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	svc.ReplaceOrganizations([]*model.Organization{
+		{ID: "org-1", DisplayName: "Platform Team"},
+		{ID: "org-9", DisplayName: "New Team"},
+		{ID: "org-2"},
+	})
+
+	t.Run("display names with ID fallback", func(t *testing.T) {
+		got, err := svc.ListOrganizations(context.Background(), &v1.ListOrganizationsRequest{})
+		assert.NoError(t, err)
+		want := []*v1.Organization{
+			{Id: "org-1", DisplayName: "Platform Team", ServiceCount: 2},
+			{Id: "org-2", DisplayName: "org-2", ServiceCount: 1},
+			{Id: "org-3", DisplayName: "org-3", ServiceCount: 1},
+			{Id: "org-9", DisplayName: "New Team", ServiceCount: 0},
+		}
+		assert.Len(t, got.Organizations, len(want))
+		for i, w := range want {
+			assert.True(t, proto.Equal(w, got.Organizations[i]), "organization %d: got %v, want %v", i, got.Organizations[i], w)
+		}
+	})
+
+	t.Run("scoped to caller organization", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1"})
+		got, err := svc.ListOrganizations(ctx, &v1.ListOrganizationsRequest{})
+		assert.NoError(t, err)
+		assert.Len(t, got.Organizations, 1)
+		assert.Equal(t, "Platform Team", got.Organizations[0].DisplayName)
+	})
+
+	t.Run("services carry the organization name", func(t *testing.T) {
+		got, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Platform Team", got.Service.OrganizationName)
+
+		list, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{OrganizationId: "org-3"})
+		assert.NoError(t, err)
+		assert.Len(t, list.Services, 1)
+		assert.Equal(t, "org-3", list.Services[0].OrganizationName)
+	})
+}
+
+func TestCatalogService_validateListServicesRequest_OrganizationIDFormat(t *testing.T) {
+	uuidOrg := "urn:uuid:3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	longOrg := "tenant-3fa85f64-5717-4562-b3fc-2c963f66afa6-eu-west"
+
+	strict := newTestCatalogService(mockTestData())
+	relaxed := newTestCatalogService(mockTestData(),
+		WithOrganizationIDFormat(regexp.MustCompile(`^(urn:uuid:[0-9a-f-]{36}|[A-Za-z0-9_-]+)$`), 64))
+
+	tests := []struct {
+		name    string
+		svc     *CatalogService
+		orgID   string
+		wantErr bool
+	}{
+		{name: "default accepts short org ID", svc: strict, orgID: "org-1"},
+		{name: "default rejects uuid urn", svc: strict, orgID: uuidOrg, wantErr: true},
+		{name: "default rejects org ID over 50 characters", svc: strict, orgID: longOrg, wantErr: true},
+		{name: "relaxed accepts uuid urn", svc: relaxed, orgID: uuidOrg},
+		{name: "relaxed accepts longer org ID", svc: relaxed, orgID: longOrg},
+		{name: "relaxed still rejects other characters", svc: relaxed, orgID: "org/1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.svc.validateListServicesRequest(&v1.ListServicesRequest{OrganizationId: tt.orgID})
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, ReasonInvalidID, ReasonOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// service IDs keep the default format when only the organization format is relaxed
+	assert.False(t, relaxed.isValidID(uuidOrg))
+}
+
+func TestCatalogService_ListServices_VersionFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		wildcard bool
+		version  string
+		wantIDs  []string
+		wantErr  bool
+	}{
+		{name: "exact major release", version: "v2.0.0", wantIDs: []string{"svc-2", "svc-3"}},
+		{name: "exact stable release across services", version: "v1.0.0", wantIDs: []string{"svc-1", "svc-3", "svc-4"}},
+		{name: "no service runs the version", version: "v3.0.0", wantIDs: []string{}},
+		{name: "exact match does not prefix match", version: "v1", wantIDs: []string{}},
+		{name: "wildcard prefix", wildcard: true, version: "v1.*", wantIDs: []string{"svc-1", "svc-3", "svc-4"}},
+		{name: "star is literal without wildcard", version: "v1.*", wantIDs: []string{}},
+		{name: "too long", version: strings.Repeat("v", 51), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), WithSearchWildcard(tt.wildcard))
+			got, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{Version: tt.version, SortBy: "created_at"})
+
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, ReasonInvalidVersion, ReasonOf(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(got.Services))
+			for _, s := range got.Services {
+				ids = append(ids, s.Id)
+			}
+			sort.Strings(ids)
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestCatalogService_FieldLimits(t *testing.T) {
+	ctx := context.Background()
+	raised := newTestCatalogService(mockTestData(), WithFieldLimits(map[string]int{
+		"ListServices.search_query":     200,
+		"ListServices.organization_ids": 2,
+		"SearchVersions.search_query":   200,
+		"BatchGetServices.ids":          2,
+	}))
+
+	t.Run("raised limits accept longer queries", func(t *testing.T) {
+		_, err := raised.ListServices(ctx, &v1.ListServicesRequest{SearchQuery: strings.Repeat("a", 150)})
+		assert.NoError(t, err)
+		_, err = raised.SearchVersions(ctx, &v1.SearchVersionsRequest{SearchQuery: strings.Repeat("a", 150)})
+		assert.NoError(t, err)
+
+		_, err = raised.ListServices(ctx, &v1.ListServicesRequest{SearchQuery: strings.Repeat("a", 201)})
+		assert.Equal(t, ReasonInvalidSearchQuery, ReasonOf(err))
+		assert.Contains(t, status.Convert(err).Message(), "max 200 characters")
+	})
+
+	t.Run("limits count characters", func(t *testing.T) {
+		_, err := newTestCatalogService(mockTestData()).ListServices(ctx, &v1.ListServicesRequest{SearchQuery: strings.Repeat("é", MaxSearchQueryLength)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing limit is unlimited", func(t *testing.T) {
+		_, err := raised.ListServices(ctx, &v1.ListServicesRequest{Version: strings.Repeat("v", 500)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("repeated fields count distinct entries", func(t *testing.T) {
+		_, err := raised.ListServices(ctx, &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org-2", "org-1"}})
+		assert.NoError(t, err)
+		_, err = raised.ListServices(ctx, &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org-2", "org-3"}})
+		assert.Equal(t, ReasonTooManyIDs, ReasonOf(err))
+
+		resp, err := raised.BatchGetServices(ctx, &v1.BatchGetServicesRequest{Ids: []string{"svc-1", "svc-2", "svc-1,svc-2"}})
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetServices(), 2)
+		_, err = raised.BatchGetServices(ctx, &v1.BatchGetServicesRequest{Ids: []string{"svc-1,svc-2,svc-3"}})
+		assert.Equal(t, ReasonTooManyIDs, ReasonOf(err))
+		assert.Contains(t, status.Convert(err).Message(), "at most 2 service IDs")
+	})
+}
+
+func TestCatalogService_ListServices_OrganizationIDs(t *testing.T) {
+	tooMany := make([]string, MaxOrganizationFilters+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("org-%d", i)
+	}
+
+	tests := []struct {
+		name       string
+		req        *v1.ListServicesRequest
+		wantIDs    []string
+		wantReason Reason
+	}{
+		{name: "union of organizations", req: &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org-2"}}, wantIDs: []string{"svc-1", "svc-2", "svc-3"}},
+		{name: "single entry", req: &v1.ListServicesRequest{OrganizationIds: []string{"org-3"}}, wantIDs: []string{"svc-4"}},
+		{name: "unknown organization matches nothing", req: &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org-9"}}, wantIDs: []string{"svc-1", "svc-3"}},
+		{name: "combined with organization_id", req: &v1.ListServicesRequest{OrganizationId: "org-3", OrganizationIds: []string{"org-2"}}, wantIDs: []string{"svc-2", "svc-4"}},
+		{name: "invalid entry", req: &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org 2"}}, wantReason: ReasonInvalidID},
+		{name: "too many entries", req: &v1.ListServicesRequest{OrganizationIds: tooMany}, wantReason: ReasonTooManyIDs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData())
+			got, err := svc.ListServices(context.Background(), tt.req)
+
+			if tt.wantReason != "" {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, tt.wantReason, ReasonOf(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			ids := serviceIDs(got.GetServices())
+			sort.Strings(ids)
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, int32(len(tt.wantIDs)), got.GetTotalCount())
+		})
+	}
+}
+
+func TestCatalogService_ListServices_OrganizationIDs_DefaultOrganization(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithDefaultOrganization("org-1"))
+
+	got, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{OrganizationIds: []string{"org-2", "org-3"}})
+	assert.NoError(t, err)
+	ids := serviceIDs(got.GetServices())
+	sort.Strings(ids)
+	assert.Equal(t, []string{"svc-2", "svc-4"}, ids, "organization_ids replaces the default organization")
+}
+
+func TestCatalogService_AnonymousOrganizations(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithAnonymousOrganizations([]string{"org-1", "org-3"}))
+	anonymous := context.Background()
+	authenticated := context.WithValue(anonymous, "user", &auth.Claims{Organization: "org-2"})
+
+	t.Run("allowlisted organization readable anonymously", func(t *testing.T) {
+		got, err := svc.GetService(anonymous, &v1.GetServiceRequest{Id: "svc-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "svc-1", got.GetService().GetId())
+	})
+
+	t.Run("other organization not found anonymously", func(t *testing.T) {
+		_, err := svc.GetService(anonymous, &v1.GetServiceRequest{Id: "svc-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, ReasonServiceNotFound, ReasonOf(err))
+
+		_, err = svc.GetServiceVersions(anonymous, &v1.GetServiceVersionsRequest{ServiceId: "svc-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	// svc-2 belongs to org-2, which is not allowlisted
+	t.Run("BatchGetServices reports hidden services as missing", func(t *testing.T) {
+		got, err := svc.BatchGetServices(anonymous, &v1.BatchGetServicesRequest{Ids: []string{"svc-1", "svc-2"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1"}, serviceIDs(got.GetServices()))
+		assert.Equal(t, []string{"svc-2"}, got.GetMissingIds())
+	})
+
+	t.Run("StreamServiceVersions not found", func(t *testing.T) {
+		stream := &versionStream{ctx: anonymous}
+		err := svc.StreamServiceVersions(&v1.StreamServiceVersionsRequest{ServiceId: "svc-2"}, stream)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, stream.chunks)
+	})
+
+	t.Run("DiffServices not found", func(t *testing.T) {
+		_, err := svc.DiffServices(anonymous, &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetServiceHistory not found", func(t *testing.T) {
+		_, err := svc.GetServiceHistory(anonymous, &v1.GetServiceHistoryRequest{ServiceId: "svc-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("ListRecentVersions leaves other organizations out", func(t *testing.T) {
+		got, err := svc.ListRecentVersions(anonymous, &v1.ListRecentVersionsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(6), got.GetTotalCount())
+		for _, v := range got.GetVersions() {
+			assert.NotEqual(t, "svc-2", v.GetServiceId())
+		}
+	})
+
+	t.Run("SearchVersions leaves other organizations out", func(t *testing.T) {
+		got, err := svc.SearchVersions(anonymous, &v1.SearchVersionsRequest{SearchQuery: "v2.0"})
+		assert.NoError(t, err)
+		if assert.Len(t, got.GetResults(), 1) {
+			assert.Equal(t, "svc-3", got.GetResults()[0].GetServiceId())
+		}
+	})
+
+	t.Run("DescribeCatalog leaves other organizations out", func(t *testing.T) {
+		got, err := svc.DescribeCatalog(anonymous, &v1.DescribeCatalogRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), got.GetTotalServices())
+		var orgs []string
+		for _, count := range got.GetServicesPerOrganization() {
+			orgs = append(orgs, count.GetOrganizationId())
+		}
+		assert.Equal(t, []string{"org-1", "org-3"}, orgs)
+	})
+
+	t.Run("ListOrganizations leaves other organizations out", func(t *testing.T) {
+		got, err := svc.ListOrganizations(anonymous, &v1.ListOrganizationsRequest{})
+		assert.NoError(t, err)
+		var orgs []string
+		for _, org := range got.GetOrganizations() {
+			orgs = append(orgs, org.GetId())
+		}
+		assert.Equal(t, []string{"org-1", "org-3"}, orgs)
+	})
+
+	t.Run("ListServicesDelta leaves other organizations out", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAnonymousOrganizations([]string{"org-1", "org-3"}))
+		initial, err := svc.ListServicesDelta(anonymous, &v1.ListServicesDeltaRequest{})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"svc-1", "svc-3", "svc-4"}, serviceIDs(initial.GetServices()))
+
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-2", Name: "Payment Gateway", OrganizationID: "org-2"}))
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-1"}))
+		delta, err := svc.ListServicesDelta(anonymous, &v1.ListServicesDeltaRequest{SinceToken: initial.GetNextToken()})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-5"}, serviceIDs(delta.GetServices()))
+	})
+
+	t.Run("listings leave other organizations out", func(t *testing.T) {
+		got, err := svc.ListServices(anonymous, &v1.ListServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-4", "svc-3", "svc-1"}, serviceIDs(got.GetServices()))
+		assert.Equal(t, int32(3), got.GetTotalCount())
+
+		got, err = svc.ListServices(anonymous, &v1.ListServicesRequest{OrganizationId: "org-2"})
+		assert.NoError(t, err)
+		assert.Empty(t, got.GetServices())
+
+		count, err := svc.CountServices(anonymous, &v1.CountServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), count.GetCount())
+	})
+
+	t.Run("authenticated callers unaffected", func(t *testing.T) {
+		got, err := svc.GetService(authenticated, &v1.GetServiceRequest{Id: "svc-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, "svc-2", got.GetService().GetId())
+
+		list, err := svc.ListServices(authenticated, &v1.ListServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-2"}, serviceIDs(list.GetServices()))
+	})
+
+	t.Run("empty allowlist allows every organization", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAnonymousOrganizations(nil))
+		got, err := svc.GetService(anonymous, &v1.GetServiceRequest{Id: "svc-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, "svc-2", got.GetService().GetId())
+	})
+}
+
+func TestCatalogService_ListServices_IDsOnly(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *v1.ListServicesRequest
+		wantIDs   []string
+		wantTotal int32
+	}{
+		{name: "every service", req: &v1.ListServicesRequest{IdsOnly: true}, wantIDs: []string{"svc-4", "svc-3", "svc-2", "svc-1"}, wantTotal: 4},
+		{name: "filtered page", req: &v1.ListServicesRequest{IdsOnly: true, OrganizationId: "org-1", PageSize: 1}, wantIDs: []string{"svc-3"}, wantTotal: 2},
+		{name: "without total count", req: &v1.ListServicesRequest{IdsOnly: true, SkipTotalCount: true, PageSize: 2}, wantIDs: []string{"svc-4", "svc-3"}, wantTotal: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData())
+			got, err := svc.ListServices(context.Background(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, serviceIDs(got.GetServices()))
+			assert.Equal(t, tt.wantTotal, got.GetTotalCount())
+
+			// Entries carry only the ID and update time
+			for _, s := range got.GetServices() {
+				assert.NotNil(t, s.GetUpdatedAt())
+				assert.True(t, proto.Equal(&v1.Service{Id: s.GetId(), UpdatedAt: s.GetUpdatedAt()}, s), s.String())
+			}
+		})
+	}
+
+	t.Run("snapshot pages", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		svc.snapshots = newSnapshotStore(DefaultSnapshotTTL)
+		first, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{IdsOnly: true, Snapshot: true, PageSize: 2})
+		assert.NoError(t, err)
+		second, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{IdsOnly: true, PageSize: 2, PageToken: first.GetNextPageToken()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"svc-2", "svc-1"}, serviceIDs(second.GetServices()))
+		assert.Empty(t, second.GetServices()[0].GetName())
+		assert.Equal(t, int32(4), second.GetTotalCount())
+	})
+}
+
+func TestCatalogService_ListServices_SearchTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		req     *v1.ListServicesRequest
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name:    "all terms across name and description",
+			req:     &v1.ListServicesRequest{SearchQuery: "service management"},
+			wantIDs: []string{"svc-1"},
+		},
+		{
+			name:    "all terms in any order",
+			req:     &v1.ListServicesRequest{SearchQuery: "management user"},
+			wantIDs: []string{"svc-1"},
+		},
+		{
+			name:    "any term",
+			req:     &v1.ListServicesRequest{SearchQuery: "service management", SearchMatch: SearchMatchAny},
+			wantIDs: []string{"svc-1", "svc-2", "svc-3", "svc-4"},
+		},
+		{
+			name:    "all terms restricted to names",
+			req:     &v1.ListServicesRequest{SearchQuery: "service management", SearchFields: SearchFieldsName},
+			wantIDs: []string{},
+		},
+		{
+			name:    "version field excluded by default",
+			req:     &v1.ListServicesRequest{SearchQuery: "gateway v2.0"},
+			wantIDs: []string{},
+		},
+		{
+			name:    "all terms including versions",
+			req:     &v1.ListServicesRequest{SearchQuery: "gateway v2.0", SearchFields: SearchFieldsNameDescriptionVersion},
+			wantIDs: []string{"svc-2"},
+		},
+		{
+			name:    "configured defaults",
+			opts:    []Option{WithSearchFields(SearchFieldsName), WithSearchMatch(SearchMatchAny)},
+			req:     &v1.ListServicesRequest{SearchQuery: "payment inventory"},
+			wantIDs: []string{"svc-2", "svc-3"},
+		},
+		{
+			name:    "request overrides configured defaults",
+			opts:    []Option{WithSearchMatch(SearchMatchAny)},
+			req:     &v1.ListServicesRequest{SearchQuery: "payment inventory", SearchMatch: SearchMatchAll},
+			wantIDs: []string{},
+		},
+		{
+			name:    "invalid search_fields",
+			req:     &v1.ListServicesRequest{SearchQuery: "user", SearchFields: "url"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid search_match",
+			req:     &v1.ListServicesRequest{SearchQuery: "user", SearchMatch: "some"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), tt.opts...)
+			got, err := svc.ListServices(context.Background(), tt.req)
+
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, ReasonInvalidSearchQuery, ReasonOf(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(got.Services))
+			for _, s := range got.Services {
+				ids = append(ids, s.Id)
+			}
+			sort.Strings(ids)
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestCatalogService_ListServices_SkipTotalCount(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+
+	// walk collects the IDs of every page, following next_page_token until it is empty
+	walk := func(t *testing.T, req *v1.ListServicesRequest) ([]string, []int32) {
+		var ids []string
+		var counts []int32
+		for pages := 0; pages < 10; pages++ {
+			resp, err := svc.ListServices(ctx, req)
+			if !assert.NoError(t, err) {
+				return nil, nil
+			}
+			for _, s := range resp.Services {
+				ids = append(ids, s.Id)
+			}
+			counts = append(counts, resp.TotalCount)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		return ids, counts
+	}
+
+	tests := []struct {
+		name  string
+		req   *v1.ListServicesRequest
+		pages int
+	}{
+		{name: "one per page", req: &v1.ListServicesRequest{PageSize: 1, SortBy: "name"}, pages: 4},
+		{name: "page size divides results", req: &v1.ListServicesRequest{PageSize: 2, SortBy: "created_at", SortOrder: "desc"}, pages: 2},
+		{name: "partial last page", req: &v1.ListServicesRequest{PageSize: 3, SortBy: "name", SortOrder: "desc"}, pages: 2},
+		{name: "filtered", req: &v1.ListServicesRequest{PageSize: 1, OrganizationId: "org-1"}, pages: 2},
+		{name: "no matches", req: &v1.ListServicesRequest{PageSize: 1, SearchQuery: "nothing matches"}, pages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counted := proto.Clone(tt.req).(*v1.ListServicesRequest)
+			wantIDs, _ := walk(t, counted)
+
+			skipped := proto.Clone(tt.req).(*v1.ListServicesRequest)
+			skipped.SkipTotalCount = true
+			gotIDs, counts := walk(t, skipped)
+
+			assert.Equal(t, wantIDs, gotIDs)
+			assert.Len(t, counts, tt.pages)
+			for _, count := range counts {
+				assert.Equal(t, int32(-1), count)
+			}
+		})
+	}
+
+	t.Run("out of range token", func(t *testing.T) {
+		_, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageToken: "page_4", SkipTotalCount: true})
+		assert.Equal(t, ReasonPageTokenOutOfRange, ReasonOf(err))
+	})
+
+	t.Run("ignored for snapshots", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		svc.snapshots = newSnapshotStore(DefaultSnapshotTTL)
+		resp, err := svc.ListServices(ctx, &v1.ListServicesRequest{PageSize: 1, Snapshot: true, SkipTotalCount: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), resp.TotalCount)
+	})
+}
+
+func TestCatalogService_firstServices(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+
+	for _, sortBy := range []string{"name", "created_at", "updated_at", "version_count"} {
+		for _, sortOrder := range []string{"asc", "desc"} {
+			want := svc.getAllServices()
+			assert.NoError(t, svc.sortServices(ctx, want, sortBy, sortOrder))
+
+			for n := 1; n <= len(want)+1; n++ {
+				got, err := svc.firstServices(ctx, svc.getAllServices(), n, sortBy, sortOrder)
+				assert.NoError(t, err)
+				assert.Equal(t, want[:min(n, len(want))], got, "%s %s first %d", sortBy, sortOrder, n)
+			}
+		}
+	}
+}
+
+func TestCatalogService_Unavailable_BeforeLoad(t *testing.T) {
+	svc := &CatalogService{}
+
+	_, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, ReasonCatalogLoading, ReasonOf(err))
+
+	st, _ := status.FromError(err)
+	var retry *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if r, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, DefaultRetryDelay, retry.GetRetryDelay().AsDuration())
+	}
+
+	// The hint is configurable
+	configured := &CatalogService{}
+	WithRetryDelay(5 * time.Second)(configured)
+	_, err = configured.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	st, _ = status.FromError(err)
+	for _, detail := range st.Details() {
+		if r, ok := detail.(*errdetails.RetryInfo); ok {
+			assert.Equal(t, 5*time.Second, r.GetRetryDelay().AsDuration())
+		}
+	}
+
+	svc.ReplaceServices(servicesOf(mockTestData()))
+	got, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), got.TotalCount)
+}
+
+func TestCatalogService_ReplaceServices_KeepsWrites(t *testing.T) {
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "admin@org1.com", Organization: "org-1", Role: "admin"})
+	fileServices := func() []*model.Service {
+		services := make([]*model.Service, 0)
+		for _, s := range mockTestData() {
+			services = append(services, s)
+		}
+		return services
+	}
+
+	svc := newTestCatalogService(mockTestData())
+	_, err := svc.TouchService(adminCtx, &v1.TouchServiceRequest{Id: "svc-1"})
+	require.NoError(t, err)
+	touched := svc.catalog()["svc-1"]
+	_, err = svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: []*v1.Service{{Id: "svc-10", Name: "Search Service"}}})
+	require.NoError(t, err)
+
+	// Reloading a file without the writes keeps them
+	require.NoError(t, svc.ReplaceServices(fileServices()))
+	assert.Same(t, touched, svc.catalog()["svc-1"])
+	assert.Contains(t, svc.catalog(), "svc-10")
+
+	// A file version updated at least as recently replaces the write, also on later reloads
+	updated := fileServices()
+	for _, s := range updated {
+		if s.ID == "svc-1" {
+			s.Name = "Edited User Service"
+			s.UpdatedAt.Time = touched.UpdatedAt.Add(time.Second)
+		}
+	}
+	require.NoError(t, svc.ReplaceServices(updated))
+	assert.Equal(t, "Edited User Service", svc.catalog()["svc-1"].Name)
+
+	require.NoError(t, svc.ReplaceServices(fileServices()))
+	assert.Equal(t, "User Service", svc.catalog()["svc-1"].Name)
+	assert.Contains(t, svc.catalog(), "svc-10", "writes the file has not caught up with are still kept")
+}
+
+func TestCatalogService_ReplaceServices_ConcurrentReads(t *testing.T) {
+	generation := func(tag string, count int) []*model.Service {
+		services := make([]*model.Service, 0, count)
+		for i := 0; i < count; i++ {
+			services = append(services, &model.Service{
+				ID:             fmt.Sprintf("%s-%d", tag, i),
+				Name:           fmt.Sprintf("%s service %d", tag, i),
+				Description:    tag,
+				OrganizationID: "org-1",
+			})
+		}
+		return services
+	}
+	genA, genB := generation("a", 40), generation("b", 70)
+	wantCount := map[string]int32{"a": 40, "b": 70}
+
+	svc := newTestCatalogService(map[string]*model.Service{})
+	svc.ReplaceServices(genA)
+
+	done := make(chan struct{})
+	var reloads sync.WaitGroup
+	reloads.Add(1)
+	go func() {
+		defer reloads.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				svc.ReplaceServices(genB)
+			} else {
+				svc.ReplaceServices(genA)
+			}
+		}
+	}()
+
+	var readers sync.WaitGroup
+	errs := make(chan string, 8)
+	for r := 0; r < 8; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := 0; i < 200; i++ {
+				resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: MaxPageSize})
+				if err != nil {
+					errs <- err.Error()
+					return
+				}
+
+				// every service in one response must come from the same generation, in full
+				tag := resp.Services[0].Description
+				for _, s := range resp.Services {
+					if s.Description != tag {
+						errs <- fmt.Sprintf("mixed generations %q and %q in one response", tag, s.Description)
+						return
+					}
+				}
+				if resp.TotalCount != wantCount[tag] {
+					errs <- fmt.Sprintf("generation %q returned %d services, want %d", tag, resp.TotalCount, wantCount[tag])
+					return
+				}
+			}
+		}()
+	}
+
+	readers.Wait()
+	close(done)
+	reloads.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Error(msg)
+	}
+}
+
+func TestCatalogService_PutService_ConcurrentListAndCreate(t *testing.T) {
+	const writers, perWriter = 4, 50
+
+	svc := newTestCatalogService(mockTestData())
+
+	var wg sync.WaitGroup
+	errs := make(chan string, writers+8)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				svc.PutService(&model.Service{
+					ID:             fmt.Sprintf("new-%d-%d", w, i),
+					Name:           fmt.Sprintf("New Service %d-%d", w, i),
+					OrganizationID: "org-1",
+				})
+			}
+		}(w)
+	}
+	for r := 0; r < 8; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last int32
+			for i := 0; i < 100; i++ {
+				resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: MaxPageSize})
+				if err != nil {
+					errs <- err.Error()
+					return
+				}
+				// services are only ever added, so a later read can never see fewer
+				if resp.TotalCount < last {
+					errs <- fmt.Sprintf("total count went from %d to %d", last, resp.TotalCount)
+					return
+				}
+				last = resp.TotalCount
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Error(msg)
+	}
+
+	resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(len(mockTestData())+writers*perWriter), resp.TotalCount)
+
+	got, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "new-3-49"})
+	assert.NoError(t, err)
+	assert.Equal(t, "New Service 3-49", got.Service.Name)
+}
+
+func TestCatalogService_ListServices_SortByVersionCount(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	tests := []struct {
+		name      string
+		sortOrder string
+		wantIDs   []string
+	}{
+		// svc-1, svc-3 and svc-4 have two versions and are ordered by name, svc-2 has one
+		{name: "descending", sortOrder: "desc", wantIDs: []string{"svc-4", "svc-3", "svc-1", "svc-2"}},
+		{name: "ascending", sortOrder: "asc", wantIDs: []string{"svc-2", "svc-4", "svc-3", "svc-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{SortBy: "version_count", SortOrder: tt.sortOrder})
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(resp.Services))
+			for _, s := range resp.Services {
+				ids = append(ids, s.Id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestCatalogService_ListServices_EqualSortKeysPaginateDeterministically(t *testing.T) {
+	// Every service has the same name and timestamps, so only the base order decides the page boundaries
+	now := time.Now()
+	data := make(map[string]*model.Service)
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("svc-%02d", i)
+		data[id] = &model.Service{ID: id, Name: "Same Name", CreatedAt: model.Timestamp{Time: now}, UpdatedAt: model.Timestamp{Time: now}}
+	}
+	svc := newTestCatalogService(data)
+
+	listAll := func(sortBy string) []string {
+		var ids []string
+		token := ""
+		for {
+			resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{SortBy: sortBy, PageSize: 3, PageToken: token})
+			if !assert.NoError(t, err) {
+				return ids
+			}
+			for _, s := range resp.Services {
+				ids = append(ids, s.Id)
+			}
+			if resp.NextPageToken == "" {
+				return ids
+			}
+			token = resp.NextPageToken
+		}
+	}
+
+	for _, sortBy := range []string{"name", "created_at", "updated_at"} {
+		t.Run(sortBy, func(t *testing.T) {
+			first := listAll(sortBy)
+			assert.Len(t, first, len(data))
+			assert.True(t, sort.StringsAreSorted(first), "equal sort keys keep ID order: %v", first)
+			for i := 0; i < 10; i++ {
+				assert.Equal(t, first, listAll(sortBy))
+			}
+		})
+	}
+}
+
+func TestCatalogService_SortServices_TotalOrder(t *testing.T) {
+	// Two groups of services equal on every sort field, so only the ID tie-breaker separates them
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+	var services []*model.Service
+	for i := 0; i < 50; i++ {
+		s := &model.Service{ID: fmt.Sprintf("svc-%02d", i), Name: "Alpha", CreatedAt: model.Timestamp{Time: early}, UpdatedAt: model.Timestamp{Time: early}}
+		if i%2 == 1 {
+			s.Name, s.CreatedAt.Time, s.UpdatedAt.Time = "Beta", late, late
+			s.Versions = []*model.ServiceVersion{{ID: "v1"}}
+		}
+		services = append(services, s)
+	}
+	svc := newTestCatalogService(map[string]*model.Service{})
+
+	for _, sortBy := range []string{"name", "created_at", "updated_at", "version_count"} {
+		for _, sortOrder := range []string{"asc", "desc"} {
+			t.Run(sortBy+" "+sortOrder, func(t *testing.T) {
+				var first []string
+				for round := 0; round < 20; round++ {
+					shuffled := append([]*model.Service(nil), services...)
+					rand.New(rand.NewSource(int64(round))).Shuffle(len(shuffled), func(i, j int) {
+						shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+					})
+					assert.NoError(t, svc.sortServices(context.Background(), shuffled, sortBy, sortOrder))
+
+					ids := make([]string, 0, len(shuffled))
+					for _, s := range shuffled {
+						ids = append(ids, s.ID)
+					}
+					if first == nil {
+						first = ids
+						continue
+					}
+					assert.Equal(t, first, ids, "round %d", round)
+				}
+
+				// Each group keeps ascending IDs whatever the direction of the sort field
+				firstGroup, secondGroup := first[:25], first[25:]
+				assert.True(t, sort.StringsAreSorted(firstGroup), "%v", firstGroup)
+				assert.True(t, sort.StringsAreSorted(secondGroup), "%v", secondGroup)
+				assert.Equal(t, sortOrder == "desc", firstGroup[0] == "svc-01")
+			})
+		}
+	}
+}
+
+func TestCatalogService_ActivateVersionAcrossServices(t *testing.T) {
+	activeVersions := func(svc *CatalogService, id string) []string {
+		var active []string
+		for _, v := range svc.catalog()[id].Versions {
+			if v.IsActive {
+				active = append(active, v.Version)
+			}
+		}
+		return active
+	}
+
+	t.Run("only services with the version are affected", func(t *testing.T) {
+		data := mockTestData()
+		svc2 := data["svc-2"]
+		svc1Versions := data["svc-1"].Versions
+		svc := newTestCatalogService(data, WithAnonymousWrites(true))
+
+		resp, err := svc.ActivateVersionAcrossServices(context.Background(), &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1", "svc-3", "svc-4"}, resp.ServiceIds)
+
+		assert.Equal(t, []string{"v1.0.0"}, activeVersions(svc, "svc-1"))
+		assert.Equal(t, []string{"v1.0.0"}, activeVersions(svc, "svc-3"))
+		assert.Equal(t, []string{"v1.0.0"}, activeVersions(svc, "svc-4"))
+		assert.Same(t, svc2, svc.catalog()["svc-2"], "service without the version is skipped")
+		assert.True(t, svc.catalog()["svc-1"].UpdatedAt.After(svc1Versions[0].UpdatedAt.Time))
+
+		// The previously published services are copied, not modified
+		assert.False(t, svc1Versions[0].IsActive)
+		assert.True(t, svc1Versions[1].IsActive)
+	})
+
+	t.Run("unknown version affects nothing", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAnonymousWrites(true))
+		resp, err := svc.ActivateVersionAcrossServices(context.Background(), &v1.ActivateVersionAcrossServicesRequest{Version: "v9.9.9"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.ServiceIds)
+		assert.Equal(t, []string{"v1.1.0"}, activeVersions(svc, "svc-1"))
+	})
+
+	t.Run("admin affects only their organization", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+		resp, err := svc.ActivateVersionAcrossServices(ctx, &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1", "svc-3"}, resp.ServiceIds)
+		assert.Equal(t, []string{"v1.0.0"}, activeVersions(svc, "svc-4"))
+	})
+
+	t.Run("non-admin rejected", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+		_, err := svc.ActivateVersionAcrossServices(ctx, &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, ReasonAdminRequired, ReasonOf(err))
+		assert.Equal(t, []string{"v1.1.0"}, activeVersions(svc, "svc-1"))
+	})
+
+	t.Run("anonymous caller rejected unless anonymous writes are allowed", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.ActivateVersionAcrossServices(context.Background(), &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, ReasonAdminRequired, ReasonOf(err))
+		assert.Equal(t, []string{"v1.1.0"}, activeVersions(svc, "svc-1"))
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAnonymousWrites(true))
+		_, err := svc.ActivateVersionAcrossServices(context.Background(), &v1.ActivateVersionAcrossServicesRequest{})
+		assert.Equal(t, ReasonInvalidVersion, ReasonOf(err))
+	})
+}
+
+func TestCatalogService_TouchService(t *testing.T) {
+	t.Run("only UpdatedAt changes", func(t *testing.T) {
+		data := mockTestData()
+		before := *data["svc-1"]
+		svc := newTestCatalogService(data, WithAuditLogSize(10))
+
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "reviewer@org1.com", Organization: "org-1", Role: "admin"})
+		resp, err := svc.TouchService(ctx, &v1.TouchServiceRequest{Id: "svc-1"})
+		assert.NoError(t, err)
+
+		touched := svc.catalog()["svc-1"]
+		assert.True(t, touched.UpdatedAt.After(before.UpdatedAt.Time))
+		assert.Equal(t, touched.UpdatedAt.Time, resp.Service.UpdatedAt.AsTime())
+		assert.Equal(t, before.CreatedAt, touched.CreatedAt)
+		assert.Equal(t, before.Versions, touched.Versions, "versions are shared, not copied or changed")
+
+		after := *touched
+		after.UpdatedAt = before.UpdatedAt
+		assert.Equal(t, before, after)
+
+		// The previously published service is copied, not modified
+		assert.Equal(t, before.UpdatedAt, data["svc-1"].UpdatedAt)
+
+		events, err := svc.ListAuditEvents(ctx, &v1.ListAuditEventsRequest{Action: AuditActionTouchService})
+		assert.NoError(t, err)
+		if assert.Len(t, events.Events, 1) {
+			assert.Equal(t, "reviewer@org1.com", events.Events[0].Actor)
+			assert.Equal(t, "svc-1", events.Events[0].ServiceId)
+		}
+	})
+
+	t.Run("other organization not found", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		before := svc.catalog()["svc-4"]
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+		_, err := svc.TouchService(ctx, &v1.TouchServiceRequest{Id: "svc-4"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Same(t, before, svc.catalog()["svc-4"])
+	})
+
+	t.Run("non-admin rejected", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		before := svc.catalog()["svc-1"]
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+		_, err := svc.TouchService(ctx, &v1.TouchServiceRequest{Id: "svc-1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, ReasonAdminRequired, ReasonOf(err))
+		assert.Same(t, before, svc.catalog()["svc-1"])
+	})
+
+	t.Run("unknown and missing ID", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAnonymousWrites(true))
+		_, err := svc.TouchService(context.Background(), &v1.TouchServiceRequest{Id: "svc-99"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = svc.TouchService(context.Background(), &v1.TouchServiceRequest{})
+		assert.Equal(t, ReasonMissingID, ReasonOf(err))
+	})
+}
+
+func TestCatalogService_CreateServices(t *testing.T) {
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "admin@org1.com", Organization: "org-1", Role: "admin"})
+	batch := func() []*v1.Service {
+		return []*v1.Service{
+			{Id: "svc-10", Name: "  Search Service ", Versions: []*v1.ServiceVersion{{Version: "v1.0.0", IsActive: true}}},
+			{Id: "svc-1", Name: "Duplicate of existing", OrganizationId: "org-1"},
+			{Name: "", OrganizationId: "org-1"},
+			{Id: "svc-11", Name: "Other org", OrganizationId: "org-2"},
+			{Id: "svc-10", Name: "Duplicate in batch"},
+			{Id: "svc-12", Name: "Bad version", Versions: []*v1.ServiceVersion{{Id: "v1"}}},
+			{Name: "Generated ID"},
+		}
+	}
+
+	t.Run("mixed batch reports each result", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAuditLogSize(10))
+		before := svc.catalog()["svc-1"]
+
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: batch()})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), resp.CreatedCount)
+		assert.Equal(t, int32(5), resp.FailedCount)
+		if !assert.Len(t, resp.Results, 7) {
+			return
+		}
+
+		for i, r := range resp.Results {
+			assert.Equal(t, int32(i), r.Index)
+		}
+		assert.Equal(t, "svc-10", resp.Results[0].Id)
+		assert.Empty(t, resp.Results[0].ErrorCode)
+		assert.Equal(t, []string{"", "ALREADY_EXISTS", "INVALID_ARGUMENT", "PERMISSION_DENIED", "ALREADY_EXISTS", "INVALID_ARGUMENT", ""},
+			[]string{resp.Results[0].ErrorCode, resp.Results[1].ErrorCode, resp.Results[2].ErrorCode, resp.Results[3].ErrorCode,
+				resp.Results[4].ErrorCode, resp.Results[5].ErrorCode, resp.Results[6].ErrorCode})
+		assert.Equal(t, string(ReasonServiceExists), resp.Results[1].ErrorReason)
+		assert.Equal(t, string(ReasonMissingName), resp.Results[2].ErrorReason)
+		assert.Equal(t, string(ReasonOrganizationDenied), resp.Results[3].ErrorReason)
+		assert.Equal(t, string(ReasonServiceExists), resp.Results[4].ErrorReason)
+		assert.Equal(t, string(ReasonInvalidField), resp.Results[5].ErrorReason)
+		assert.Contains(t, resp.Results[5].ErrorMessage, "Versions[0].Version")
+		assert.NotEmpty(t, resp.Results[6].Id)
+
+		created := svc.catalog()["svc-10"]
+		if assert.NotNil(t, created) {
+			assert.Equal(t, "Search Service", created.Name, "names are normalized")
+			assert.Equal(t, "org-1", created.OrganizationID, "organization defaults to the caller's")
+			assert.False(t, created.CreatedAt.IsZero())
+			if assert.Len(t, created.Versions, 1) {
+				assert.NotEmpty(t, created.Versions[0].ID)
+				assert.Equal(t, "svc-10", created.Versions[0].ServiceID)
+			}
+		}
+		assert.NotNil(t, svc.catalog()[resp.Results[6].Id])
+		assert.Nil(t, svc.catalog()["svc-11"])
+		assert.Same(t, before, svc.catalog()["svc-1"], "existing services are not replaced")
+		assert.Len(t, svc.catalog(), len(mockTestData())+2)
+
+		events, err := svc.ListAuditEvents(adminCtx, &v1.ListAuditEventsRequest{Action: AuditActionCreateService})
+		assert.NoError(t, err)
+		assert.Len(t, events.Events, 2)
+	})
+
+	t.Run("transactional batch rolls back on any failure", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAuditLogSize(10))
+		before := svc.catalog()
+
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: batch(), Transactional: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), resp.CreatedCount)
+		assert.Equal(t, int32(7), resp.FailedCount)
+		for _, r := range resp.Results {
+			assert.Empty(t, r.Id)
+			assert.NotEmpty(t, r.ErrorCode)
+		}
+		assert.Equal(t, "ABORTED", resp.Results[0].ErrorCode)
+		assert.Equal(t, string(ReasonBatchAborted), resp.Results[0].ErrorReason)
+		assert.Equal(t, "ALREADY_EXISTS", resp.Results[1].ErrorCode)
+		assert.Equal(t, "ABORTED", resp.Results[6].ErrorCode)
+
+		assert.Equal(t, before, svc.catalog(), "the catalog is unchanged")
+		events, err := svc.ListAuditEvents(adminCtx, &v1.ListAuditEventsRequest{Action: AuditActionCreateService})
+		assert.NoError(t, err)
+		assert.Empty(t, events.Events)
+	})
+
+	t.Run("transactional batch without failures", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{
+			Services:      []*v1.Service{{Id: "svc-10", Name: "Search"}, {Id: "svc-11", Name: "Billing"}},
+			Transactional: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), resp.CreatedCount)
+		assert.NotNil(t, svc.catalog()["svc-10"])
+		assert.NotNil(t, svc.catalog()["svc-11"])
+	})
+
+	t.Run("store limit", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		svc.maxServices = len(mockTestData()) + 1
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{
+			Services: []*v1.Service{{Id: "svc-10", Name: "Search"}, {Id: "svc-11", Name: "Billing"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "svc-10", resp.Results[0].Id)
+		assert.Equal(t, "RESOURCE_EXHAUSTED", resp.Results[1].ErrorCode)
+	})
+
+	t.Run("future timestamps follow the policy", func(t *testing.T) {
+		future := timestamppb.New(time.Now().Add(time.Hour))
+		batch := func() []*v1.Service {
+			return []*v1.Service{
+				{Id: "svc-10", Name: "Search", CreatedAt: future},
+				{Id: "svc-11", Name: "Billing", Versions: []*v1.ServiceVersion{{Version: "v1.0.0", UpdatedAt: future}}},
+				{Id: "svc-12", Name: "Payments", CreatedAt: timestamppb.New(time.Now().Add(time.Minute))},
+			}
+		}
+
+		svc := newTestCatalogService(mockTestData(), WithFutureTimestamps(model.FutureTimestampsReject, 5*time.Minute))
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: batch()})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), resp.CreatedCount)
+		assert.Equal(t, string(ReasonInvalidTimestamp), resp.Results[0].ErrorReason)
+		assert.Contains(t, resp.Results[0].ErrorMessage, `service "svc-10"`)
+		assert.Equal(t, string(ReasonInvalidTimestamp), resp.Results[1].ErrorReason)
+		assert.Equal(t, "svc-12", resp.Results[2].Id, "timestamps within the skew are accepted")
+		assert.Nil(t, svc.catalog()["svc-10"])
+
+		svc = newTestCatalogService(mockTestData(), WithFutureTimestamps(model.FutureTimestampsWarn, 5*time.Minute))
+		resp, err = svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: batch()})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), resp.CreatedCount)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		services := make([]*v1.Service, MaxCreateBatchSize+1)
+		for i := range services {
+			services[i] = &v1.Service{Name: fmt.Sprintf("Service %d", i)}
+		}
+		_, err = svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: services})
+		assert.Equal(t, ReasonTooManyServices, ReasonOf(err))
+
+		userCtx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+		_, err = svc.CreateServices(userCtx, &v1.CreateServicesRequest{Services: []*v1.Service{{Name: "Search"}}})
+		assert.Equal(t, ReasonAdminRequired, ReasonOf(err))
+		assert.Len(t, svc.catalog(), len(mockTestData()))
+	})
+}
+
+func TestCatalogService_CreateServices_IdempotencyKey(t *testing.T) {
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "admin@org1.com", Organization: "org-1", Role: "admin"})
+	create := func(svc *CatalogService, ctx context.Context, key, name string) (*v1.CreateServicesResponse, error) {
+		return svc.CreateServices(ctx, &v1.CreateServicesRequest{
+			IdempotencyKey: key,
+			Services:       []*v1.Service{{Name: name, Versions: []*v1.ServiceVersion{{Version: "v1.0.0"}}}},
+		})
+	}
+
+	t.Run("same key creates one service", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+
+		first, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		second, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+
+		assert.True(t, proto.Equal(first, second), "a retry must get the first response")
+		assert.Len(t, svc.catalog(), len(mockTestData())+1)
+	})
+
+	t.Run("different key creates a second service", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+
+		first, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		second, err := create(svc, adminCtx, "key-2", "Search Service")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.Results[0].Id, second.Results[0].Id)
+		assert.Len(t, svc.catalog(), len(mockTestData())+2)
+	})
+
+	t.Run("key reused for a different request", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+
+		_, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		_, err = create(svc, adminCtx, "key-1", "Other Service")
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, ReasonIdempotencyReused, ReasonOf(err))
+		assert.Len(t, svc.catalog(), len(mockTestData())+1)
+	})
+
+	t.Run("keys are scoped to the caller", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+		otherCtx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "other@org1.com", Organization: "org-1", Role: "admin"})
+
+		_, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		_, err = create(svc, otherCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		assert.Len(t, svc.catalog(), len(mockTestData())+2)
+	})
+
+	t.Run("expired key creates again", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+		now := time.Now()
+		svc.idempotency.now = func() time.Time { return now }
+
+		_, err := create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+		_, err = create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		assert.Len(t, svc.catalog(), len(mockTestData())+2)
+	})
+
+	t.Run("oldest key evicted when full", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute), WithIdempotencyMaxKeys(2))
+
+		for _, key := range []string{"key-1", "key-2", "key-3"} {
+			_, err := create(svc, adminCtx, key, "Search Service")
+			require.NoError(t, err)
+		}
+		assert.Len(t, svc.idempotency.entries, 2)
+		assert.Len(t, svc.catalog(), len(mockTestData())+3)
+
+		// key-3 is still replayed, the evicted key-1 creates again
+		_, err := create(svc, adminCtx, "key-3", "Search Service")
+		require.NoError(t, err)
+		assert.Len(t, svc.catalog(), len(mockTestData())+3)
+		_, err = create(svc, adminCtx, "key-1", "Search Service")
+		require.NoError(t, err)
+		assert.Len(t, svc.catalog(), len(mockTestData())+4)
+		assert.Len(t, svc.idempotency.entries, 2)
+	})
+
+	t.Run("without a key every request creates", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+
+		_, err := create(svc, adminCtx, "", "Search Service")
+		require.NoError(t, err)
+		_, err = create(svc, adminCtx, "", "Search Service")
+		require.NoError(t, err)
+		assert.Len(t, svc.catalog(), len(mockTestData())+2)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithIdempotencyTTL(time.Minute))
+
+		_, err := create(svc, adminCtx, strings.Repeat("k", maxIdempotencyKeyLength+1), "Search Service")
+		assert.Equal(t, ReasonInvalidField, ReasonOf(err))
+	})
+}
+
+func TestCatalogService_CrossOrgPolicy(t *testing.T) {
+	// svc-4 belongs to org-3
+	crossOrg := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+	sameOrg := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-3", Role: "user"})
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantCode   codes.Code
+		wantReason Reason
+	}{
+		{"default hides", nil, codes.NotFound, ReasonServiceNotFound},
+		{"hide", []Option{WithCrossOrgPolicy(CrossOrgHide)}, codes.NotFound, ReasonServiceNotFound},
+		{"deny", []Option{WithCrossOrgPolicy(CrossOrgDeny)}, codes.PermissionDenied, ReasonOrganizationDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCatalogService(mockTestData(), tt.opts...)
+
+			_, err := svc.GetService(crossOrg, &v1.GetServiceRequest{Id: "svc-4"})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReason, ReasonOf(err))
+
+			_, err = svc.GetServiceVersions(crossOrg, &v1.GetServiceVersionsRequest{ServiceId: "svc-4"})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReason, ReasonOf(err))
+
+			_, err = svc.GetServiceHistory(crossOrg, &v1.GetServiceHistoryRequest{ServiceId: "svc-4"})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReason, ReasonOf(err))
+
+			// batches report hidden services as missing and denied ones as item errors
+			batch, err := svc.BatchGetServices(crossOrg, &v1.BatchGetServicesRequest{Ids: []string{"svc-1", "svc-4"}})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"svc-1"}, serviceIDs(batch.GetServices()))
+			if tt.wantCode == codes.NotFound {
+				assert.Equal(t, []string{"svc-4"}, batch.GetMissingIds())
+				assert.Empty(t, batch.GetErrors())
+			} else {
+				assert.Empty(t, batch.GetMissingIds())
+				if assert.Len(t, batch.GetErrors(), 1) {
+					assert.Equal(t, "svc-4", batch.GetErrors()[0].GetId())
+					assert.Equal(t, "PERMISSION_DENIED", batch.GetErrors()[0].GetErrorCode())
+					assert.Equal(t, string(tt.wantReason), batch.GetErrors()[0].GetErrorReason())
+				}
+			}
+
+			// the caller's own services and unauthenticated callers are unaffected
+			resp, err := svc.GetService(sameOrg, &v1.GetServiceRequest{Id: "svc-4"})
+			assert.NoError(t, err)
+			assert.Equal(t, "svc-4", resp.Service.Id)
+			_, err = svc.GetServiceVersions(context.Background(), &v1.GetServiceVersionsRequest{ServiceId: "svc-4"})
+			assert.NoError(t, err)
+
+			// a missing service is always not found
+			_, err = svc.GetService(crossOrg, &v1.GetServiceRequest{Id: "svc-99"})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	}
+
+	t.Run("hidden errors read like a missing service", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, hidden := svc.GetService(crossOrg, &v1.GetServiceRequest{Id: "svc-4"})
+		_, missing := svc.GetService(crossOrg, &v1.GetServiceRequest{Id: "svc-99"})
+		assert.Equal(t, "service not found: service with ID 'svc-4' not found", hidden.Error())
+		assert.Equal(t, strings.ReplaceAll(missing.Error(), "svc-99", "svc-4"), hidden.Error())
+	})
+}
+
+func TestCatalogService_DiffServices(t *testing.T) {
+	fields := func(differences []*v1.FieldDiff) []string {
+		var names []string
+		for _, d := range differences {
+			names = append(names, d.Field)
+		}
+		return names
+	}
+
+	t.Run("two services", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		resp, err := svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"name", "description", "organization_id", "url", "created_at", "updated_at", "versions"}, fields(resp.Differences))
+		assert.Equal(t, "User Service", resp.Differences[0].Base)
+		assert.Equal(t, "Payment Gateway", resp.Differences[0].Target)
+		assert.Equal(t, "2024-05-01T10:00:00Z", resp.Differences[4].Base)
+		assert.Equal(t, "v1.0.0, v1.1.0", resp.Differences[6].Base)
+		assert.Equal(t, "v2.0.0", resp.Differences[6].Target)
+	})
+
+	t.Run("service with itself", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		resp, err := svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-1"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Differences)
+	})
+
+	t.Run("two versions", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		resp, err := svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", BaseVersionId: "v1", TargetVersionId: "v2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"version", "description", "is_active", "created_at", "updated_at"}, fields(resp.Differences))
+		assert.Equal(t, "false", resp.Differences[2].Base)
+		assert.Equal(t, "true", resp.Differences[2].Target)
+
+		resp, err = svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", BaseVersionId: "v2", TargetVersionId: "v2"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Differences)
+	})
+
+	t.Run("missing IDs not found", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-99"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, ReasonServiceNotFound, ReasonOf(err))
+
+		_, err = svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-99", OtherServiceId: "svc-1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-1", BaseVersionId: "v1", TargetVersionId: "v9"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, ReasonVersionNotFound, ReasonOf(err))
+	})
+
+	t.Run("other organization's service", func(t *testing.T) {
+		// svc-1 belongs to org-1 and svc-2 to org-2
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.DiffServices(ctx, &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, ReasonServiceNotFound, ReasonOf(err))
+
+		_, err = svc.DiffServices(ctx, &v1.DiffServicesRequest{ServiceId: "svc-2", BaseVersionId: "v1", TargetVersionId: "v1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		svc = newTestCatalogService(mockTestData(), WithCrossOrgPolicy(CrossOrgDeny))
+		_, err = svc.DiffServices(ctx, &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-2"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		tests := []struct {
+			name       string
+			req        *v1.DiffServicesRequest
+			wantReason Reason
+		}{
+			{name: "missing service_id", req: &v1.DiffServicesRequest{OtherServiceId: "svc-2"}, wantReason: ReasonMissingID},
+			{name: "nothing to compare with", req: &v1.DiffServicesRequest{ServiceId: "svc-1"}, wantReason: ReasonMissingID},
+			{name: "one version", req: &v1.DiffServicesRequest{ServiceId: "svc-1", BaseVersionId: "v1"}, wantReason: ReasonMissingID},
+			{name: "service and versions", req: &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc-2", BaseVersionId: "v1", TargetVersionId: "v2"}, wantReason: ReasonInvalidField},
+			{name: "invalid other_service_id", req: &v1.DiffServicesRequest{ServiceId: "svc-1", OtherServiceId: "svc 2"}, wantReason: ReasonInvalidID},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.DiffServices(context.Background(), tt.req)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, tt.wantReason, ReasonOf(err))
+			})
+		}
+	})
+}
+
+func TestCatalogService_GetServiceHistory(t *testing.T) {
+	t.Run("chronological with release interval", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		resp, err := svc.GetServiceHistory(context.Background(), &v1.GetServiceHistoryRequest{ServiceId: "svc-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "svc-1", resp.ServiceId)
+		if assert.Len(t, resp.Entries, 2) {
+			assert.Equal(t, "v1.0.0", resp.Entries[0].Version.Version)
+			assert.Nil(t, resp.Entries[0].SincePreviousRelease)
+			assert.Equal(t, "v1.1.0", resp.Entries[1].Version.Version)
+			assert.True(t, resp.Entries[1].Version.IsActive)
+			// 2024-05-01 to 2024-07-01
+			assert.Equal(t, 61*24*time.Hour, resp.Entries[1].SincePreviousRelease.AsDuration())
+		}
+	})
+
+	t.Run("unsorted versions and equal timestamps", func(t *testing.T) {
+		released := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		data := map[string]*model.Service{"svc-1": {
+			ID: "svc-1",
+			Versions: []*model.ServiceVersion{
+				{ID: "v3", Version: "v3.0.0", CreatedAt: model.Timestamp{Time: released.Add(time.Hour)}},
+				{ID: "v2", Version: "v2.0.0", CreatedAt: model.Timestamp{Time: released}},
+				{ID: "v1", Version: "v1.0.0", CreatedAt: model.Timestamp{Time: released}},
+			},
+		}}
+		svc := newTestCatalogService(data)
+
+		resp, err := svc.GetServiceHistory(context.Background(), &v1.GetServiceHistoryRequest{ServiceId: "svc-1"})
+		assert.NoError(t, err)
+		var ids []string
+		for _, e := range resp.Entries {
+			ids = append(ids, e.Version.Id)
+		}
+		// Ties keep data file order
+		assert.Equal(t, []string{"v2", "v1", "v3"}, ids)
+		assert.Equal(t, time.Duration(0), resp.Entries[1].SincePreviousRelease.AsDuration())
+		assert.Equal(t, time.Hour, resp.Entries[2].SincePreviousRelease.AsDuration())
+		// The published versions are not reordered
+		assert.Equal(t, "v3", data["svc-1"].Versions[0].ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.GetServiceHistory(context.Background(), &v1.GetServiceHistoryRequest{ServiceId: "svc-9"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("missing ID", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, err := svc.GetServiceHistory(context.Background(), &v1.GetServiceHistoryRequest{})
+		assert.Equal(t, ReasonMissingID, ReasonOf(err))
+	})
+}
+
+func TestCatalogService_PutService_MaxServices(t *testing.T) {
+	store := model.NewStore(4)
+	assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+	svc := NewCatalogService(store)
+
+	err := svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, ReasonStoreFull, ReasonOf(err))
+	assert.ErrorIs(t, err, model.ErrStoreFull)
+	assert.Len(t, svc.catalog(), 4)
+
+	// Replacing an existing service is still allowed at the limit
+	assert.NoError(t, svc.PutService(&model.Service{ID: "svc-1", Name: "Identity Service"}))
+	assert.Equal(t, "Identity Service", svc.catalog()["svc-1"].Name)
+
+	err = svc.ReplaceServices(append(servicesOf(mockTestData()), &model.Service{ID: "svc-5"}))
+	assert.Equal(t, ReasonStoreFull, ReasonOf(err))
+	assert.Equal(t, "Identity Service", svc.catalog()["svc-1"].Name)
+}
+
+func TestCatalogService_ReplaceServices_MaxServicesLeavesWritesUnchanged(t *testing.T) {
+	store := model.NewStore(5)
+	assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+	svc := NewCatalogService(store)
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+	_, err := svc.TouchService(adminCtx, &v1.TouchServiceRequest{Id: "svc-1"})
+	require.NoError(t, err)
+	touched := svc.catalog()["svc-1"]
+
+	// A file superseding the write but over the limit is rejected before anything changes
+	tooMany := mockTestData()
+	tooMany["svc-1"].UpdatedAt.Time = touched.UpdatedAt.Add(time.Second)
+	tooMany["svc-5"] = &model.Service{ID: "svc-5", Name: "Search Service"}
+	tooMany["svc-6"] = &model.Service{ID: "svc-6", Name: "Billing Service"}
+	err = svc.ReplaceServices(servicesOf(tooMany))
+	assert.Equal(t, ReasonStoreFull, ReasonOf(err))
+	assert.Same(t, touched, svc.catalog()["svc-1"])
+	assert.Len(t, svc.catalog(), 4)
+
+	// The write is still kept over an older file
+	require.NoError(t, svc.ReplaceServices(servicesOf(mockTestData())))
+	assert.Same(t, touched, svc.catalog()["svc-1"])
+}
+
+func TestCatalogService_Sharding(t *testing.T) {
+	// With 4 shards svc-1 and svc-3 hash to shard 1, svc-2 and svc-4 to shard 2
+	store := model.NewStore(0)
+	assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+	store.SetSharding(model.NewHashRing(4), 1)
+	svc := NewCatalogService(store)
+
+	listResp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), listResp.GetTotalCount())
+	var ids []string
+	for _, s := range listResp.GetServices() {
+		ids = append(ids, s.GetId())
+	}
+	assert.ElementsMatch(t, []string{"svc-1", "svc-3"}, ids)
+
+	getResp, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "svc-1", getResp.GetService().GetId())
+
+	_, err = svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonWrongShard, ReasonOf(err))
+	assert.Contains(t, err.Error(), "belongs to shard 2")
+
+	t.Run("lookups of other shards' services fail", func(t *testing.T) {
+		adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Role: "admin"})
+		lookups := map[string]func(id string) error{
+			"GetServiceVersions": func(id string) error {
+				_, err := svc.GetServiceVersions(context.Background(), &v1.GetServiceVersionsRequest{ServiceId: id})
+				return err
+			},
+			"StreamServiceVersions": func(id string) error {
+				return svc.StreamServiceVersions(&v1.StreamServiceVersionsRequest{ServiceId: id}, &versionStream{ctx: context.Background()})
+			},
+			"GetServiceHistory": func(id string) error {
+				_, err := svc.GetServiceHistory(context.Background(), &v1.GetServiceHistoryRequest{ServiceId: id})
+				return err
+			},
+			"DiffServices": func(id string) error {
+				_, err := svc.DiffServices(context.Background(), &v1.DiffServicesRequest{ServiceId: "svc-3", OtherServiceId: id})
+				return err
+			},
+			"TouchService": func(id string) error {
+				_, err := svc.TouchService(adminCtx, &v1.TouchServiceRequest{Id: id})
+				return err
+			},
+		}
+		for name, lookup := range lookups {
+			t.Run(name, func(t *testing.T) {
+				assert.NoError(t, lookup("svc-1"))
+				err := lookup("svc-2")
+				assert.Equal(t, codes.NotFound, status.Code(err))
+				assert.Equal(t, ReasonWrongShard, ReasonOf(err))
+			})
+		}
+	})
+
+	t.Run("services of other shards are not created", func(t *testing.T) {
+		// svc-6 hashes to shard 0
+		adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "admin"})
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: []*v1.Service{{Id: "svc-6", Name: "Search Service"}}})
+		assert.NoError(t, err)
+		if assert.Len(t, resp.GetResults(), 1) {
+			assert.Equal(t, string(ReasonWrongShard), resp.GetResults()[0].GetErrorReason())
+		}
+		assert.NotContains(t, svc.catalog(), "svc-6")
+	})
+
+	t.Run("catalog-wide reads cover the local shard", func(t *testing.T) {
+		countResp, err := svc.CountServices(context.Background(), &v1.CountServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), countResp.GetCount())
+
+		var exported []string
+		_, err = svc.ExportServices(context.Background(), &v1.ListServicesRequest{}, func(s *v1.Service) error {
+			exported = append(exported, s.GetId())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"svc-1", "svc-3"}, exported)
+
+		deltaResp, err := svc.ListServicesDelta(context.Background(), &v1.ListServicesDeltaRequest{})
+		assert.NoError(t, err)
+		var synced []string
+		for _, s := range deltaResp.GetServices() {
+			synced = append(synced, s.GetId())
+		}
+		assert.ElementsMatch(t, []string{"svc-1", "svc-3"}, synced)
+	})
+
+	t.Run("catalog statistics cover the local shard", func(t *testing.T) {
+		describeResp, err := svc.DescribeCatalog(context.Background(), &v1.DescribeCatalogRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), describeResp.GetTotalServices())
+		assert.Equal(t, int32(4), describeResp.GetTotalVersions())
+
+		orgsResp, err := svc.ListOrganizations(context.Background(), &v1.ListOrganizationsRequest{})
+		assert.NoError(t, err)
+		if assert.Len(t, orgsResp.GetOrganizations(), 1) {
+			assert.Equal(t, "org-1", orgsResp.GetOrganizations()[0].GetId())
+			assert.Equal(t, int32(2), orgsResp.GetOrganizations()[0].GetServiceCount())
+		}
+	})
+
+	t.Run("bulk activation changes the local shard only", func(t *testing.T) {
+		data := mockTestData()
+		data["svc-4"].Versions[1].IsActive = false
+		store := model.NewStore(0)
+		assert.NoError(t, store.SetServices(servicesOf(data)))
+		store.SetSharding(model.NewHashRing(4), 1)
+		svc := NewCatalogService(store)
+
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Role: "admin"})
+		resp, err := svc.ActivateVersionAcrossServices(ctx, &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc-1", "svc-3"}, resp.GetServiceIds())
+		assert.False(t, svc.catalog()["svc-4"].Versions[1].IsActive)
+	})
+
+	t.Run("single shard serves everything", func(t *testing.T) {
+		store := model.NewStore(0)
+		assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+		store.SetSharding(model.NewHashRing(1), 0)
+		svc := NewCatalogService(store)
+
+		listResp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), listResp.GetTotalCount())
+
+		_, err = svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-2"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatalogService_CoalescesConcurrentRequests(t *testing.T) {
+	const callers = 20
+
+	// blockFirstCompute holds the first computation open until every caller is waiting on it
+	blockFirstCompute := func(svc *CatalogService) (*int, chan struct{}, chan struct{}) {
+		var mu sync.Mutex
+		computed := 0
+		started, release := make(chan struct{}), make(chan struct{})
+		svc.flights.onCompute = func(string) {
+			mu.Lock()
+			computed++
+			first := computed == 1
+			mu.Unlock()
+			if first {
+				close(started)
+				<-release
+			}
+		}
+		return &computed, started, release
+	}
+
+	// fire starts callers concurrent calls of fn once the first one is computing, then lets it finish
+	fire := func(started, release chan struct{}, fn func() error) []error {
+		errs := make([]error, callers)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[0] = fn()
+		}()
+		<-started
+		for i := 1; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = fn()
+			}(i)
+		}
+		// Give the other callers time to join the running computation
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		return errs
+	}
+
+	t.Run("GetService", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		computed, started, release := blockFirstCompute(svc)
+
+		var mu sync.Mutex
+		var responses []*v1.GetServiceResponse
+		errs := fire(started, release, func() error {
+			resp, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+			mu.Lock()
+			responses = append(responses, resp)
+			mu.Unlock()
+			return err
+		})
+
+		assert.Equal(t, 1, *computed)
+		for i := range errs {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, "svc-1", responses[i].GetService().GetId())
+		}
+		// Every caller owns its response
+		assert.NotSame(t, responses[0], responses[1])
+	})
+
+	t.Run("ListServices", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		computed, started, release := blockFirstCompute(svc)
+
+		errs := fire(started, release, func() error {
+			resp, err := svc.ListServices(context.Background(), &v1.ListServicesRequest{SearchQuery: "service", SortBy: "name"})
+			if err == nil {
+				assert.Equal(t, int32(3), resp.GetTotalCount())
+			}
+			return err
+		})
+
+		assert.Equal(t, 1, *computed)
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("hits and misses are counted per method", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, started, release := blockFirstCompute(svc)
+
+		hits := logger.CacheHits().WithLabelValues("GetService")
+		misses := logger.CacheMisses().WithLabelValues("GetService")
+		coalesced := logger.CoalescedRequests().WithLabelValues("GetService")
+		listMisses := logger.CacheMisses().WithLabelValues("ListServices")
+		hitsBefore, missesBefore, coalescedBefore, listMissesBefore := hits.Value(), misses.Value(), coalesced.Value(), listMisses.Value()
+
+		errs := fire(started, release, func() error {
+			_, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+			return err
+		})
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+
+		// Only the caller whose computation ran is a miss, every caller that joined it is a hit
+		assert.Equal(t, int64(callers-1), hits.Value()-hitsBefore)
+		assert.Equal(t, int64(1), misses.Value()-missesBefore)
+		assert.Equal(t, int64(1), coalesced.Value()-coalescedBefore)
+		assert.Greater(t, logger.CacheHitRatio("GetService"), 0.0)
+		assert.Equal(t, listMissesBefore, listMisses.Value())
+
+		// A lone request is a miss and not a hit
+		hitsBefore, missesBefore = hits.Value(), misses.Value()
+		_, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, hitsBefore, hits.Value())
+		assert.Equal(t, missesBefore+1, misses.Value())
+	})
+
+	t.Run("different requests are not shared", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		var mu sync.Mutex
+		var keys []string
+		svc.flights.onCompute = func(key string) {
+			mu.Lock()
+			keys = append(keys, key)
+			mu.Unlock()
+		}
+
+		_, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+		assert.NoError(t, err)
+		_, err = svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-2"})
+		assert.NoError(t, err)
+		_, err = svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 2})
+		assert.NoError(t, err)
+		_, err = svc.ListServices(context.Background(), &v1.ListServicesRequest{PageSize: 3})
+		assert.NoError(t, err)
+		assert.Len(t, keys, 4)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+
+		_, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-5"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service"}))
+		resp, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-5"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Search Service", resp.GetService().GetName())
+	})
+
+	t.Run("cancelled caller does not fail the others", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		_, started, release := blockFirstCompute(svc)
+
+		leaderDone := make(chan error, 1)
+		go func() {
+			_, err := svc.GetService(context.Background(), &v1.GetServiceRequest{Id: "svc-1"})
+			leaderDone <- err
+		}()
+		<-started
+
+		// A caller that gives up returns at once, while the shared computation keeps running
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelledDone := make(chan error, 1)
+		go func() {
+			_, err := svc.GetService(ctx, &v1.GetServiceRequest{Id: "svc-1"})
+			cancelledDone <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		assert.Equal(t, codes.Canceled, status.Code(<-cancelledDone))
+
+		close(release)
+		assert.NoError(t, <-leaderDone)
+	})
+}
+
+func TestCatalogService_BatchGetServices(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	tests := []struct {
+		name        string
+		ids         []string
+		wantFound   []string
+		wantMissing []string
+		wantReason  Reason
+	}{
+		{name: "found and missing in request order", ids: []string{"svc-3", "svc-9", "svc-1"}, wantFound: []string{"svc-3", "svc-1"}, wantMissing: []string{"svc-9"}},
+		{name: "comma separated and padded", ids: []string{" svc-1 , svc-2", "svc-8 "}, wantFound: []string{"svc-1", "svc-2"}, wantMissing: []string{"svc-8"}},
+		{name: "duplicates ignored", ids: []string{"svc-1", "svc-1,svc-1"}, wantFound: []string{"svc-1"}},
+		{name: "empty", ids: []string{"", " , "}, wantReason: ReasonMissingID},
+		{name: "invalid id", ids: []string{"svc-1", "svc/2"}, wantReason: ReasonInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.BatchGetServices(context.Background(), &v1.BatchGetServicesRequest{Ids: tt.ids})
+			if tt.wantReason != "" {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, tt.wantReason, ReasonOf(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			var found []string
+			for _, s := range resp.GetServices() {
+				found = append(found, s.GetId())
+			}
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantMissing, resp.GetMissingIds())
+		})
+	}
+
+	t.Run("too many ids", func(t *testing.T) {
+		ids := make([]string, MaxBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("svc-%d", i)
+		}
+		_, err := svc.BatchGetServices(context.Background(), &v1.BatchGetServicesRequest{Ids: []string{strings.Join(ids, ",")}})
+		assert.Equal(t, ReasonTooManyIDs, ReasonOf(err))
+	})
+
+	t.Run("other shards are missing", func(t *testing.T) {
+		store := model.NewStore(0)
+		assert.NoError(t, store.SetServices(servicesOf(mockTestData())))
+		store.SetSharding(model.NewHashRing(4), 1)
+		sharded := NewCatalogService(store)
+
+		resp, err := sharded.BatchGetServices(context.Background(), &v1.BatchGetServicesRequest{Ids: []string{"svc-1,svc-2"}})
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetServices(), 1)
+		assert.Equal(t, []string{"svc-2"}, resp.GetMissingIds())
+	})
+}
+
+func TestCatalogService_PutService_RequireHTTPSURLs(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithRequireHTTPSURLs(true))
+
+	err := svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", URL: "http://search.example.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonInvalidURL, ReasonOf(err))
+	assert.NotContains(t, svc.catalog(), "svc-5")
+
+	assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", URL: "https://search.example.com"}))
+	assert.Contains(t, svc.catalog(), "svc-5")
+
+	// Without the option any URL is accepted
+	assert.NoError(t, newTestCatalogService(mockTestData()).PutService(&model.Service{ID: "svc-5", Name: "Search Service", URL: "http://search.example.com"}))
+}
+
+func TestCatalogService_RequireKnownOrganizations(t *testing.T) {
+	orgs := []*model.Organization{{ID: "org-1", DisplayName: "Platform Team"}, {ID: "org-2"}}
+
+	t.Run("unknown organization rejected", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithRequireKnownOrganizations(true))
+		svc.ReplaceOrganizations(orgs)
+
+		err := svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-9"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, ReasonUnknownOrganization, ReasonOf(err))
+		assert.NotContains(t, svc.catalog(), "svc-5")
+
+		// Listed organizations, even without a display name, and services without one are accepted
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-2"}))
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-6", Name: "Shared Service"}))
+	})
+
+	t.Run("unknown organization rejected on create", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithRequireKnownOrganizations(true))
+		svc.ReplaceOrganizations(orgs)
+		adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Role: "admin"})
+
+		resp, err := svc.CreateServices(adminCtx, &v1.CreateServicesRequest{Services: []*v1.Service{
+			{Id: "svc-5", Name: "Search Service", OrganizationId: "org-9"},
+			{Id: "svc-6", Name: "Billing Service", OrganizationId: "org-1"},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), resp.GetCreatedCount())
+		assert.Equal(t, string(ReasonUnknownOrganization), resp.GetResults()[0].GetErrorReason())
+		assert.Empty(t, resp.GetResults()[1].GetErrorReason())
+	})
+
+	t.Run("any organization without a list", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithRequireKnownOrganizations(true))
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-9"}))
+	})
+
+	t.Run("any organization without the option", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData())
+		svc.ReplaceOrganizations(orgs)
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service", OrganizationID: "org-9"}))
+	})
+}
+
+func TestCatalogService_PutService_NormalizesName(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithNameNormalization(model.NameNormalizationCollapse))
+
+	assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "  Search \t Service ", Description: " Finds  things\n"}))
+	assert.Equal(t, "Search Service", svc.catalog()["svc-5"].Name)
+	assert.Equal(t, "Finds  things", svc.catalog()["svc-5"].Description)
+
+	err := svc.PutService(&model.Service{ID: "svc-6", Name: " \t "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonMissingName, ReasonOf(err))
+	assert.NotContains(t, svc.catalog(), "svc-6")
+}
+
+func TestCatalogService_PutService_GeneratesIDs(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	added := &model.Service{
+		Name:     "Search Service",
+		Versions: []*model.ServiceVersion{{Version: "v1.0.0"}, {ID: "search-v2", Version: "v2.0.0"}},
+	}
+	assert.NoError(t, svc.PutService(added))
+
+	assert.Regexp(t, `^[0-9A-Z]{26}$`, added.ID)
+	assert.True(t, svc.isValidID(added.ID))
+	assert.Contains(t, svc.catalog(), added.ID)
+	assert.Regexp(t, `^[0-9A-Z]{26}$`, added.Versions[0].ID)
+	assert.Equal(t, "search-v2", added.Versions[1].ID, "existing version IDs are kept")
+	for _, v := range added.Versions {
+		assert.Equal(t, added.ID, v.ServiceID)
+	}
+
+	// A generated ID the configured format rejects is not added
+	strict := newTestCatalogService(mockTestData(), WithServiceIDFormat(regexp.MustCompile(`^svc-[0-9]+$`), 20))
+	err := strict.PutService(&model.Service{Name: "Search Service"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonInvalidID, ReasonOf(err))
+	assert.Len(t, strict.catalog(), len(mockTestData()))
+}
+
+func TestCatalogService_PutService_ConcurrentGeneratedIDs(t *testing.T) {
+	svc := newTestCatalogService(map[string]*model.Service{}, WithIDGenerator(idgen.UUID{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, svc.PutService(&model.Service{Name: "Generated Service"}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, svc.catalog(), 50)
+	for id := range svc.catalog() {
+		assert.True(t, svc.isValidID(id), id)
+	}
+}
+
+func TestCatalogService_ListAuditEvents(t *testing.T) {
+	base := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+
+	// newAuditedService records events one minute apart starting at base
+	newAuditedService := func(size int) *CatalogService {
+		svc := newTestCatalogService(mockTestData(), WithAuditLogSize(size))
+		clock := base
+		svc.audit.now = func() time.Time {
+			now := clock
+			clock = clock.Add(time.Minute)
+			return now
+		}
+		svc.audit.record("alice@example.com", AuditActionActivateVersion, "svc-1", "activated v1.0.0") // 09:00
+		svc.audit.record("bob@example.com", AuditActionActivateVersion, "svc-2", "activated v2.0.0")   // 09:01
+		svc.audit.record("alice@example.com", AuditActionActivateVersion, "svc-3", "activated v2.0.0") // 09:02
+		svc.audit.record(auditActorSystem, AuditActionReplaceCatalog, "", "loaded 4 services")         // 09:03
+		svc.audit.record("alice@example.com", AuditActionActivateVersion, "svc-1", "activated v1.1.0") // 09:04
+		return svc
+	}
+	idsOf := func(resp *v1.ListAuditEventsResponse) []string {
+		var ids []string
+		for _, e := range resp.GetEvents() {
+			ids = append(ids, e.GetId())
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name    string
+		req     *v1.ListAuditEventsRequest
+		wantIDs []string
+	}{
+		{name: "all newest first", req: &v1.ListAuditEventsRequest{}, wantIDs: []string{"5", "4", "3", "2", "1"}},
+		{name: "by actor", req: &v1.ListAuditEventsRequest{Actor: "alice@example.com"}, wantIDs: []string{"5", "3", "1"}},
+		{name: "by service", req: &v1.ListAuditEventsRequest{ServiceId: "svc-1"}, wantIDs: []string{"5", "1"}},
+		{name: "by action", req: &v1.ListAuditEventsRequest{Action: AuditActionReplaceCatalog}, wantIDs: []string{"4"}},
+		{
+			name: "by time window",
+			req: &v1.ListAuditEventsRequest{
+				StartTime: timestamppb.New(base.Add(time.Minute)),
+				EndTime:   timestamppb.New(base.Add(4 * time.Minute)),
+			},
+			wantIDs: []string{"4", "3", "2"},
+		},
+		{
+			name: "by actor and time window",
+			req: &v1.ListAuditEventsRequest{
+				Actor:     "alice@example.com",
+				StartTime: timestamppb.New(base.Add(time.Minute)),
+			},
+			wantIDs: []string{"5", "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := newAuditedService(10).ListAuditEvents(context.Background(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, idsOf(resp))
+			assert.Equal(t, int32(len(tt.wantIDs)), resp.GetTotalCount())
+		})
+	}
+
+	t.Run("event fields", func(t *testing.T) {
+		resp, err := newAuditedService(10).ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{PageSize: 1})
+		assert.NoError(t, err)
+		e := resp.GetEvents()[0]
+		assert.Equal(t, "alice@example.com", e.GetActor())
+		assert.Equal(t, AuditActionActivateVersion, e.GetAction())
+		assert.Equal(t, "svc-1", e.GetServiceId())
+		assert.Equal(t, "activated v1.1.0", e.GetDetails())
+		assert.Equal(t, base.Add(4*time.Minute), e.GetTime().AsTime())
+	})
+
+	t.Run("pages are unaffected by new events", func(t *testing.T) {
+		svc := newAuditedService(10)
+		resp, err := svc.ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{PageSize: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"5", "4"}, idsOf(resp))
+		assert.Equal(t, "audit_4", resp.GetNextPageToken())
+
+		svc.audit.record("carol@example.com", AuditActionPutService, "svc-5", "")
+		resp, err = svc.ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{PageSize: 2, PageToken: resp.GetNextPageToken()})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3", "2"}, idsOf(resp))
+
+		resp, err = svc.ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{PageSize: 2, PageToken: resp.GetNextPageToken()})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, idsOf(resp))
+		assert.Empty(t, resp.GetNextPageToken())
+	})
+
+	t.Run("oldest events are dropped when full", func(t *testing.T) {
+		resp, err := newAuditedService(3).ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"5", "4", "3"}, idsOf(resp))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		svc := newAuditedService(10)
+		_, err := svc.ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{PageToken: "page_2"})
+		assert.Equal(t, ReasonInvalidPageToken, ReasonOf(err))
+
+		_, err = svc.ListAuditEvents(context.Background(), &v1.ListAuditEventsRequest{
+			StartTime: timestamppb.New(base),
+			EndTime:   timestamppb.New(base),
+		})
+		assert.Equal(t, ReasonInvalidTimestamp, ReasonOf(err))
+
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Organization: "org-1", Role: "user"})
+		_, err = svc.ListAuditEvents(ctx, &v1.ListAuditEventsRequest{})
+		assert.Equal(t, ReasonAdminRequired, ReasonOf(err))
+	})
+
+	t.Run("changes are recorded", func(t *testing.T) {
+		svc := newTestCatalogService(mockTestData(), WithAuditLogSize(10))
+		ctx := context.WithValue(context.Background(), "user", &auth.Claims{Email: "admin@example.com", Organization: "org-1", Role: "admin"})
+
+		_, err := svc.ActivateVersionAcrossServices(ctx, &v1.ActivateVersionAcrossServicesRequest{Version: "v1.0.0"})
+		assert.NoError(t, err)
+		assert.NoError(t, svc.PutService(&model.Service{ID: "svc-5", Name: "Search Service"}))
+
+		resp, err := svc.ListAuditEvents(ctx, &v1.ListAuditEventsRequest{})
+		assert.NoError(t, err)
+		var got []string
+		for _, e := range resp.GetEvents() {
+			got = append(got, e.GetActor()+" "+e.GetAction()+" "+e.GetServiceId())
+		}
+		assert.Equal(t, []string{
+			"system service.put svc-5",
+			"admin@example.com version.activate svc-3",
+			"admin@example.com version.activate svc-1",
+		}, got)
+	})
+}
+
+func TestCatalogService_ListServices_DefaultOrganization(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithDefaultOrganization("org-1"))
+	adminCtx := context.WithValue(context.Background(), "user", &auth.Claims{Role: "admin", Organization: "org-2"})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *v1.ListServicesRequest
+		wantIDs []string
+	}{
+		{name: "anonymous is scoped to the default organization", ctx: context.Background(), req: &v1.ListServicesRequest{}, wantIDs: []string{"svc-1", "svc-3"}},
+		{name: "explicit organization overrides the default", ctx: context.Background(), req: &v1.ListServicesRequest{OrganizationId: "org-2"}, wantIDs: []string{"svc-2"}},
+		{name: "authenticated caller sees its own organization", ctx: adminCtx, req: &v1.ListServicesRequest{}, wantIDs: []string{"svc-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.ListServices(tt.ctx, tt.req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var ids []string
+			for _, s := range resp.Services {
+				ids = append(ids, s.Id)
+			}
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+			assert.Equal(t, int32(len(tt.wantIDs)), resp.TotalCount)
+		})
+	}
+
+	// The caller's request is left unchanged
+	assert.Empty(t, tests[0].req.OrganizationId)
+
+	// Without a default organization anonymous callers see every organization
+	resp, err := newTestCatalogService(mockTestData()).ListServices(context.Background(), &v1.ListServicesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Services, 4)
+}
+
+func TestCatalogService_ListServices_MatchesExport(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithDefaultOrganization("org-1"))
+	org2 := context.WithValue(context.Background(), "user", &auth.Claims{Role: "user", Organization: "org-2"})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *v1.ListServicesRequest
+		wantIDs []string
+	}{
+		{name: "anonymous", ctx: context.Background(), req: &v1.ListServicesRequest{}, wantIDs: []string{"svc-3", "svc-1"}},
+		{name: "authenticated", ctx: org2, req: &v1.ListServicesRequest{}, wantIDs: []string{"svc-2"}},
+		{name: "authenticated asking for its organization", ctx: org2, req: &v1.ListServicesRequest{OrganizationIds: []string{"org-1", "org-2"}}, wantIDs: []string{"svc-2"}},
+		{name: "authenticated asking for another organization", ctx: org2, req: &v1.ListServicesRequest{OrganizationId: "org-1"}, wantIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := svc.ListServices(tt.ctx, tt.req)
+			assert.NoError(t, err)
+
+			exported := []string{}
+			_, err = svc.ExportServices(tt.ctx, tt.req, func(s *v1.Service) error {
+				exported = append(exported, s.GetId())
+				return nil
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantIDs, exported)
+			assert.Equal(t, exported, serviceIDs(list.GetServices()))
+			assert.Equal(t, int32(len(exported)), list.GetTotalCount())
+		})
+	}
+}
+
+func TestCatalogService_RedactsRequestLogs(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.Get()
+	logger.SetLogger(zap.New(core).Sugar())
+	logger.SetRedactedFields([]string{"organization_id", "search_query"})
+	t.Cleanup(func() {
+		logger.SetLogger(previous)
+		logger.SetRedactedFields(nil)
+	})
+
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+	_, err := svc.ListServices(ctx, &v1.ListServicesRequest{OrganizationId: "org-secret", SearchQuery: "payments"})
+	assert.NoError(t, err)
+	_, err = svc.CountServices(ctx, &v1.CountServicesRequest{OrganizationId: "org-secret", SearchQuery: "payments"})
+	assert.NoError(t, err)
+	_, err = svc.ExportServices(ctx, &v1.ListServicesRequest{OrganizationId: "org-secret", SearchQuery: "payments"}, func(*v1.Service) error { return nil })
+	assert.NoError(t, err)
+	_, err = svc.SearchVersions(ctx, &v1.SearchVersionsRequest{SearchQuery: "payments"})
+	assert.NoError(t, err)
+
+	called := 0
+	for _, entry := range logs.All() {
+		if !strings.HasSuffix(entry.Message, " called") {
+			continue
+		}
+		called++
+		fields := entry.ContextMap()
+		for _, key := range []string{"organization_id", "search_query"} {
+			if value, ok := fields[key]; ok {
+				assert.Contains(t, value, "sha256:", "%s of %q", key, entry.Message)
+			}
+		}
+	}
+	assert.Equal(t, 4, called)
+}
+
+func TestCatalogService_CountServices(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       *v1.CountServicesRequest
+		wantCount int32
+	}{
+		{name: "all services", req: &v1.CountServicesRequest{}, wantCount: 4},
+		{name: "organization", req: &v1.CountServicesRequest{OrganizationId: "org-1"}, wantCount: 2},
+		{name: "search", req: &v1.CountServicesRequest{SearchQuery: "service"}, wantCount: 3},
+		{name: "organization and search", req: &v1.CountServicesRequest{OrganizationId: "org-1", SearchQuery: "inventory"}, wantCount: 1},
+		{name: "version", req: &v1.CountServicesRequest{Version: "v1.0.0"}, wantCount: 3},
+		{name: "any search term", req: &v1.CountServicesRequest{SearchQuery: "payments reports", SearchMatch: "any"}, wantCount: 2},
+		{name: "no match", req: &v1.CountServicesRequest{OrganizationId: "org-9"}, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.CountServices(ctx, tt.req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCount, resp.Count)
+
+			// The count is the total_count ListServices reports for the same filters
+			list, err := svc.ListServices(ctx, &v1.ListServicesRequest{
+				OrganizationId: tt.req.OrganizationId,
+				SearchQuery:    tt.req.SearchQuery,
+				Version:        tt.req.Version,
+				SearchFields:   tt.req.SearchFields,
+				SearchMatch:    tt.req.SearchMatch,
+			})
+			if assert.NoError(t, err) {
+				assert.Equal(t, list.TotalCount, resp.Count)
+			}
+		})
+	}
+}
+
+func TestCatalogService_CountServices_Scoping(t *testing.T) {
+	svc := newTestCatalogService(mockTestData(), WithDefaultOrganization("org-1"))
+
+	// Anonymous callers are scoped to the default organization unless they name one
+	resp, err := svc.CountServices(context.Background(), &v1.CountServicesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Count)
+
+	resp, err = svc.CountServices(context.Background(), &v1.CountServicesRequest{OrganizationId: "org-3"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Count)
+
+	// Invalid filters are rejected like ListServices rejects them
+	_, err = svc.CountServices(context.Background(), &v1.CountServicesRequest{OrganizationId: "org 1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Like every read, counting waits for the catalog to load
+	_, err = (&CatalogService{}).CountServices(context.Background(), &v1.CountServicesRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// versionStream collects the messages sent on a StreamServiceVersions stream
+type versionStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks [][]string
+	// cancelAfter cancels the stream context once this many messages are sent, when set
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (s *versionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *versionStream) Send(resp *v1.StreamServiceVersionsResponse) error {
+	var chunk []string
+	for _, v := range resp.Versions {
+		chunk = append(chunk, v.Version)
+	}
+	s.chunks = append(s.chunks, chunk)
+	if s.cancel != nil && len(s.chunks) == s.cancelAfter {
+		s.cancel()
+	}
+	return nil
+}
+
+func TestCatalogService_StreamServiceVersions(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+	active := true
+
+	tests := []struct {
+		name       string
+		req        *v1.StreamServiceVersionsRequest
+		wantChunks [][]string
+		wantCode   codes.Code
+	}{
+		{name: "default chunk size", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1"}, wantChunks: [][]string{{"v1.0.0", "v1.1.0"}}},
+		{name: "one version per chunk", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: 1}, wantChunks: [][]string{{"v1.0.0"}, {"v1.1.0"}}},
+		{name: "active only", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", IsActive: &active}, wantChunks: [][]string{{"v1.1.0"}}},
+		{
+			name:       "updated after",
+			req:        &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", UpdatedAfter: timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))},
+			wantChunks: [][]string{{"v1.1.0"}},
+		},
+		{
+			name: "no match sends nothing",
+			req:  &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", UpdatedAfter: timestamppb.New(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+		{name: "missing service", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-9"}, wantCode: codes.NotFound},
+		{name: "missing service ID", req: &v1.StreamServiceVersionsRequest{}, wantCode: codes.InvalidArgument},
+		{name: "chunk size too large", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: MaxVersionChunkSize + 1}, wantCode: codes.InvalidArgument},
+		{name: "negative chunk size", req: &v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: -1}, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &versionStream{ctx: context.Background()}
+			err := svc.StreamServiceVersions(tt.req, stream)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantChunks, stream.chunks)
+		})
+	}
+}
+
+func TestCatalogService_StreamServiceVersions_Cancelled(t *testing.T) {
+	svc := newTestCatalogService(mockTestData())
+
+	// The stream stops at the first chunk after the client goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &versionStream{ctx: ctx, cancelAfter: 1, cancel: cancel}
+	err := svc.StreamServiceVersions(&v1.StreamServiceVersionsRequest{ServiceId: "svc-1", ChunkSize: 1}, stream)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Equal(t, [][]string{{"v1.0.0"}}, stream.chunks)
+
+	// Like every read, streaming waits for the catalog to load
+	err = (&CatalogService{}).StreamServiceVersions(&v1.StreamServiceVersionsRequest{ServiceId: "svc-1"}, &versionStream{ctx: context.Background()})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestCatalogService_ListServices_MaxListResults(t *testing.T) {
+	data := make(map[string]*model.Service)
+	for i := 0; i < 25; i++ {
+		id := fmt.Sprintf("svc-%02d", i)
+		data[id] = &model.Service{ID: id, Name: "Service " + id, OrganizationID: "org-1"}
+	}
+	svc := newTestCatalogService(data, WithMaxListResults(8))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       *v1.ListServicesRequest
+		wantPages []int
+	}{
+		{name: "largest page is truncated to the cap", req: &v1.ListServicesRequest{PageSize: MaxPageSize}, wantPages: []int{8, 8, 8, 1}},
+		{name: "default page is truncated to the cap", req: &v1.ListServicesRequest{}, wantPages: []int{8, 8, 8, 1}},
+		{name: "smaller pages are unaffected", req: &v1.ListServicesRequest{PageSize: 5}, wantPages: []int{5, 5, 5, 5, 5}},
+		{name: "without total count", req: &v1.ListServicesRequest{PageSize: MaxPageSize, SkipTotalCount: true}, wantPages: []int{8, 8, 8, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages []int
+			seen := make(map[string]bool)
+			req := tt.req
+			for len(pages) < 10 {
+				resp, err := svc.ListServices(ctx, req)
+				if !assert.NoError(t, err) {
+					return
+				}
+				pages = append(pages, len(resp.Services))
+				for _, s := range resp.Services {
+					assert.False(t, seen[s.Id], "service %s returned twice", s.Id)
+					seen[s.Id] = true
+				}
+				if !req.SkipTotalCount {
+					assert.Equal(t, int32(25), resp.TotalCount, "total_count still counts every match")
+				}
+				if resp.NextPageToken == "" {
+					break
+				}
+				req = proto.Clone(req).(*v1.ListServicesRequest)
+				req.PageToken = resp.NextPageToken
+			}
+			assert.Equal(t, tt.wantPages, pages)
+			assert.Len(t, seen, 25)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/model"
+)
+
+const (
+	// DefaultSnapshotTTL is how long a ListServices snapshot stays readable after it is captured
+	DefaultSnapshotTTL = 5 * time.Minute
+
+	// DefaultMaxSnapshots is how many ListServices snapshots are kept at once
+	DefaultMaxSnapshots = 1000
+
+	// snapshotMinIdle is how long a snapshot must go unread before a new snapshot may evict it,
+	// so snapshots being paged through are not dropped mid-pagination
+	snapshotMinIdle = 30 * time.Second
+
+	// snapshotTokenPrefix marks page tokens that read from a frozen snapshot - format: "snap_<id>_<offset>"
+	snapshotTokenPrefix = "snap_"
+)
+
+// snapshot is a frozen copy of filtered and sorted ListServices results
+type snapshot struct {
+	id        string
+	services  []*model.Service
+	expiresAt time.Time
+	lastUsed  time.Time
+}
+
+// snapshotStore keeps ListServices snapshots in memory until they expire, at most maxCount at once,
+// evicting the least recently used one to make room
+type snapshotStore struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru orders the snapshots from most to least recently used
+	lru      *list.List
+	ttl      time.Duration
+	maxCount int
+	now      func() time.Time
+}
+
+// newSnapshotStore creates an empty snapshot store whose entries expire after ttl, holding at most
+// DefaultMaxSnapshots snapshots
+func newSnapshotStore(ttl time.Duration) *snapshotStore {
+	return &snapshotStore{
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		ttl:      ttl,
+		maxCount: DefaultMaxSnapshots,
+		now:      time.Now,
+	}
+}
+
+// put stores a copy of services and returns the new snapshot ID. When the store is full the least recently used
+// snapshot is evicted, unless it was read within snapshotMinIdle: then every snapshot is in use and
+// ResourceExhausted is returned with a retry hint.
+func (s *snapshotStore) put(services []*model.Service) (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", newError(codes.Internal, ReasonInternal, nil, "failed to generate snapshot ID: %v", err)
+	}
+	id := hex.EncodeToString(buf)
+
+	frozen := make([]*model.Service, len(services))
+	copy(frozen, services)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.purgeExpired()
+	if s.maxCount > 0 && s.lru.Len() >= s.maxCount {
+		oldest := s.lru.Back().Value.(*snapshot)
+		if idle := now.Sub(oldest.lastUsed); idle < snapshotMinIdle {
+			err := newError(codes.ResourceExhausted, ReasonTooManySnapshots, nil, "too many snapshots in use, max %d", s.maxCount)
+			// by then the least recently used snapshot can be evicted, unless it is read again
+			err.RetryDelay = snapshotMinIdle - idle
+			return "", err
+		}
+		s.remove(s.lru.Back())
+		logger.Get().Debugw("Evicted least recently used snapshot", "snapshot_id", oldest.id)
+	}
+
+	s.entries[id] = s.lru.PushFront(&snapshot{
+		id:        id,
+		services:  frozen,
+		expiresAt: now.Add(s.ttl),
+		lastUsed:  now,
+	})
+	return id, nil
+}
+
+// get returns the services of an unexpired snapshot, marking it as the most recently used
+func (s *snapshotStore) get(id string) ([]*model.Service, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[id]
+	if !ok {
+		return nil, false
+	}
+	snap := elem.Value.(*snapshot)
+	now := s.now()
+	if !now.Before(snap.expiresAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	snap.lastUsed = now
+	s.lru.MoveToFront(elem)
+	return snap.services, true
+}
+
+// purgeExpired drops expired snapshots, the caller must hold the lock
+func (s *snapshotStore) purgeExpired() {
+	now := s.now()
+	for _, elem := range s.entries {
+		if !now.Before(elem.Value.(*snapshot).expiresAt) {
+			s.remove(elem)
+		}
+	}
+}
+
+// remove drops a snapshot, the caller must hold the lock
+func (s *snapshotStore) remove(elem *list.Element) {
+	delete(s.entries, elem.Value.(*snapshot).id)
+	s.lru.Remove(elem)
+}
+
+// isSnapshotToken reports whether a page token refers to a snapshot
+func isSnapshotToken(pageToken string) bool {
+	return strings.HasPrefix(pageToken, snapshotTokenPrefix)
+}
+
+// snapshotPageToken builds the page token for the given snapshot and offset
+func snapshotPageToken(id string, offset int32) string {
+	return fmt.Sprintf("%s%s_%d", snapshotTokenPrefix, id, offset)
+}
+
+// parseSnapshotToken splits a snapshot page token into its snapshot ID and offset
+func parseSnapshotToken(pageToken string) (string, int, error) {
+	id, offsetStr, ok := strings.Cut(strings.TrimPrefix(pageToken, snapshotTokenPrefix), "_")
+	if !ok || id == "" {
+		return "", 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token format")
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		return "", 0, newInvalidArgumentError(ReasonInvalidPageToken, "invalid page token: %v", err)
+	}
+
+	return id, offset, nil
+}
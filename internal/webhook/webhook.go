@@ -0,0 +1,168 @@
+// Package webhook delivers catalog change events to external HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ankittk/catalog-service/internal/logger"
+	"github.com/ankittk/catalog-service/internal/service"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the request body keyed with the secret
+	SignatureHeader = "X-Catalog-Signature"
+	// EventHeader carries the event type, e.g. "service.created"
+	EventHeader = "X-Catalog-Event"
+)
+
+// Config describes where and how events are delivered
+type Config struct {
+	// URLs receive a POST for every event
+	URLs []string
+	// Secret signs every request body, see SignatureHeader
+	Secret string
+	// MaxRetries is how many times a failed delivery is retried, waiting RetryBackoff before the first retry
+	// and doubling the wait for each further one
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// Timeout bounds each delivery attempt
+	Timeout time.Duration
+}
+
+// Payload is the JSON body posted for an event
+type Payload struct {
+	Type      service.EventType `json:"type"`
+	ServiceID string            `json:"service_id"`
+	Time      time.Time         `json:"time"`
+	// Service is the service after the change in the API's JSON format, absent for deletions
+	Service json.RawMessage `json:"service,omitempty"`
+}
+
+// Dispatcher posts events to the configured URLs in the order they occurred
+type Dispatcher struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewDispatcher creates a dispatcher for cfg
+func NewDispatcher(cfg Config) *Dispatcher {
+	return &Dispatcher{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// Run delivers events until the channel is closed or ctx is cancelled. It is meant to run in its own goroutine
+// reading a subscription, whose buffer bounds the queue of pending deliveries.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan service.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			d.dispatch(ctx, e)
+		}
+	}
+}
+
+// dispatch delivers one event to every URL, logging deliveries that fail after all retries
+func (d *Dispatcher) dispatch(ctx context.Context, e service.Event) {
+	body, err := encodePayload(e)
+	if err != nil {
+		logger.Get().Errorw("Failed to encode webhook payload", "event", e.Type, "service_id", e.ServiceID, "error", err)
+		return
+	}
+
+	for _, url := range d.cfg.URLs {
+		if err := d.deliver(ctx, url, e.Type, body); err != nil {
+			logger.Get().Errorw("Webhook delivery failed",
+				"url", url,
+				"event", e.Type,
+				"service_id", e.ServiceID,
+				"error", err)
+		}
+	}
+}
+
+// deliver posts body to url, retrying transport errors, 429 and 5xx responses with exponential backoff
+func (d *Dispatcher) deliver(ctx context.Context, url string, eventType service.EventType, body []byte) error {
+	backoff := d.cfg.RetryBackoff
+	var err error
+	for attempt := 0; attempt <= d.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retryable bool
+		if retryable, err = d.post(ctx, url, eventType, body); err == nil || !retryable {
+			return err
+		}
+		logger.Get().Warnw("Webhook delivery attempt failed", "url", url, "event", eventType, "attempt", attempt+1, "error", err)
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", d.cfg.MaxRetries+1, err)
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth retrying
+func (d *Dispatcher) post(ctx context.Context, url string, eventType service.EventType, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(eventType))
+	req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("endpoint responded %s", resp.Status)
+	default:
+		return false, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+}
+
+// Sign returns the signature header value of body: "sha256=" followed by its hex HMAC-SHA256 keyed with secret.
+// Receivers should compare it to their own computation with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// encodePayload renders the JSON body of an event
+func encodePayload(e service.Event) ([]byte, error) {
+	payload := Payload{Type: e.Type, ServiceID: e.ServiceID, Time: e.Time}
+	if svc := e.ServiceProto(); svc != nil {
+		encoded, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(svc)
+		if err != nil {
+			return nil, err
+		}
+		payload.Service = encoded
+	}
+	return json.Marshal(payload)
+}
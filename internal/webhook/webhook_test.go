@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ankittk/catalog-service/internal/model"
+	"github.com/ankittk/catalog-service/internal/service"
+)
+
+const testSecret = "webhook-secret"
+
+// delivery is a request received by the test endpoint
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// newEndpoint starts a server answering with the given status codes in turn (200 once they run out)
+// and reporting every request on the returned channel
+func newEndpoint(t *testing.T, statuses ...int) (*httptest.Server, <-chan delivery, *atomic.Int32) {
+	t.Helper()
+	received := make(chan delivery, 10)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := int(calls.Add(1))
+		received <- delivery{header: r.Header.Clone(), body: body}
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, received, &calls
+}
+
+func newTestDispatcher(url string) *Dispatcher {
+	return NewDispatcher(Config{
+		URLs:         []string{url},
+		Secret:       testSecret,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+		Timeout:      time.Second,
+	})
+}
+
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	server, received, _ := newEndpoint(t)
+	events := make(chan service.Event, 1)
+	events <- service.Event{
+		Type:      service.EventServiceCreated,
+		ServiceID: "svc-1",
+		Service:   &model.Service{ID: "svc-1", Name: "User Service"},
+		Time:      time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC),
+	}
+	close(events)
+
+	newTestDispatcher(server.URL).Run(context.Background(), events)
+
+	d := <-received
+	assert.True(t, hmac.Equal([]byte(Sign(testSecret, d.body)), []byte(d.header.Get(SignatureHeader))))
+	assert.Equal(t, "service.created", d.header.Get(EventHeader))
+	assert.Equal(t, "application/json", d.header.Get("Content-Type"))
+
+	var payload struct {
+		Type      string    `json:"type"`
+		ServiceID string    `json:"service_id"`
+		Time      time.Time `json:"time"`
+		Service   struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"service"`
+	}
+	require.NoError(t, json.Unmarshal(d.body, &payload))
+	assert.Equal(t, "service.created", payload.Type)
+	assert.Equal(t, "svc-1", payload.ServiceID)
+	assert.Equal(t, "User Service", payload.Service.Name)
+	assert.True(t, payload.Time.Equal(time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestDispatcher_DeletedEventHasNoService(t *testing.T) {
+	server, received, _ := newEndpoint(t)
+	events := make(chan service.Event, 1)
+	events <- service.Event{Type: service.EventServiceDeleted, ServiceID: "svc-1", Time: time.Now()}
+	close(events)
+
+	newTestDispatcher(server.URL).Run(context.Background(), events)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal((<-received).body, &payload))
+	assert.Equal(t, "service.deleted", payload["type"])
+	assert.NotContains(t, payload, "service")
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "server error then success", statuses: []int{http.StatusInternalServerError, http.StatusBadGateway}, wantCalls: 3},
+		{name: "rate limited then success", statuses: []int{http.StatusTooManyRequests}, wantCalls: 2},
+		{name: "gives up after max retries", statuses: []int{500, 500, 500, 500}, wantCalls: 3},
+		{name: "client error is not retried", statuses: []int{http.StatusBadRequest}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, calls := newEndpoint(t, tt.statuses...)
+			events := make(chan service.Event, 1)
+			events <- service.Event{Type: service.EventServiceDeleted, ServiceID: "svc-1", Time: time.Now()}
+			close(events)
+
+			newTestDispatcher(server.URL).Run(context.Background(), events)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestDispatcher_Run_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		newTestDispatcher("http://127.0.0.1:0").Run(ctx, make(chan service.Event))
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, Sign("secret", []byte("body")), Sign("secret", []byte("body")))
+	assert.NotEqual(t, Sign("secret", []byte("body")), Sign("other", []byte("body")))
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, Sign("secret", []byte("body")))
+}
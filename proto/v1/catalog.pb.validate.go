@@ -176,6 +176,10 @@ func (m *Service) validate(all bool) error {
 
 	// no validation rules for Url
 
+	// no validation rules for HasBreakingChange
+
+	// no validation rules for OrganizationName
+
 	if len(errors) > 0 {
 		return ServiceMultiError(errors)
 	}
@@ -189,7 +193,7 @@ type ServiceMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
 func (m ServiceMultiError) Error() string {
-	var msgs []string
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -375,7 +379,7 @@ type ServiceVersionMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
 func (m ServiceVersionMultiError) Error() string {
-	var msgs []string
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -461,10 +465,10 @@ func (m *ListServicesRequest) validate(all bool) error {
 
 	var errors []error
 
-	if val := m.GetPageSize(); val < 1 || val > 100 {
+	if val := m.GetPageSize(); val < 0 || val > 100 {
 		err := ListServicesRequestValidationError{
 			field:  "PageSize",
-			reason: "value must be inside range [1, 100]",
+			reason: "value must be inside range [0, 100]",
 		}
 		if !all {
 			return err
@@ -482,6 +486,16 @@ func (m *ListServicesRequest) validate(all bool) error {
 
 	// no validation rules for SortOrder
 
+	// no validation rules for Snapshot
+
+	// no validation rules for Version
+
+	// no validation rules for SearchFields
+
+	// no validation rules for SearchMatch
+
+	// no validation rules for SkipTotalCount
+
 	if len(errors) > 0 {
 		return ListServicesRequestMultiError(errors)
 	}
@@ -496,7 +510,7 @@ type ListServicesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
 func (m ListServicesRequestMultiError) Error() string {
-	var msgs []string
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -622,6 +636,35 @@ func (m *ListServicesResponse) validate(all bool) error {
 
 	// no validation rules for TotalCount
 
+	if all {
+		switch v := interface{}(m.GetLinks()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListServicesResponseValidationError{
+					field:  "Links",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListServicesResponseValidationError{
+					field:  "Links",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLinks()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListServicesResponseValidationError{
+				field:  "Links",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ListServicesResponseMultiError(errors)
 	}
@@ -636,7 +679,7 @@ type ListServicesResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
 func (m ListServicesResponseMultiError) Error() string {
-	var msgs []string
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -702,54 +745,53 @@ var _ interface {
 	ErrorName() string
 } = ListServicesResponseValidationError{}
 
-// Validate checks the field values on GetServiceRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetServiceRequest) Validate() error {
+// Validate checks the field values on CountServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CountServicesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetServiceRequest with the rules
+// ValidateAll checks the field values on CountServicesRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetServiceRequestMultiError, or nil if none found.
-func (m *GetServiceRequest) ValidateAll() error {
+// CountServicesRequestMultiError, or nil if none found.
+func (m *CountServicesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetServiceRequest) validate(all bool) error {
+func (m *CountServicesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetId()) < 1 {
-		err := GetServiceRequestValidationError{
-			field:  "Id",
-			reason: "value length must be at least 1 runes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for OrganizationId
+
+	// no validation rules for SearchQuery
+
+	// no validation rules for Version
+
+	// no validation rules for SearchFields
+
+	// no validation rules for SearchMatch
 
 	if len(errors) > 0 {
-		return GetServiceRequestMultiError(errors)
+		return CountServicesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetServiceRequestMultiError is an error wrapping multiple validation errors
-// returned by GetServiceRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetServiceRequestMultiError []error
+// CountServicesRequestMultiError is an error wrapping multiple validation
+// errors returned by CountServicesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CountServicesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetServiceRequestMultiError) Error() string {
-	var msgs []string
+func (m CountServicesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -757,11 +799,11 @@ func (m GetServiceRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetServiceRequestMultiError) AllErrors() []error { return m }
+func (m CountServicesRequestMultiError) AllErrors() []error { return m }
 
-// GetServiceRequestValidationError is the validation error returned by
-// GetServiceRequest.Validate if the designated constraints aren't met.
-type GetServiceRequestValidationError struct {
+// CountServicesRequestValidationError is the validation error returned by
+// CountServicesRequest.Validate if the designated constraints aren't met.
+type CountServicesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -769,24 +811,24 @@ type GetServiceRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetServiceRequestValidationError) Field() string { return e.field }
+func (e CountServicesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetServiceRequestValidationError) Reason() string { return e.reason }
+func (e CountServicesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetServiceRequestValidationError) Cause() error { return e.cause }
+func (e CountServicesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetServiceRequestValidationError) Key() bool { return e.key }
+func (e CountServicesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetServiceRequestValidationError) ErrorName() string {
-	return "GetServiceRequestValidationError"
+func (e CountServicesRequestValidationError) ErrorName() string {
+	return "CountServicesRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetServiceRequestValidationError) Error() string {
+func (e CountServicesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -798,14 +840,14 @@ func (e GetServiceRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetServiceRequest.%s: %s%s",
+		"invalid %sCountServicesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetServiceRequestValidationError{}
+var _ error = CountServicesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -813,74 +855,47 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetServiceRequestValidationError{}
+} = CountServicesRequestValidationError{}
 
-// Validate checks the field values on GetServiceResponse with the rules
+// Validate checks the field values on CountServicesResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetServiceResponse) Validate() error {
+func (m *CountServicesResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetServiceResponse with the rules
+// ValidateAll checks the field values on CountServicesResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetServiceResponseMultiError, or nil if none found.
-func (m *GetServiceResponse) ValidateAll() error {
+// CountServicesResponseMultiError, or nil if none found.
+func (m *CountServicesResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetServiceResponse) validate(all bool) error {
+func (m *CountServicesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetService()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetServiceResponseValidationError{
-					field:  "Service",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetServiceResponseValidationError{
-					field:  "Service",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetService()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetServiceResponseValidationError{
-				field:  "Service",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Count
 
 	if len(errors) > 0 {
-		return GetServiceResponseMultiError(errors)
+		return CountServicesResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetServiceResponseMultiError is an error wrapping multiple validation errors
-// returned by GetServiceResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetServiceResponseMultiError []error
+// CountServicesResponseMultiError is an error wrapping multiple validation
+// errors returned by CountServicesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CountServicesResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetServiceResponseMultiError) Error() string {
-	var msgs []string
+func (m CountServicesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -888,11 +903,11 @@ func (m GetServiceResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetServiceResponseMultiError) AllErrors() []error { return m }
+func (m CountServicesResponseMultiError) AllErrors() []error { return m }
 
-// GetServiceResponseValidationError is the validation error returned by
-// GetServiceResponse.Validate if the designated constraints aren't met.
-type GetServiceResponseValidationError struct {
+// CountServicesResponseValidationError is the validation error returned by
+// CountServicesResponse.Validate if the designated constraints aren't met.
+type CountServicesResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -900,24 +915,24 @@ type GetServiceResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetServiceResponseValidationError) Field() string { return e.field }
+func (e CountServicesResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetServiceResponseValidationError) Reason() string { return e.reason }
+func (e CountServicesResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetServiceResponseValidationError) Cause() error { return e.cause }
+func (e CountServicesResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetServiceResponseValidationError) Key() bool { return e.key }
+func (e CountServicesResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetServiceResponseValidationError) ErrorName() string {
-	return "GetServiceResponseValidationError"
+func (e CountServicesResponseValidationError) ErrorName() string {
+	return "CountServicesResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetServiceResponseValidationError) Error() string {
+func (e CountServicesResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -929,14 +944,14 @@ func (e GetServiceResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetServiceResponse.%s: %s%s",
+		"invalid %sCountServicesResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetServiceResponseValidationError{}
+var _ error = CountServicesResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -944,56 +959,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetServiceResponseValidationError{}
+} = CountServicesResponseValidationError{}
 
-// Validate checks the field values on GetServiceVersionsRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetServiceVersionsRequest) Validate() error {
+// Validate checks the field values on PageLinks with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PageLinks) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetServiceVersionsRequest with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetServiceVersionsRequestMultiError, or nil if none found.
-func (m *GetServiceVersionsRequest) ValidateAll() error {
+// ValidateAll checks the field values on PageLinks with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PageLinksMultiError, or nil
+// if none found.
+func (m *PageLinks) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetServiceVersionsRequest) validate(all bool) error {
+func (m *PageLinks) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetServiceId()) < 1 {
-		err := GetServiceVersionsRequestValidationError{
-			field:  "ServiceId",
-			reason: "value length must be at least 1 runes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for Self
+
+	// no validation rules for Next
 
 	if len(errors) > 0 {
-		return GetServiceVersionsRequestMultiError(errors)
+		return PageLinksMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetServiceVersionsRequestMultiError is an error wrapping multiple validation
-// errors returned by GetServiceVersionsRequest.ValidateAll() if the
-// designated constraints aren't met.
-type GetServiceVersionsRequestMultiError []error
+// PageLinksMultiError is an error wrapping multiple validation errors returned
+// by PageLinks.ValidateAll() if the designated constraints aren't met.
+type PageLinksMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetServiceVersionsRequestMultiError) Error() string {
-	var msgs []string
+func (m PageLinksMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -1001,11 +1008,11 @@ func (m GetServiceVersionsRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetServiceVersionsRequestMultiError) AllErrors() []error { return m }
+func (m PageLinksMultiError) AllErrors() []error { return m }
 
-// GetServiceVersionsRequestValidationError is the validation error returned by
-// GetServiceVersionsRequest.Validate if the designated constraints aren't met.
-type GetServiceVersionsRequestValidationError struct {
+// PageLinksValidationError is the validation error returned by
+// PageLinks.Validate if the designated constraints aren't met.
+type PageLinksValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1013,24 +1020,22 @@ type GetServiceVersionsRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetServiceVersionsRequestValidationError) Field() string { return e.field }
+func (e PageLinksValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetServiceVersionsRequestValidationError) Reason() string { return e.reason }
+func (e PageLinksValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetServiceVersionsRequestValidationError) Cause() error { return e.cause }
+func (e PageLinksValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetServiceVersionsRequestValidationError) Key() bool { return e.key }
+func (e PageLinksValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetServiceVersionsRequestValidationError) ErrorName() string {
-	return "GetServiceVersionsRequestValidationError"
-}
+func (e PageLinksValidationError) ErrorName() string { return "PageLinksValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetServiceVersionsRequestValidationError) Error() string {
+func (e PageLinksValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1042,14 +1047,14 @@ func (e GetServiceVersionsRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetServiceVersionsRequest.%s: %s%s",
+		"invalid %sPageLinks.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetServiceVersionsRequestValidationError{}
+var _ error = PageLinksValidationError{}
 
 var _ interface {
 	Field() string
@@ -1057,79 +1062,56 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetServiceVersionsRequestValidationError{}
+} = PageLinksValidationError{}
 
-// Validate checks the field values on GetServiceVersionsResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetServiceVersionsResponse) Validate() error {
+// Validate checks the field values on GetServiceRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetServiceVersionsResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on GetServiceRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetServiceVersionsResponseMultiError, or nil if none found.
-func (m *GetServiceVersionsResponse) ValidateAll() error {
+// GetServiceRequestMultiError, or nil if none found.
+func (m *GetServiceRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetServiceVersionsResponse) validate(all bool) error {
+func (m *GetServiceRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetVersions() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GetServiceVersionsResponseValidationError{
-						field:  fmt.Sprintf("Versions[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, GetServiceVersionsResponseValidationError{
-						field:  fmt.Sprintf("Versions[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return GetServiceVersionsResponseValidationError{
-					field:  fmt.Sprintf("Versions[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := GetServiceRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
 		}
-
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if len(errors) > 0 {
-		return GetServiceVersionsResponseMultiError(errors)
+		return GetServiceRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetServiceVersionsResponseMultiError is an error wrapping multiple
-// validation errors returned by GetServiceVersionsResponse.ValidateAll() if
-// the designated constraints aren't met.
-type GetServiceVersionsResponseMultiError []error
+// GetServiceRequestMultiError is an error wrapping multiple validation errors
+// returned by GetServiceRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetServiceRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetServiceVersionsResponseMultiError) Error() string {
-	var msgs []string
+func (m GetServiceRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
@@ -1137,11 +1119,11 @@ func (m GetServiceVersionsResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetServiceVersionsResponseMultiError) AllErrors() []error { return m }
+func (m GetServiceRequestMultiError) AllErrors() []error { return m }
 
-// GetServiceVersionsResponseValidationError is the validation error returned
-// by GetServiceVersionsResponse.Validate if the designated constraints aren't met.
-type GetServiceVersionsResponseValidationError struct {
+// GetServiceRequestValidationError is the validation error returned by
+// GetServiceRequest.Validate if the designated constraints aren't met.
+type GetServiceRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1149,24 +1131,5139 @@ type GetServiceVersionsResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetServiceVersionsResponseValidationError) Field() string { return e.field }
+func (e GetServiceRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetServiceVersionsResponseValidationError) Reason() string { return e.reason }
+func (e GetServiceRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetServiceVersionsResponseValidationError) Cause() error { return e.cause }
+func (e GetServiceRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceRequestValidationError) ErrorName() string {
+	return "GetServiceRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceRequestValidationError{}
+
+// Validate checks the field values on GetServiceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetServiceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetServiceResponseMultiError, or nil if none found.
+func (m *GetServiceResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetServiceResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetService()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetServiceResponseValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetServiceResponseValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetService()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetServiceResponseValidationError{
+				field:  "Service",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetServiceResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetServiceResponseMultiError is an error wrapping multiple validation errors
+// returned by GetServiceResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetServiceResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetServiceResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetServiceResponseMultiError) AllErrors() []error { return m }
+
+// GetServiceResponseValidationError is the validation error returned by
+// GetServiceResponse.Validate if the designated constraints aren't met.
+type GetServiceResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetServiceResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetServiceResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetServiceResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceResponseValidationError) ErrorName() string {
+	return "GetServiceResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceResponseValidationError{}
+
+// Validate checks the field values on BatchGetServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetServicesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetServicesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetServicesRequestMultiError, or nil if none found.
+func (m *BatchGetServicesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetServicesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return BatchGetServicesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetServicesRequestMultiError is an error wrapping multiple validation
+// errors returned by BatchGetServicesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetServicesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetServicesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetServicesRequestMultiError) AllErrors() []error { return m }
+
+// BatchGetServicesRequestValidationError is the validation error returned by
+// BatchGetServicesRequest.Validate if the designated constraints aren't met.
+type BatchGetServicesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetServicesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetServicesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetServicesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetServicesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetServicesRequestValidationError) ErrorName() string {
+	return "BatchGetServicesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetServicesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetServicesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetServicesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetServicesRequestValidationError{}
+
+// Validate checks the field values on BatchGetServicesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetServicesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetServicesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetServicesResponseMultiError, or nil if none found.
+func (m *BatchGetServicesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetServicesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetServices() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetServicesResponseValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetServicesResponseValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetServicesResponseValidationError{
+					field:  fmt.Sprintf("Services[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetErrors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetServicesResponseValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetServicesResponseValidationError{
+						field:  fmt.Sprintf("Errors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetServicesResponseValidationError{
+					field:  fmt.Sprintf("Errors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetServicesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetServicesResponseMultiError is an error wrapping multiple validation
+// errors returned by BatchGetServicesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetServicesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetServicesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetServicesResponseMultiError) AllErrors() []error { return m }
+
+// BatchGetServicesResponseValidationError is the validation error returned by
+// BatchGetServicesResponse.Validate if the designated constraints aren't met.
+type BatchGetServicesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetServicesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetServicesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetServicesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetServicesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetServicesResponseValidationError) ErrorName() string {
+	return "BatchGetServicesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetServicesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetServicesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetServicesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetServicesResponseValidationError{}
+
+// Validate checks the field values on BatchGetServiceError with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetServiceError) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetServiceError with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// BatchGetServiceErrorMultiError, or nil if none found.
+func (m *BatchGetServiceError) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetServiceError) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for ErrorCode
+
+	// no validation rules for ErrorReason
+
+	// no validation rules for ErrorMessage
+
+	if len(errors) > 0 {
+		return BatchGetServiceErrorMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetServiceErrorMultiError is an error wrapping multiple validation
+// errors returned by BatchGetServiceError.ValidateAll() if the designated
+// constraints aren't met.
+type BatchGetServiceErrorMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetServiceErrorMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetServiceErrorMultiError) AllErrors() []error { return m }
+
+// BatchGetServiceErrorValidationError is the validation error returned by
+// BatchGetServiceError.Validate if the designated constraints aren't met.
+type BatchGetServiceErrorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetServiceErrorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetServiceErrorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetServiceErrorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetServiceErrorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetServiceErrorValidationError) ErrorName() string {
+	return "BatchGetServiceErrorValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetServiceErrorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetServiceError.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetServiceErrorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetServiceErrorValidationError{}
+
+// Validate checks the field values on ListServicesDeltaRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ListServicesDeltaRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListServicesDeltaRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListServicesDeltaRequestMultiError, or nil if none found.
+func (m *ListServicesDeltaRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListServicesDeltaRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SinceToken
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListServicesDeltaRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	if len(errors) > 0 {
+		return ListServicesDeltaRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListServicesDeltaRequestMultiError is an error wrapping multiple validation
+// errors returned by ListServicesDeltaRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListServicesDeltaRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListServicesDeltaRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListServicesDeltaRequestMultiError) AllErrors() []error { return m }
+
+// ListServicesDeltaRequestValidationError is the validation error returned by
+// ListServicesDeltaRequest.Validate if the designated constraints aren't met.
+type ListServicesDeltaRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListServicesDeltaRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListServicesDeltaRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListServicesDeltaRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListServicesDeltaRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListServicesDeltaRequestValidationError) ErrorName() string {
+	return "ListServicesDeltaRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListServicesDeltaRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListServicesDeltaRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListServicesDeltaRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListServicesDeltaRequestValidationError{}
+
+// Validate checks the field values on ServiceTombstone with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ServiceTombstone) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceTombstone with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ServiceTombstoneMultiError, or nil if none found.
+func (m *ServiceTombstone) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceTombstone) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServiceTombstoneValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServiceTombstoneValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServiceTombstoneValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ServiceTombstoneMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceTombstoneMultiError is an error wrapping multiple validation errors
+// returned by ServiceTombstone.ValidateAll() if the designated constraints
+// aren't met.
+type ServiceTombstoneMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceTombstoneMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceTombstoneMultiError) AllErrors() []error { return m }
+
+// ServiceTombstoneValidationError is the validation error returned by
+// ServiceTombstone.Validate if the designated constraints aren't met.
+type ServiceTombstoneValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceTombstoneValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceTombstoneValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceTombstoneValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceTombstoneValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceTombstoneValidationError) ErrorName() string {
+	return "ServiceTombstoneValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServiceTombstoneValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceTombstone.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceTombstoneValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceTombstoneValidationError{}
+
+// Validate checks the field values on ListServicesDeltaResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ListServicesDeltaResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListServicesDeltaResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListServicesDeltaResponseMultiError, or nil if none found.
+func (m *ListServicesDeltaResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListServicesDeltaResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetServices() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListServicesDeltaResponseValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListServicesDeltaResponseValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListServicesDeltaResponseValidationError{
+					field:  fmt.Sprintf("Services[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetDeletedServices() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListServicesDeltaResponseValidationError{
+						field:  fmt.Sprintf("DeletedServices[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListServicesDeltaResponseValidationError{
+						field:  fmt.Sprintf("DeletedServices[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListServicesDeltaResponseValidationError{
+					field:  fmt.Sprintf("DeletedServices[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextToken
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListServicesDeltaResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListServicesDeltaResponseMultiError is an error wrapping multiple validation
+// errors returned by ListServicesDeltaResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListServicesDeltaResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListServicesDeltaResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListServicesDeltaResponseMultiError) AllErrors() []error { return m }
+
+// ListServicesDeltaResponseValidationError is the validation error returned by
+// ListServicesDeltaResponse.Validate if the designated constraints aren't met.
+type ListServicesDeltaResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListServicesDeltaResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListServicesDeltaResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListServicesDeltaResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListServicesDeltaResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListServicesDeltaResponseValidationError) ErrorName() string {
+	return "ListServicesDeltaResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListServicesDeltaResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListServicesDeltaResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListServicesDeltaResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListServicesDeltaResponseValidationError{}
+
+// Validate checks the field values on GetServiceVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetServiceVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetServiceVersionsRequestMultiError, or nil if none found.
+func (m *GetServiceVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetServiceVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetServiceId()) < 1 {
+		err := GetServiceVersionsRequestValidationError{
+			field:  "ServiceId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetServiceVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetServiceVersionsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetServiceVersionsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type GetServiceVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetServiceVersionsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetServiceVersionsRequestMultiError) AllErrors() []error { return m }
+
+// GetServiceVersionsRequestValidationError is the validation error returned by
+// GetServiceVersionsRequest.Validate if the designated constraints aren't met.
+type GetServiceVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetServiceVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetServiceVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetServiceVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceVersionsRequestValidationError) ErrorName() string {
+	return "GetServiceVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceVersionsRequestValidationError{}
+
+// Validate checks the field values on GetServiceVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetServiceVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetServiceVersionsResponseMultiError, or nil if none found.
+func (m *GetServiceVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetServiceVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetServiceVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetServiceVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetServiceVersionsResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetServiceVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetServiceVersionsResponseMultiError is an error wrapping multiple
+// validation errors returned by GetServiceVersionsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetServiceVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetServiceVersionsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetServiceVersionsResponseMultiError) AllErrors() []error { return m }
+
+// GetServiceVersionsResponseValidationError is the validation error returned
+// by GetServiceVersionsResponse.Validate if the designated constraints aren't met.
+type GetServiceVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetServiceVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetServiceVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetServiceVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceVersionsResponseValidationError) ErrorName() string {
+	return "GetServiceVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceVersionsResponseValidationError{}
+
+// Validate checks the field values on StreamServiceVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StreamServiceVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StreamServiceVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StreamServiceVersionsRequestMultiError, or nil if none found.
+func (m *StreamServiceVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StreamServiceVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetServiceId()) < 1 {
+		err := StreamServiceVersionsRequestValidationError{
+			field:  "ServiceId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAfter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StreamServiceVersionsRequestValidationError{
+					field:  "UpdatedAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StreamServiceVersionsRequestValidationError{
+					field:  "UpdatedAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAfter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StreamServiceVersionsRequestValidationError{
+				field:  "UpdatedAfter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if val := m.GetChunkSize(); val < 0 || val > 1000 {
+		err := StreamServiceVersionsRequestValidationError{
+			field:  "ChunkSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.IsActive != nil {
+		// no validation rules for IsActive
+	}
+
+	if len(errors) > 0 {
+		return StreamServiceVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StreamServiceVersionsRequestMultiError is an error wrapping multiple
+// validation errors returned by StreamServiceVersionsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type StreamServiceVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StreamServiceVersionsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StreamServiceVersionsRequestMultiError) AllErrors() []error { return m }
+
+// StreamServiceVersionsRequestValidationError is the validation error returned
+// by StreamServiceVersionsRequest.Validate if the designated constraints
+// aren't met.
+type StreamServiceVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamServiceVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamServiceVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamServiceVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamServiceVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamServiceVersionsRequestValidationError) ErrorName() string {
+	return "StreamServiceVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamServiceVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamServiceVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamServiceVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamServiceVersionsRequestValidationError{}
+
+// Validate checks the field values on StreamServiceVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StreamServiceVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StreamServiceVersionsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// StreamServiceVersionsResponseMultiError, or nil if none found.
+func (m *StreamServiceVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StreamServiceVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, StreamServiceVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, StreamServiceVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return StreamServiceVersionsResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return StreamServiceVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// StreamServiceVersionsResponseMultiError is an error wrapping multiple
+// validation errors returned by StreamServiceVersionsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type StreamServiceVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StreamServiceVersionsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StreamServiceVersionsResponseMultiError) AllErrors() []error { return m }
+
+// StreamServiceVersionsResponseValidationError is the validation error
+// returned by StreamServiceVersionsResponse.Validate if the designated
+// constraints aren't met.
+type StreamServiceVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamServiceVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamServiceVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamServiceVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamServiceVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamServiceVersionsResponseValidationError) ErrorName() string {
+	return "StreamServiceVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamServiceVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamServiceVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamServiceVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamServiceVersionsResponseValidationError{}
+
+// Validate checks the field values on GetServiceHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetServiceHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetServiceHistoryRequestMultiError, or nil if none found.
+func (m *GetServiceHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetServiceHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetServiceId()) < 1 {
+		err := GetServiceHistoryRequestValidationError{
+			field:  "ServiceId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetServiceHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetServiceHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by GetServiceHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetServiceHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetServiceHistoryRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetServiceHistoryRequestMultiError) AllErrors() []error { return m }
+
+// GetServiceHistoryRequestValidationError is the validation error returned by
+// GetServiceHistoryRequest.Validate if the designated constraints aren't met.
+type GetServiceHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetServiceHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetServiceHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetServiceHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceHistoryRequestValidationError) ErrorName() string {
+	return "GetServiceHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceHistoryRequestValidationError{}
+
+// Validate checks the field values on ServiceHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ServiceHistoryEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ServiceHistoryEntryMultiError, or nil if none found.
+func (m *ServiceHistoryEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceHistoryEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetVersion()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServiceHistoryEntryValidationError{
+					field:  "Version",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServiceHistoryEntryValidationError{
+					field:  "Version",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVersion()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServiceHistoryEntryValidationError{
+				field:  "Version",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSincePreviousRelease()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServiceHistoryEntryValidationError{
+					field:  "SincePreviousRelease",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServiceHistoryEntryValidationError{
+					field:  "SincePreviousRelease",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSincePreviousRelease()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServiceHistoryEntryValidationError{
+				field:  "SincePreviousRelease",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ServiceHistoryEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceHistoryEntryMultiError is an error wrapping multiple validation
+// errors returned by ServiceHistoryEntry.ValidateAll() if the designated
+// constraints aren't met.
+type ServiceHistoryEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceHistoryEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceHistoryEntryMultiError) AllErrors() []error { return m }
+
+// ServiceHistoryEntryValidationError is the validation error returned by
+// ServiceHistoryEntry.Validate if the designated constraints aren't met.
+type ServiceHistoryEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceHistoryEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceHistoryEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceHistoryEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceHistoryEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceHistoryEntryValidationError) ErrorName() string {
+	return "ServiceHistoryEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServiceHistoryEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceHistoryEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceHistoryEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceHistoryEntryValidationError{}
+
+// Validate checks the field values on GetServiceHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetServiceHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetServiceHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetServiceHistoryResponseMultiError, or nil if none found.
+func (m *GetServiceHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetServiceHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ServiceId
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetServiceHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetServiceHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetServiceHistoryResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetServiceHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetServiceHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by GetServiceHistoryResponse.ValidateAll() if the
+// designated constraints aren't met.
+type GetServiceHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetServiceHistoryResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetServiceHistoryResponseMultiError) AllErrors() []error { return m }
+
+// GetServiceHistoryResponseValidationError is the validation error returned by
+// GetServiceHistoryResponse.Validate if the designated constraints aren't met.
+type GetServiceHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetServiceHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetServiceHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetServiceHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetServiceHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetServiceHistoryResponseValidationError) ErrorName() string {
+	return "GetServiceHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetServiceHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetServiceHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetServiceHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetServiceHistoryResponseValidationError{}
+
+// Validate checks the field values on ListRecentVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRecentVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRecentVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRecentVersionsRequestMultiError, or nil if none found.
+func (m *ListRecentVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRecentVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListRecentVersionsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAfter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListRecentVersionsRequestValidationError{
+					field:  "UpdatedAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListRecentVersionsRequestValidationError{
+					field:  "UpdatedAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAfter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListRecentVersionsRequestValidationError{
+				field:  "UpdatedAfter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.IsActive != nil {
+		// no validation rules for IsActive
+	}
+
+	if len(errors) > 0 {
+		return ListRecentVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRecentVersionsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListRecentVersionsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ListRecentVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRecentVersionsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRecentVersionsRequestMultiError) AllErrors() []error { return m }
+
+// ListRecentVersionsRequestValidationError is the validation error returned by
+// ListRecentVersionsRequest.Validate if the designated constraints aren't met.
+type ListRecentVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRecentVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRecentVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRecentVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRecentVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRecentVersionsRequestValidationError) ErrorName() string {
+	return "ListRecentVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListRecentVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRecentVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRecentVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRecentVersionsRequestValidationError{}
+
+// Validate checks the field values on ListRecentVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRecentVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRecentVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRecentVersionsResponseMultiError, or nil if none found.
+func (m *ListRecentVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRecentVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListRecentVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListRecentVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListRecentVersionsResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	// no validation rules for TotalCount
+
+	if len(errors) > 0 {
+		return ListRecentVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRecentVersionsResponseMultiError is an error wrapping multiple
+// validation errors returned by ListRecentVersionsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListRecentVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRecentVersionsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRecentVersionsResponseMultiError) AllErrors() []error { return m }
+
+// ListRecentVersionsResponseValidationError is the validation error returned
+// by ListRecentVersionsResponse.Validate if the designated constraints aren't met.
+type ListRecentVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRecentVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRecentVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRecentVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRecentVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRecentVersionsResponseValidationError) ErrorName() string {
+	return "ListRecentVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListRecentVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRecentVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRecentVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRecentVersionsResponseValidationError{}
+
+// Validate checks the field values on SearchVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *SearchVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// SearchVersionsRequestMultiError, or nil if none found.
+func (m *SearchVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := SearchVersionsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	// no validation rules for SearchQuery
+
+	if m.IsActive != nil {
+		// no validation rules for IsActive
+	}
+
+	if len(errors) > 0 {
+		return SearchVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchVersionsRequestMultiError is an error wrapping multiple validation
+// errors returned by SearchVersionsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SearchVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchVersionsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchVersionsRequestMultiError) AllErrors() []error { return m }
+
+// SearchVersionsRequestValidationError is the validation error returned by
+// SearchVersionsRequest.Validate if the designated constraints aren't met.
+type SearchVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchVersionsRequestValidationError) ErrorName() string {
+	return "SearchVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchVersionsRequestValidationError{}
+
+// Validate checks the field values on VersionSearchResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *VersionSearchResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VersionSearchResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VersionSearchResultMultiError, or nil if none found.
+func (m *VersionSearchResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VersionSearchResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ServiceId
+
+	if all {
+		switch v := interface{}(m.GetVersion()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, VersionSearchResultValidationError{
+					field:  "Version",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, VersionSearchResultValidationError{
+					field:  "Version",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVersion()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return VersionSearchResultValidationError{
+				field:  "Version",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return VersionSearchResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// VersionSearchResultMultiError is an error wrapping multiple validation
+// errors returned by VersionSearchResult.ValidateAll() if the designated
+// constraints aren't met.
+type VersionSearchResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VersionSearchResultMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VersionSearchResultMultiError) AllErrors() []error { return m }
+
+// VersionSearchResultValidationError is the validation error returned by
+// VersionSearchResult.Validate if the designated constraints aren't met.
+type VersionSearchResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VersionSearchResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VersionSearchResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VersionSearchResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VersionSearchResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VersionSearchResultValidationError) ErrorName() string {
+	return "VersionSearchResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VersionSearchResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVersionSearchResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VersionSearchResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VersionSearchResultValidationError{}
+
+// Validate checks the field values on SearchVersionsResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *SearchVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchVersionsResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// SearchVersionsResponseMultiError, or nil if none found.
+func (m *SearchVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SearchVersionsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SearchVersionsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SearchVersionsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	// no validation rules for TotalCount
+
+	if len(errors) > 0 {
+		return SearchVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchVersionsResponseMultiError is an error wrapping multiple validation
+// errors returned by SearchVersionsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SearchVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchVersionsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchVersionsResponseMultiError) AllErrors() []error { return m }
+
+// SearchVersionsResponseValidationError is the validation error returned by
+// SearchVersionsResponse.Validate if the designated constraints aren't met.
+type SearchVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchVersionsResponseValidationError) ErrorName() string {
+	return "SearchVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchVersionsResponseValidationError{}
+
+// Validate checks the field values on DescribeCatalogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DescribeCatalogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DescribeCatalogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DescribeCatalogRequestMultiError, or nil if none found.
+func (m *DescribeCatalogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DescribeCatalogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DescribeCatalogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DescribeCatalogRequestMultiError is an error wrapping multiple validation
+// errors returned by DescribeCatalogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DescribeCatalogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DescribeCatalogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DescribeCatalogRequestMultiError) AllErrors() []error { return m }
+
+// DescribeCatalogRequestValidationError is the validation error returned by
+// DescribeCatalogRequest.Validate if the designated constraints aren't met.
+type DescribeCatalogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DescribeCatalogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DescribeCatalogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DescribeCatalogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DescribeCatalogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DescribeCatalogRequestValidationError) ErrorName() string {
+	return "DescribeCatalogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DescribeCatalogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDescribeCatalogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DescribeCatalogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DescribeCatalogRequestValidationError{}
+
+// Validate checks the field values on OrganizationServiceCount with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *OrganizationServiceCount) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OrganizationServiceCount with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// OrganizationServiceCountMultiError, or nil if none found.
+func (m *OrganizationServiceCount) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OrganizationServiceCount) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for OrganizationId
+
+	// no validation rules for ServiceCount
+
+	if len(errors) > 0 {
+		return OrganizationServiceCountMultiError(errors)
+	}
+
+	return nil
+}
+
+// OrganizationServiceCountMultiError is an error wrapping multiple validation
+// errors returned by OrganizationServiceCount.ValidateAll() if the designated
+// constraints aren't met.
+type OrganizationServiceCountMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OrganizationServiceCountMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OrganizationServiceCountMultiError) AllErrors() []error { return m }
+
+// OrganizationServiceCountValidationError is the validation error returned by
+// OrganizationServiceCount.Validate if the designated constraints aren't met.
+type OrganizationServiceCountValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OrganizationServiceCountValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OrganizationServiceCountValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OrganizationServiceCountValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OrganizationServiceCountValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OrganizationServiceCountValidationError) ErrorName() string {
+	return "OrganizationServiceCountValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e OrganizationServiceCountValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOrganizationServiceCount.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OrganizationServiceCountValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OrganizationServiceCountValidationError{}
+
+// Validate checks the field values on DescribeCatalogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DescribeCatalogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DescribeCatalogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DescribeCatalogResponseMultiError, or nil if none found.
+func (m *DescribeCatalogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DescribeCatalogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TotalServices
+
+	// no validation rules for TotalVersions
+
+	// no validation rules for ActiveVersions
+
+	for idx, item := range m.GetServicesPerOrganization() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DescribeCatalogResponseValidationError{
+						field:  fmt.Sprintf("ServicesPerOrganization[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DescribeCatalogResponseValidationError{
+						field:  fmt.Sprintf("ServicesPerOrganization[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DescribeCatalogResponseValidationError{
+					field:  fmt.Sprintf("ServicesPerOrganization[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetNewestService()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DescribeCatalogResponseValidationError{
+					field:  "NewestService",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DescribeCatalogResponseValidationError{
+					field:  "NewestService",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetNewestService()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DescribeCatalogResponseValidationError{
+				field:  "NewestService",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetOldestService()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DescribeCatalogResponseValidationError{
+					field:  "OldestService",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DescribeCatalogResponseValidationError{
+					field:  "OldestService",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOldestService()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DescribeCatalogResponseValidationError{
+				field:  "OldestService",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DescribeCatalogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DescribeCatalogResponseMultiError is an error wrapping multiple validation
+// errors returned by DescribeCatalogResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DescribeCatalogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DescribeCatalogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DescribeCatalogResponseMultiError) AllErrors() []error { return m }
+
+// DescribeCatalogResponseValidationError is the validation error returned by
+// DescribeCatalogResponse.Validate if the designated constraints aren't met.
+type DescribeCatalogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DescribeCatalogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DescribeCatalogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DescribeCatalogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DescribeCatalogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DescribeCatalogResponseValidationError) ErrorName() string {
+	return "DescribeCatalogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DescribeCatalogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDescribeCatalogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DescribeCatalogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DescribeCatalogResponseValidationError{}
+
+// Validate checks the field values on ListOrganizationsRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ListOrganizationsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListOrganizationsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListOrganizationsRequestMultiError, or nil if none found.
+func (m *ListOrganizationsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListOrganizationsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListOrganizationsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListOrganizationsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListOrganizationsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListOrganizationsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListOrganizationsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListOrganizationsRequestMultiError) AllErrors() []error { return m }
+
+// ListOrganizationsRequestValidationError is the validation error returned by
+// ListOrganizationsRequest.Validate if the designated constraints aren't met.
+type ListOrganizationsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListOrganizationsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListOrganizationsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListOrganizationsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListOrganizationsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListOrganizationsRequestValidationError) ErrorName() string {
+	return "ListOrganizationsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListOrganizationsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListOrganizationsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListOrganizationsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListOrganizationsRequestValidationError{}
+
+// Validate checks the field values on Organization with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Organization) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Organization with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in OrganizationMultiError, or
+// nil if none found.
+func (m *Organization) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Organization) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for DisplayName
+
+	// no validation rules for ServiceCount
+
+	if len(errors) > 0 {
+		return OrganizationMultiError(errors)
+	}
+
+	return nil
+}
+
+// OrganizationMultiError is an error wrapping multiple validation errors
+// returned by Organization.ValidateAll() if the designated constraints aren't
+// met.
+type OrganizationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OrganizationMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OrganizationMultiError) AllErrors() []error { return m }
+
+// OrganizationValidationError is the validation error returned by
+// Organization.Validate if the designated constraints aren't met.
+type OrganizationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OrganizationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OrganizationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OrganizationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OrganizationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OrganizationValidationError) ErrorName() string {
+	return "OrganizationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e OrganizationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOrganization.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OrganizationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OrganizationValidationError{}
+
+// Validate checks the field values on ListOrganizationsResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ListOrganizationsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListOrganizationsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListOrganizationsResponseMultiError, or nil if none found.
+func (m *ListOrganizationsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListOrganizationsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetOrganizations() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListOrganizationsResponseValidationError{
+						field:  fmt.Sprintf("Organizations[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListOrganizationsResponseValidationError{
+						field:  fmt.Sprintf("Organizations[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListOrganizationsResponseValidationError{
+					field:  fmt.Sprintf("Organizations[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListOrganizationsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListOrganizationsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListOrganizationsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListOrganizationsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListOrganizationsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListOrganizationsResponseMultiError) AllErrors() []error { return m }
+
+// ListOrganizationsResponseValidationError is the validation error returned by
+// ListOrganizationsResponse.Validate if the designated constraints aren't met.
+type ListOrganizationsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListOrganizationsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListOrganizationsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListOrganizationsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListOrganizationsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListOrganizationsResponseValidationError) ErrorName() string {
+	return "ListOrganizationsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListOrganizationsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListOrganizationsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListOrganizationsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListOrganizationsResponseValidationError{}
+
+// Validate checks the field values on ActivateVersionAcrossServicesRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *ActivateVersionAcrossServicesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivateVersionAcrossServicesRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ActivateVersionAcrossServicesRequestMultiError, or nil if none found.
+func (m *ActivateVersionAcrossServicesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivateVersionAcrossServicesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetVersion()) < 1 {
+		err := ActivateVersionAcrossServicesRequestValidationError{
+			field:  "Version",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ActivateVersionAcrossServicesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivateVersionAcrossServicesRequestMultiError is an error wrapping multiple
+// validation errors returned by
+// ActivateVersionAcrossServicesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ActivateVersionAcrossServicesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivateVersionAcrossServicesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivateVersionAcrossServicesRequestMultiError) AllErrors() []error { return m }
+
+// ActivateVersionAcrossServicesRequestValidationError is the validation error
+// returned by ActivateVersionAcrossServicesRequest.Validate if the designated
+// constraints aren't met.
+type ActivateVersionAcrossServicesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivateVersionAcrossServicesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivateVersionAcrossServicesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivateVersionAcrossServicesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivateVersionAcrossServicesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivateVersionAcrossServicesRequestValidationError) ErrorName() string {
+	return "ActivateVersionAcrossServicesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ActivateVersionAcrossServicesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivateVersionAcrossServicesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivateVersionAcrossServicesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivateVersionAcrossServicesRequestValidationError{}
+
+// Validate checks the field values on ActivateVersionAcrossServicesResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *ActivateVersionAcrossServicesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivateVersionAcrossServicesResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ActivateVersionAcrossServicesResponseMultiError, or nil if none found.
+func (m *ActivateVersionAcrossServicesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivateVersionAcrossServicesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ActivateVersionAcrossServicesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivateVersionAcrossServicesResponseMultiError is an error wrapping
+// multiple validation errors returned by
+// ActivateVersionAcrossServicesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ActivateVersionAcrossServicesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivateVersionAcrossServicesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivateVersionAcrossServicesResponseMultiError) AllErrors() []error { return m }
+
+// ActivateVersionAcrossServicesResponseValidationError is the validation error
+// returned by ActivateVersionAcrossServicesResponse.Validate if the
+// designated constraints aren't met.
+type ActivateVersionAcrossServicesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivateVersionAcrossServicesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivateVersionAcrossServicesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivateVersionAcrossServicesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivateVersionAcrossServicesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivateVersionAcrossServicesResponseValidationError) ErrorName() string {
+	return "ActivateVersionAcrossServicesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ActivateVersionAcrossServicesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivateVersionAcrossServicesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivateVersionAcrossServicesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivateVersionAcrossServicesResponseValidationError{}
+
+// Validate checks the field values on DiffServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DiffServicesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DiffServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DiffServicesRequestMultiError, or nil if none found.
+func (m *DiffServicesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DiffServicesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetServiceId()) < 1 {
+		err := DiffServicesRequestValidationError{
+			field:  "ServiceId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OtherServiceId
+
+	// no validation rules for BaseVersionId
+
+	// no validation rules for TargetVersionId
+
+	if len(errors) > 0 {
+		return DiffServicesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DiffServicesRequestMultiError is an error wrapping multiple validation
+// errors returned by DiffServicesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DiffServicesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DiffServicesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DiffServicesRequestMultiError) AllErrors() []error { return m }
+
+// DiffServicesRequestValidationError is the validation error returned by
+// DiffServicesRequest.Validate if the designated constraints aren't met.
+type DiffServicesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DiffServicesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DiffServicesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DiffServicesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DiffServicesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DiffServicesRequestValidationError) ErrorName() string {
+	return "DiffServicesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DiffServicesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDiffServicesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DiffServicesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DiffServicesRequestValidationError{}
+
+// Validate checks the field values on FieldDiff with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FieldDiff) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FieldDiff with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FieldDiffMultiError, or nil
+// if none found.
+func (m *FieldDiff) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FieldDiff) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Field
+
+	// no validation rules for Base
+
+	// no validation rules for Target
+
+	if len(errors) > 0 {
+		return FieldDiffMultiError(errors)
+	}
+
+	return nil
+}
+
+// FieldDiffMultiError is an error wrapping multiple validation errors returned
+// by FieldDiff.ValidateAll() if the designated constraints aren't met.
+type FieldDiffMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FieldDiffMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FieldDiffMultiError) AllErrors() []error { return m }
+
+// FieldDiffValidationError is the validation error returned by
+// FieldDiff.Validate if the designated constraints aren't met.
+type FieldDiffValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FieldDiffValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FieldDiffValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FieldDiffValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FieldDiffValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FieldDiffValidationError) ErrorName() string { return "FieldDiffValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FieldDiffValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFieldDiff.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FieldDiffValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FieldDiffValidationError{}
+
+// Validate checks the field values on DiffServicesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DiffServicesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DiffServicesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DiffServicesResponseMultiError, or nil if none found.
+func (m *DiffServicesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DiffServicesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetDifferences() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DiffServicesResponseValidationError{
+						field:  fmt.Sprintf("Differences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DiffServicesResponseValidationError{
+						field:  fmt.Sprintf("Differences[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DiffServicesResponseValidationError{
+					field:  fmt.Sprintf("Differences[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return DiffServicesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DiffServicesResponseMultiError is an error wrapping multiple validation
+// errors returned by DiffServicesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DiffServicesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DiffServicesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DiffServicesResponseMultiError) AllErrors() []error { return m }
+
+// DiffServicesResponseValidationError is the validation error returned by
+// DiffServicesResponse.Validate if the designated constraints aren't met.
+type DiffServicesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DiffServicesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DiffServicesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DiffServicesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DiffServicesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DiffServicesResponseValidationError) ErrorName() string {
+	return "DiffServicesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DiffServicesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDiffServicesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DiffServicesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DiffServicesResponseValidationError{}
+
+// Validate checks the field values on TouchServiceRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TouchServiceRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TouchServiceRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TouchServiceRequestMultiError, or nil if none found.
+func (m *TouchServiceRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TouchServiceRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := TouchServiceRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return TouchServiceRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// TouchServiceRequestMultiError is an error wrapping multiple validation
+// errors returned by TouchServiceRequest.ValidateAll() if the designated
+// constraints aren't met.
+type TouchServiceRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TouchServiceRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TouchServiceRequestMultiError) AllErrors() []error { return m }
+
+// TouchServiceRequestValidationError is the validation error returned by
+// TouchServiceRequest.Validate if the designated constraints aren't met.
+type TouchServiceRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TouchServiceRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TouchServiceRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TouchServiceRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TouchServiceRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TouchServiceRequestValidationError) ErrorName() string {
+	return "TouchServiceRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TouchServiceRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTouchServiceRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TouchServiceRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TouchServiceRequestValidationError{}
+
+// Validate checks the field values on TouchServiceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TouchServiceResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TouchServiceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TouchServiceResponseMultiError, or nil if none found.
+func (m *TouchServiceResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TouchServiceResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetService()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TouchServiceResponseValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TouchServiceResponseValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetService()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TouchServiceResponseValidationError{
+				field:  "Service",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return TouchServiceResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// TouchServiceResponseMultiError is an error wrapping multiple validation
+// errors returned by TouchServiceResponse.ValidateAll() if the designated
+// constraints aren't met.
+type TouchServiceResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TouchServiceResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TouchServiceResponseMultiError) AllErrors() []error { return m }
+
+// TouchServiceResponseValidationError is the validation error returned by
+// TouchServiceResponse.Validate if the designated constraints aren't met.
+type TouchServiceResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TouchServiceResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TouchServiceResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TouchServiceResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TouchServiceResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TouchServiceResponseValidationError) ErrorName() string {
+	return "TouchServiceResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TouchServiceResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTouchServiceResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TouchServiceResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TouchServiceResponseValidationError{}
+
+// Validate checks the field values on CreateServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServicesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServicesRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CreateServicesRequestMultiError, or nil if none found.
+func (m *CreateServicesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServicesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetServices() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CreateServicesRequestValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CreateServicesRequestValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CreateServicesRequestValidationError{
+					field:  fmt.Sprintf("Services[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Transactional
+
+	// no validation rules for IdempotencyKey
+
+	if len(errors) > 0 {
+		return CreateServicesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServicesRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateServicesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateServicesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServicesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServicesRequestMultiError) AllErrors() []error { return m }
+
+// CreateServicesRequestValidationError is the validation error returned by
+// CreateServicesRequest.Validate if the designated constraints aren't met.
+type CreateServicesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServicesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServicesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServicesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServicesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServicesRequestValidationError) ErrorName() string {
+	return "CreateServicesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServicesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServicesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServicesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServicesRequestValidationError{}
+
+// Validate checks the field values on CreateServiceResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServiceResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServiceResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CreateServiceResultMultiError, or nil if none found.
+func (m *CreateServiceResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServiceResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	// no validation rules for Id
+
+	// no validation rules for ErrorCode
+
+	// no validation rules for ErrorReason
+
+	// no validation rules for ErrorMessage
+
+	if len(errors) > 0 {
+		return CreateServiceResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServiceResultMultiError is an error wrapping multiple validation
+// errors returned by CreateServiceResult.ValidateAll() if the designated
+// constraints aren't met.
+type CreateServiceResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServiceResultMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServiceResultMultiError) AllErrors() []error { return m }
+
+// CreateServiceResultValidationError is the validation error returned by
+// CreateServiceResult.Validate if the designated constraints aren't met.
+type CreateServiceResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServiceResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServiceResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServiceResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServiceResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServiceResultValidationError) ErrorName() string {
+	return "CreateServiceResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServiceResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServiceResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServiceResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServiceResultValidationError{}
+
+// Validate checks the field values on CreateServicesResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServicesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServicesResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CreateServicesResponseMultiError, or nil if none found.
+func (m *CreateServicesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServicesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CreateServicesResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CreateServicesResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CreateServicesResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for CreatedCount
+
+	// no validation rules for FailedCount
+
+	if len(errors) > 0 {
+		return CreateServicesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServicesResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateServicesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateServicesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServicesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServicesResponseMultiError) AllErrors() []error { return m }
+
+// CreateServicesResponseValidationError is the validation error returned by
+// CreateServicesResponse.Validate if the designated constraints aren't met.
+type CreateServicesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServicesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServicesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServicesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServicesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServicesResponseValidationError) ErrorName() string {
+	return "CreateServicesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServicesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServicesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServicesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServicesResponseValidationError{}
+
+// Validate checks the field values on AuditEvent with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AuditEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuditEventMultiError, or
+// nil if none found.
+func (m *AuditEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AuditEventValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AuditEventValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AuditEventValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Actor
+
+	// no validation rules for Action
+
+	// no validation rules for ServiceId
+
+	// no validation rules for Details
+
+	if len(errors) > 0 {
+		return AuditEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditEventMultiError is an error wrapping multiple validation errors
+// returned by AuditEvent.ValidateAll() if the designated constraints aren't met.
+type AuditEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditEventMultiError) AllErrors() []error { return m }
+
+// AuditEventValidationError is the validation error returned by
+// AuditEvent.Validate if the designated constraints aren't met.
+type AuditEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditEventValidationError) ErrorName() string { return "AuditEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditEventValidationError{}
+
+// Validate checks the field values on ListAuditEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditEventsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditEventsRequestMultiError, or nil if none found.
+func (m *ListAuditEventsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditEventsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListAuditEventsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	// no validation rules for Actor
+
+	// no validation rules for ServiceId
+
+	// no validation rules for Action
+
+	if all {
+		switch v := interface{}(m.GetStartTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListAuditEventsRequestValidationError{
+				field:  "StartTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListAuditEventsRequestValidationError{
+				field:  "EndTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListAuditEventsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAuditEventsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListAuditEventsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListAuditEventsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditEventsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditEventsRequestMultiError) AllErrors() []error { return m }
+
+// ListAuditEventsRequestValidationError is the validation error returned by
+// ListAuditEventsRequest.Validate if the designated constraints aren't met.
+type ListAuditEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditEventsRequestValidationError) ErrorName() string {
+	return "ListAuditEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAuditEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditEventsRequestValidationError{}
+
+// Validate checks the field values on ListAuditEventsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditEventsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditEventsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditEventsResponseMultiError, or nil if none found.
+func (m *ListAuditEventsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditEventsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEvents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAuditEventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAuditEventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAuditEventsResponseValidationError{
+					field:  fmt.Sprintf("Events[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	// no validation rules for TotalCount
+
+	if len(errors) > 0 {
+		return ListAuditEventsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAuditEventsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListAuditEventsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAuditEventsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditEventsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditEventsResponseMultiError) AllErrors() []error { return m }
+
+// ListAuditEventsResponseValidationError is the validation error returned by
+// ListAuditEventsResponse.Validate if the designated constraints aren't met.
+type ListAuditEventsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditEventsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditEventsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditEventsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditEventsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditEventsResponseValidationError) ErrorName() string {
+	return "ListAuditEventsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAuditEventsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditEventsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditEventsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditEventsResponseValidationError{}
+
+// Validate checks the field values on ValidateCatalogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ValidateCatalogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ValidateCatalogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ValidateCatalogRequestMultiError, or nil if none found.
+func (m *ValidateCatalogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ValidateCatalogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetContent()) < 1 {
+		err := ValidateCatalogRequestValidationError{
+			field:  "Content",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ValidateCatalogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidateCatalogRequestMultiError is an error wrapping multiple validation
+// errors returned by ValidateCatalogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ValidateCatalogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidateCatalogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidateCatalogRequestMultiError) AllErrors() []error { return m }
+
+// ValidateCatalogRequestValidationError is the validation error returned by
+// ValidateCatalogRequest.Validate if the designated constraints aren't met.
+type ValidateCatalogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidateCatalogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidateCatalogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidateCatalogRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetServiceVersionsResponseValidationError) Key() bool { return e.key }
+func (e ValidateCatalogRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetServiceVersionsResponseValidationError) ErrorName() string {
-	return "GetServiceVersionsResponseValidationError"
+func (e ValidateCatalogRequestValidationError) ErrorName() string {
+	return "ValidateCatalogRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetServiceVersionsResponseValidationError) Error() string {
+func (e ValidateCatalogRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1178,14 +6275,14 @@ func (e GetServiceVersionsResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetServiceVersionsResponse.%s: %s%s",
+		"invalid %sValidateCatalogRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetServiceVersionsResponseValidationError{}
+var _ error = ValidateCatalogRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1193,4 +6290,256 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetServiceVersionsResponseValidationError{}
+} = ValidateCatalogRequestValidationError{}
+
+// Validate checks the field values on ValidationIssue with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ValidationIssue) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ValidationIssue with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ValidationIssueMultiError, or nil if none found.
+func (m *ValidationIssue) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ValidationIssue) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Severity
+
+	// no validation rules for Message
+
+	// no validation rules for Line
+
+	// no validation rules for ServiceId
+
+	// no validation rules for VersionId
+
+	// no validation rules for Field
+
+	if len(errors) > 0 {
+		return ValidationIssueMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidationIssueMultiError is an error wrapping multiple validation errors
+// returned by ValidationIssue.ValidateAll() if the designated constraints
+// aren't met.
+type ValidationIssueMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidationIssueMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidationIssueMultiError) AllErrors() []error { return m }
+
+// ValidationIssueValidationError is the validation error returned by
+// ValidationIssue.Validate if the designated constraints aren't met.
+type ValidationIssueValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidationIssueValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidationIssueValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidationIssueValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidationIssueValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidationIssueValidationError) ErrorName() string { return "ValidationIssueValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ValidationIssueValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidationIssue.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidationIssueValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidationIssueValidationError{}
+
+// Validate checks the field values on ValidateCatalogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ValidateCatalogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ValidateCatalogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ValidateCatalogResponseMultiError, or nil if none found.
+func (m *ValidateCatalogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ValidateCatalogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Valid
+
+	for idx, item := range m.GetIssues() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValidateCatalogResponseValidationError{
+						field:  fmt.Sprintf("Issues[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValidateCatalogResponseValidationError{
+						field:  fmt.Sprintf("Issues[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValidateCatalogResponseValidationError{
+					field:  fmt.Sprintf("Issues[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for ServiceCount
+
+	if len(errors) > 0 {
+		return ValidateCatalogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidateCatalogResponseMultiError is an error wrapping multiple validation
+// errors returned by ValidateCatalogResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ValidateCatalogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidateCatalogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidateCatalogResponseMultiError) AllErrors() []error { return m }
+
+// ValidateCatalogResponseValidationError is the validation error returned by
+// ValidateCatalogResponse.Validate if the designated constraints aren't met.
+type ValidateCatalogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidateCatalogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidateCatalogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidateCatalogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidateCatalogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidateCatalogResponseValidationError) ErrorName() string {
+	return "ValidateCatalogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ValidateCatalogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidateCatalogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidateCatalogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidateCatalogResponseValidationError{}
@@ -24,10 +24,42 @@ const _ = grpc.SupportPackageIsVersion7
 type CatalogServiceClient interface {
 	// ListServices returns a list of services with filtering, sorting, and pagination
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	// CountServices returns how many services match the ListServices filters, without returning them
+	CountServices(ctx context.Context, in *CountServicesRequest, opts ...grpc.CallOption) (*CountServicesResponse, error)
 	// GetService returns details for a single service
 	GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error)
+	// BatchGetServices returns several services by ID in one call, listing the IDs that were not found.
+	// Over HTTP the IDs may be comma-separated: /v1/services:batchGet?ids=svc-1,svc-2
+	BatchGetServices(ctx context.Context, in *BatchGetServicesRequest, opts ...grpc.CallOption) (*BatchGetServicesResponse, error)
+	// ListServicesDelta returns the services changed since a token from an earlier call, for clients syncing a cached catalog
+	ListServicesDelta(ctx context.Context, in *ListServicesDeltaRequest, opts ...grpc.CallOption) (*ListServicesDeltaResponse, error)
 	// GetServiceVersions returns all versions of a service
 	GetServiceVersions(ctx context.Context, in *GetServiceVersionsRequest, opts ...grpc.CallOption) (*GetServiceVersionsResponse, error)
+	// StreamServiceVersions streams the versions of a service in chunks, for services with too many versions
+	// to return at once. Over HTTP the chunks are newline-delimited JSON objects.
+	StreamServiceVersions(ctx context.Context, in *StreamServiceVersionsRequest, opts ...grpc.CallOption) (CatalogService_StreamServiceVersionsClient, error)
+	// GetServiceHistory returns the versions of a service as a timeline, oldest release first
+	GetServiceHistory(ctx context.Context, in *GetServiceHistoryRequest, opts ...grpc.CallOption) (*GetServiceHistoryResponse, error)
+	// ListRecentVersions returns versions across all services, most recently updated first
+	ListRecentVersions(ctx context.Context, in *ListRecentVersionsRequest, opts ...grpc.CallOption) (*ListRecentVersionsResponse, error)
+	// SearchVersions finds versions across all services whose version string or description matches a query
+	SearchVersions(ctx context.Context, in *SearchVersionsRequest, opts ...grpc.CallOption) (*SearchVersionsResponse, error)
+	// DiffServices compares two services, or two versions of one service, field by field
+	DiffServices(ctx context.Context, in *DiffServicesRequest, opts ...grpc.CallOption) (*DiffServicesResponse, error)
+	// DescribeCatalog returns aggregate statistics over the catalog
+	DescribeCatalog(ctx context.Context, in *DescribeCatalogRequest, opts ...grpc.CallOption) (*DescribeCatalogResponse, error)
+	// ListOrganizations returns the organizations owning services or given a display name, with their display names
+	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	// ActivateVersionAcrossServices makes a version string the only active version of every service that has it (admin only)
+	ActivateVersionAcrossServices(ctx context.Context, in *ActivateVersionAcrossServicesRequest, opts ...grpc.CallOption) (*ActivateVersionAcrossServicesResponse, error)
+	// CreateServices adds a batch of new services and reports the outcome of each, optionally all or nothing (admin only)
+	CreateServices(ctx context.Context, in *CreateServicesRequest, opts ...grpc.CallOption) (*CreateServicesResponse, error)
+	// TouchService sets a service's updated_at to now and changes nothing else, e.g. to mark it reviewed (admin only)
+	TouchService(ctx context.Context, in *TouchServiceRequest, opts ...grpc.CallOption) (*TouchServiceResponse, error)
+	// ListAuditEvents returns recorded catalog changes, newest first, with optional filters (admin only)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// ValidateCatalog dry-runs the load-time validation of a services file, reporting every problem without applying it
+	ValidateCatalog(ctx context.Context, in *ValidateCatalogRequest, opts ...grpc.CallOption) (*ValidateCatalogResponse, error)
 }
 
 type catalogServiceClient struct {
@@ -47,6 +79,15 @@ func (c *catalogServiceClient) ListServices(ctx context.Context, in *ListService
 	return out, nil
 }
 
+func (c *catalogServiceClient) CountServices(ctx context.Context, in *CountServicesRequest, opts ...grpc.CallOption) (*CountServicesResponse, error) {
+	out := new(CountServicesResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/CountServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error) {
 	out := new(GetServiceResponse)
 	err := c.cc.Invoke(ctx, "/v1.CatalogService/GetService", in, out, opts...)
@@ -56,6 +97,24 @@ func (c *catalogServiceClient) GetService(ctx context.Context, in *GetServiceReq
 	return out, nil
 }
 
+func (c *catalogServiceClient) BatchGetServices(ctx context.Context, in *BatchGetServicesRequest, opts ...grpc.CallOption) (*BatchGetServicesResponse, error) {
+	out := new(BatchGetServicesResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/BatchGetServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListServicesDelta(ctx context.Context, in *ListServicesDeltaRequest, opts ...grpc.CallOption) (*ListServicesDeltaResponse, error) {
+	out := new(ListServicesDeltaResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ListServicesDelta", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) GetServiceVersions(ctx context.Context, in *GetServiceVersionsRequest, opts ...grpc.CallOption) (*GetServiceVersionsResponse, error) {
 	out := new(GetServiceVersionsResponse)
 	err := c.cc.Invoke(ctx, "/v1.CatalogService/GetServiceVersions", in, out, opts...)
@@ -65,16 +124,179 @@ func (c *catalogServiceClient) GetServiceVersions(ctx context.Context, in *GetSe
 	return out, nil
 }
 
+func (c *catalogServiceClient) StreamServiceVersions(ctx context.Context, in *StreamServiceVersionsRequest, opts ...grpc.CallOption) (CatalogService_StreamServiceVersionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], "/v1.CatalogService/StreamServiceVersions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogServiceStreamServiceVersionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogService_StreamServiceVersionsClient interface {
+	Recv() (*StreamServiceVersionsResponse, error)
+	grpc.ClientStream
+}
+
+type catalogServiceStreamServiceVersionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogServiceStreamServiceVersionsClient) Recv() (*StreamServiceVersionsResponse, error) {
+	m := new(StreamServiceVersionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogServiceClient) GetServiceHistory(ctx context.Context, in *GetServiceHistoryRequest, opts ...grpc.CallOption) (*GetServiceHistoryResponse, error) {
+	out := new(GetServiceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/GetServiceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListRecentVersions(ctx context.Context, in *ListRecentVersionsRequest, opts ...grpc.CallOption) (*ListRecentVersionsResponse, error) {
+	out := new(ListRecentVersionsResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ListRecentVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SearchVersions(ctx context.Context, in *SearchVersionsRequest, opts ...grpc.CallOption) (*SearchVersionsResponse, error) {
+	out := new(SearchVersionsResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/SearchVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DiffServices(ctx context.Context, in *DiffServicesRequest, opts ...grpc.CallOption) (*DiffServicesResponse, error) {
+	out := new(DiffServicesResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/DiffServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DescribeCatalog(ctx context.Context, in *DescribeCatalogRequest, opts ...grpc.CallOption) (*DescribeCatalogResponse, error) {
+	out := new(DescribeCatalogResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/DescribeCatalog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ListOrganizations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ActivateVersionAcrossServices(ctx context.Context, in *ActivateVersionAcrossServicesRequest, opts ...grpc.CallOption) (*ActivateVersionAcrossServicesResponse, error) {
+	out := new(ActivateVersionAcrossServicesResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ActivateVersionAcrossServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateServices(ctx context.Context, in *CreateServicesRequest, opts ...grpc.CallOption) (*CreateServicesResponse, error) {
+	out := new(CreateServicesResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/CreateServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) TouchService(ctx context.Context, in *TouchServiceRequest, opts ...grpc.CallOption) (*TouchServiceResponse, error) {
+	out := new(TouchServiceResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/TouchService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ValidateCatalog(ctx context.Context, in *ValidateCatalogRequest, opts ...grpc.CallOption) (*ValidateCatalogResponse, error) {
+	out := new(ValidateCatalogResponse)
+	err := c.cc.Invoke(ctx, "/v1.CatalogService/ValidateCatalog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceServer is the server API for CatalogService service.
 // All implementations must embed UnimplementedCatalogServiceServer
 // for forward compatibility
 type CatalogServiceServer interface {
 	// ListServices returns a list of services with filtering, sorting, and pagination
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	// CountServices returns how many services match the ListServices filters, without returning them
+	CountServices(context.Context, *CountServicesRequest) (*CountServicesResponse, error)
 	// GetService returns details for a single service
 	GetService(context.Context, *GetServiceRequest) (*GetServiceResponse, error)
+	// BatchGetServices returns several services by ID in one call, listing the IDs that were not found.
+	// Over HTTP the IDs may be comma-separated: /v1/services:batchGet?ids=svc-1,svc-2
+	BatchGetServices(context.Context, *BatchGetServicesRequest) (*BatchGetServicesResponse, error)
+	// ListServicesDelta returns the services changed since a token from an earlier call, for clients syncing a cached catalog
+	ListServicesDelta(context.Context, *ListServicesDeltaRequest) (*ListServicesDeltaResponse, error)
 	// GetServiceVersions returns all versions of a service
 	GetServiceVersions(context.Context, *GetServiceVersionsRequest) (*GetServiceVersionsResponse, error)
+	// StreamServiceVersions streams the versions of a service in chunks, for services with too many versions
+	// to return at once. Over HTTP the chunks are newline-delimited JSON objects.
+	StreamServiceVersions(*StreamServiceVersionsRequest, CatalogService_StreamServiceVersionsServer) error
+	// GetServiceHistory returns the versions of a service as a timeline, oldest release first
+	GetServiceHistory(context.Context, *GetServiceHistoryRequest) (*GetServiceHistoryResponse, error)
+	// ListRecentVersions returns versions across all services, most recently updated first
+	ListRecentVersions(context.Context, *ListRecentVersionsRequest) (*ListRecentVersionsResponse, error)
+	// SearchVersions finds versions across all services whose version string or description matches a query
+	SearchVersions(context.Context, *SearchVersionsRequest) (*SearchVersionsResponse, error)
+	// DiffServices compares two services, or two versions of one service, field by field
+	DiffServices(context.Context, *DiffServicesRequest) (*DiffServicesResponse, error)
+	// DescribeCatalog returns aggregate statistics over the catalog
+	DescribeCatalog(context.Context, *DescribeCatalogRequest) (*DescribeCatalogResponse, error)
+	// ListOrganizations returns the organizations owning services or given a display name, with their display names
+	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	// ActivateVersionAcrossServices makes a version string the only active version of every service that has it (admin only)
+	ActivateVersionAcrossServices(context.Context, *ActivateVersionAcrossServicesRequest) (*ActivateVersionAcrossServicesResponse, error)
+	// CreateServices adds a batch of new services and reports the outcome of each, optionally all or nothing (admin only)
+	CreateServices(context.Context, *CreateServicesRequest) (*CreateServicesResponse, error)
+	// TouchService sets a service's updated_at to now and changes nothing else, e.g. to mark it reviewed (admin only)
+	TouchService(context.Context, *TouchServiceRequest) (*TouchServiceResponse, error)
+	// ListAuditEvents returns recorded catalog changes, newest first, with optional filters (admin only)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// ValidateCatalog dry-runs the load-time validation of a services file, reporting every problem without applying it
+	ValidateCatalog(context.Context, *ValidateCatalogRequest) (*ValidateCatalogResponse, error)
 	mustEmbedUnimplementedCatalogServiceServer()
 }
 
@@ -85,12 +307,57 @@ type UnimplementedCatalogServiceServer struct {
 func (UnimplementedCatalogServiceServer) ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
 }
+func (UnimplementedCatalogServiceServer) CountServices(context.Context, *CountServicesRequest) (*CountServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountServices not implemented")
+}
 func (UnimplementedCatalogServiceServer) GetService(context.Context, *GetServiceRequest) (*GetServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetService not implemented")
 }
+func (UnimplementedCatalogServiceServer) BatchGetServices(context.Context, *BatchGetServicesRequest) (*BatchGetServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetServices not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListServicesDelta(context.Context, *ListServicesDeltaRequest) (*ListServicesDeltaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServicesDelta not implemented")
+}
 func (UnimplementedCatalogServiceServer) GetServiceVersions(context.Context, *GetServiceVersionsRequest) (*GetServiceVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetServiceVersions not implemented")
 }
+func (UnimplementedCatalogServiceServer) StreamServiceVersions(*StreamServiceVersionsRequest, CatalogService_StreamServiceVersionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamServiceVersions not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetServiceHistory(context.Context, *GetServiceHistoryRequest) (*GetServiceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceHistory not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListRecentVersions(context.Context, *ListRecentVersionsRequest) (*ListRecentVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentVersions not implemented")
+}
+func (UnimplementedCatalogServiceServer) SearchVersions(context.Context, *SearchVersionsRequest) (*SearchVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchVersions not implemented")
+}
+func (UnimplementedCatalogServiceServer) DiffServices(context.Context, *DiffServicesRequest) (*DiffServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffServices not implemented")
+}
+func (UnimplementedCatalogServiceServer) DescribeCatalog(context.Context, *DescribeCatalogRequest) (*DescribeCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeCatalog not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedCatalogServiceServer) ActivateVersionAcrossServices(context.Context, *ActivateVersionAcrossServicesRequest) (*ActivateVersionAcrossServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateVersionAcrossServices not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateServices(context.Context, *CreateServicesRequest) (*CreateServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServices not implemented")
+}
+func (UnimplementedCatalogServiceServer) TouchService(context.Context, *TouchServiceRequest) (*TouchServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TouchService not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedCatalogServiceServer) ValidateCatalog(context.Context, *ValidateCatalogRequest) (*ValidateCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCatalog not implemented")
+}
 func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
 
 // UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -122,6 +389,24 @@ func _CatalogService_ListServices_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_CountServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CountServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/CountServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CountServices(ctx, req.(*CountServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_GetService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetServiceRequest)
 	if err := dec(in); err != nil {
@@ -140,6 +425,42 @@ func _CatalogService_GetService_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_BatchGetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).BatchGetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/BatchGetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).BatchGetServices(ctx, req.(*BatchGetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListServicesDelta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesDeltaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListServicesDelta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ListServicesDelta",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListServicesDelta(ctx, req.(*ListServicesDeltaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_GetServiceVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetServiceVersionsRequest)
 	if err := dec(in); err != nil {
@@ -158,6 +479,225 @@ func _CatalogService_GetServiceVersions_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_StreamServiceVersions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServiceVersionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).StreamServiceVersions(m, &catalogServiceStreamServiceVersionsServer{stream})
+}
+
+type CatalogService_StreamServiceVersionsServer interface {
+	Send(*StreamServiceVersionsResponse) error
+	grpc.ServerStream
+}
+
+type catalogServiceStreamServiceVersionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogServiceStreamServiceVersionsServer) Send(m *StreamServiceVersionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CatalogService_GetServiceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetServiceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/GetServiceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetServiceHistory(ctx, req.(*GetServiceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListRecentVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListRecentVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ListRecentVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListRecentVersions(ctx, req.(*ListRecentVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SearchVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SearchVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/SearchVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SearchVersions(ctx, req.(*SearchVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DiffServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DiffServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/DiffServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DiffServices(ctx, req.(*DiffServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DescribeCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DescribeCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/DescribeCatalog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DescribeCatalog(ctx, req.(*DescribeCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ListOrganizations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListOrganizations(ctx, req.(*ListOrganizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ActivateVersionAcrossServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateVersionAcrossServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ActivateVersionAcrossServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ActivateVersionAcrossServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ActivateVersionAcrossServices(ctx, req.(*ActivateVersionAcrossServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/CreateServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateServices(ctx, req.(*CreateServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_TouchService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).TouchService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/TouchService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).TouchService(ctx, req.(*TouchServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ValidateCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ValidateCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CatalogService/ValidateCatalog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ValidateCatalog(ctx, req.(*ValidateCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -169,15 +709,77 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListServices",
 			Handler:    _CatalogService_ListServices_Handler,
 		},
+		{
+			MethodName: "CountServices",
+			Handler:    _CatalogService_CountServices_Handler,
+		},
 		{
 			MethodName: "GetService",
 			Handler:    _CatalogService_GetService_Handler,
 		},
+		{
+			MethodName: "BatchGetServices",
+			Handler:    _CatalogService_BatchGetServices_Handler,
+		},
+		{
+			MethodName: "ListServicesDelta",
+			Handler:    _CatalogService_ListServicesDelta_Handler,
+		},
 		{
 			MethodName: "GetServiceVersions",
 			Handler:    _CatalogService_GetServiceVersions_Handler,
 		},
+		{
+			MethodName: "GetServiceHistory",
+			Handler:    _CatalogService_GetServiceHistory_Handler,
+		},
+		{
+			MethodName: "ListRecentVersions",
+			Handler:    _CatalogService_ListRecentVersions_Handler,
+		},
+		{
+			MethodName: "SearchVersions",
+			Handler:    _CatalogService_SearchVersions_Handler,
+		},
+		{
+			MethodName: "DiffServices",
+			Handler:    _CatalogService_DiffServices_Handler,
+		},
+		{
+			MethodName: "DescribeCatalog",
+			Handler:    _CatalogService_DescribeCatalog_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _CatalogService_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "ActivateVersionAcrossServices",
+			Handler:    _CatalogService_ActivateVersionAcrossServices_Handler,
+		},
+		{
+			MethodName: "CreateServices",
+			Handler:    _CatalogService_CreateServices_Handler,
+		},
+		{
+			MethodName: "TouchService",
+			Handler:    _CatalogService_TouchService_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _CatalogService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ValidateCatalog",
+			Handler:    _CatalogService_ValidateCatalog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamServiceVersions",
+			Handler:       _CatalogService_StreamServiceVersions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/catalog.proto",
 }